@@ -0,0 +1,141 @@
+// Package commands implements the inventory binary's subcommands. Every
+// command reads its settings through the config package.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"main/config"
+	"main/migrations"
+	service "main/services"
+	"os"
+
+	"gorm.io/gorm"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(cfg *config.Config, args []string) error
+}
+
+var commandList = []command{
+	{"serve", "start the HTTP server (default)", Serve},
+	{"migrate", "apply or roll back schema migrations: migrate up|down [--steps N]|status", Migrate},
+	{"seed", "insert generated inventory items: seed [--count N]", Seed},
+	{"export", "write all inventory items as JSON lines: export [--file PATH]", Export},
+	{"import", "create inventory items from JSON lines: import [--file PATH]", Import},
+}
+
+// Run executes the subcommand named by args[0] and returns the process exit
+// code. Without arguments the server is started.
+func Run(args []string) int {
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(os.Stdout)
+		return 0
+	}
+
+	for _, cmd := range commandList {
+		if cmd.name == name {
+			cfg, err := config.Load()
+			if err != nil {
+				log.Print(err)
+				return 1
+			}
+
+			if err := cmd.run(cfg, args); err != nil {
+				log.Printf("%s: %v", name, err)
+				return 1
+			}
+			return 0
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage(os.Stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: inventory <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commandList {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// openSQL connects to the SQL database of the configured backend, or returns
+// nil for backends without a SQL schema.
+func openSQL(cfg *config.Config) (*gorm.DB, error) {
+	switch cfg.Backend {
+	case config.BackendPostgres:
+		if err := config.PostgresConnect(cfg.Postgres); err != nil {
+			return nil, err
+		}
+		return config.PG, nil
+	case config.BackendSQLite:
+		if err := config.SQLiteConnect(cfg.SQLite); err != nil {
+			return nil, err
+		}
+		return config.SQLite, nil
+	default:
+		return nil, nil
+	}
+}
+
+func newMigrator(db *gorm.DB) (*migrations.Migrator, error) {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return nil, fmt.Errorf("error loading migrations: %w", err)
+	}
+	return migrator, nil
+}
+
+// openStore connects to the configured backend, applies pending migrations
+// and returns its store.
+func openStore(cfg *config.Config) (service.InventoryStore, error) {
+	db, err := openSQL(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if db != nil {
+		migrator, err := newMigrator(db)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return nil, fmt.Errorf("error applying migrations: %w", err)
+		}
+
+		if cfg.Backend == config.BackendSQLite {
+			return service.NewSQLiteStore(db), nil
+		}
+		return service.NewPostgresStore(db), nil
+	}
+
+	switch cfg.Backend {
+	case config.BackendMongo:
+		if err := config.InitMongoDB(cfg.Mongo); err != nil {
+			return nil, err
+		}
+		store := service.NewMongoStore(config.InventoryCollection)
+		if err := store.EnsureIndexes(context.Background()); err != nil {
+			return nil, fmt.Errorf("error creating MongoDB indexes: %w", err)
+		}
+		return store, nil
+	case config.BackendMemory:
+		log.Println("Using in-memory inventory store; data will not survive a restart")
+		return service.NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown INVENTORY_BACKEND %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"main/config"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+func Migrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New("expected up, down or status")
+	}
+	action, args := args[0], args[1:]
+
+	flags := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	steps := flags.Int("steps", 1, "number of migrations to roll back (down only)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openSQL(cfg)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return fmt.Errorf("backend %q has no SQL schema to migrate", cfg.Backend)
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return nil
+
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No applied migrations to roll back")
+		}
+		return nil
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate action %q, expected up, down or status", action)
+	}
+}
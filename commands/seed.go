@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"main/config"
+	"main/models"
+	"math/rand"
+)
+
+var (
+	seedAdjectives = []string{"Compact", "Wireless", "Heavy-Duty", "Portable", "Premium", "Classic", "Smart", "Eco"}
+	seedNouns      = []string{"Headphones", "Drill", "Lamp", "Keyboard", "Backpack", "Kettle", "Monitor", "Speaker"}
+	seedVendors    = []string{"Acme Corp", "Globex", "Initech", "Umbrella Supplies", "Stark Industries"}
+	seedCurrencies = []string{"USD", "EUR", "INR"}
+)
+
+func Seed(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	count := flags.Int("count", 10, "number of items to create")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *count < 1 {
+		return errors.New("--count must be at least 1")
+	}
+
+	store, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for i := 0; i < *count; i++ {
+		item := &models.Inventory{
+			Name:     seedAdjectives[rand.Intn(len(seedAdjectives))] + " " + seedNouns[rand.Intn(len(seedNouns))],
+			Price:    100 + rand.Intn(99900),
+			Currency: seedCurrencies[rand.Intn(len(seedCurrencies))],
+			Discount: rand.Intn(30),
+			Vendor:   seedVendors[rand.Intn(len(seedVendors))],
+		}
+		if _, err := store.CreateItem(ctx, item); err != nil {
+			return fmt.Errorf("failed to create item %d: %w", i+1, err)
+		}
+	}
+
+	fmt.Printf("Created %d inventory items\n", *count)
+	return nil
+}
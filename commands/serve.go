@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"main/config"
+	"main/controllers"
+	manager "main/managers"
+	"main/notifications"
+	"main/routes"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// Serve starts the HTTP server, the sweeper that expires reservations and
+// the low-stock evaluator, and shuts them down gracefully on SIGINT or
+// SIGTERM.
+func Serve(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", cfg.Server.Addr, "listen address")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	e := echo.New()
+	e.Server.ReadTimeout = cfg.Server.ReadTimeout
+	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.Server.IdleTimeout = cfg.Server.IdleTimeout
+
+	inventoryManager := manager.NewInventoryManager(store)
+	inventoryManager.ReservationTTL = cfg.Reservations.TTL
+	inventoryManager.MaxReservationTTL = cfg.Reservations.MaxTTL
+	inventoryManager.Notifier = alertNotifier(cfg.Alerts)
+
+	inventoryController := &controllers.InventoryController{
+		Validate:         validator.New(),
+		InventoryManager: inventoryManager,
+	}
+
+	routes.RegisterInventoryRoutes(e, inventoryController)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go inventoryManager.SweepReservations(ctx, cfg.Reservations.SweepInterval)
+	go inventoryManager.WatchLowStock(ctx, cfg.Alerts.EvaluationInterval)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.Start(*addr)
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	return e.Shutdown(shutdownCtx)
+}
+
+// alertNotifier logs new low-stock alerts and, if a webhook is configured,
+// posts them to it.
+func alertNotifier(cfg config.AlertConfig) manager.AlertNotifier {
+	if cfg.WebhookURL == "" {
+		return notifications.LogNotifier{}
+	}
+	return notifications.Multi{
+		notifications.LogNotifier{},
+		&notifications.WebhookNotifier{URL: cfg.WebhookURL, Client: &http.Client{Timeout: cfg.WebhookTimeout}},
+	}
+}
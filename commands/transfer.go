@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"main/config"
+	manager "main/managers"
+	"main/models"
+	"os"
+	"strings"
+)
+
+const exportPageSize = 500
+
+// exportedItem is one line of an export: an item together with what refers
+// to it by ID, which means nothing to another backend.
+type exportedItem struct {
+	models.Inventory
+	// CategoryPath names the item's category and its ancestors, from the
+	// top level down.
+	CategoryPath []string         `json:"category_path,omitempty"`
+	Lots         []*models.Lot    `json:"lots,omitempty"`
+	Serials      []*models.Serial `json:"serials,omitempty"`
+}
+
+// Export writes every inventory item, with its category path, lots and
+// serial numbers, as one JSON object per line, to stdout or to --file.
+func Export(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := flags.String("file", "", "output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	ctx := context.Background()
+	categories, err := store.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	categoriesByID := make(map[string]*models.Category, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+
+	w := bufio.NewWriter(out)
+	encoder := json.NewEncoder(w)
+	query := models.ItemQuery{Limit: exportPageSize}
+	count := 0
+	for {
+		page, err := store.GetItems(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range page.Items {
+			record := exportedItem{Inventory: *item, CategoryPath: categoryPath(categoriesByID, item.CategoryID)}
+			if record.Lots, _, err = store.GetLots(ctx, models.LotQuery{ItemIDs: []string{item.ID}}); err != nil {
+				return err
+			}
+			if record.Serials, _, err = store.GetSerials(ctx, models.SerialQuery{ItemID: item.ID}); err != nil {
+				return err
+			}
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		count += len(page.Items)
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	log.Printf("Exported %d inventory items", count)
+	return nil
+}
+
+// categoryPath returns the names from the top-level category down to id.
+func categoryPath(categoriesByID map[string]*models.Category, id *string) []string {
+	var path []string
+	seen := make(map[string]bool)
+	for id != nil && !seen[*id] {
+		category, ok := categoriesByID[*id]
+		if !ok {
+			break
+		}
+		seen[*id] = true
+		path = append([]string{category.Name}, path...)
+		id = category.ParentID
+	}
+	return path
+}
+
+// Import reads items in the format written by Export, from stdin or from
+// --file, and creates each one in its own transaction. The backend assigns
+// new IDs and the category path is looked up, creating the categories that
+// are missing. On-hand stock is carried over as an adjustment in the
+// ledger, not kept at any location, and then divided among the item's lots
+// and serial numbers. Reservations are not carried over, so reserved and
+// returned units come back in stock.
+func Import(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := flags.String("file", "", "input file (default stdin)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	store, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	inventoryManager := manager.NewInventoryManager(store)
+	ctx := context.Background()
+	decoder := json.NewDecoder(bufio.NewReader(in))
+	count := 0
+	for {
+		var record exportedItem
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("invalid item after %d imported: %w", count, err)
+		}
+
+		err := store.WithTransaction(ctx, func(ctx context.Context) error {
+			return importItem(ctx, inventoryManager, &record)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import item %q: %w", record.Name, err)
+		}
+		count++
+	}
+
+	log.Printf("Imported %d inventory items", count)
+	return nil
+}
+
+// importItem creates the item of record with its stock. The item starts out
+// neither lot-tracked nor serialized, so that its stock can come in as one
+// adjustment, and becomes so once its lots and serial numbers are in place.
+func importItem(ctx context.Context, inventoryManager *manager.InventoryManager, record *exportedItem) error {
+	store := inventoryManager.Store
+	item := record.Inventory
+	onHand, lotTracked, serialized := item.OnHand, item.LotTracked, item.Serialized
+
+	lotted, serialsOnHand := 0, 0
+	for _, lot := range record.Lots {
+		lotted += lot.OnHand
+	}
+	for _, serial := range record.Serials {
+		if serial.OnHand() {
+			serialsOnHand++
+		}
+	}
+	if lotted > onHand || serialsOnHand > onHand {
+		return fmt.Errorf("its lots or serial numbers hold more than the %d units on hand", onHand)
+	}
+
+	categoryID, err := importCategory(ctx, inventoryManager, record.CategoryPath)
+	if err != nil {
+		return err
+	}
+
+	item.ID, item.CategoryID = "", categoryID
+	item.LotTracked, item.Serialized = false, false
+	created, err := inventoryManager.CreateItem(ctx, &item)
+	if err != nil {
+		return err
+	}
+	if onHand > 0 {
+		if _, err := inventoryManager.AdjustStock(ctx, created.ID, onHand, "import", ""); err != nil {
+			return err
+		}
+	}
+
+	for _, lot := range record.Lots {
+		// Returns are not carried over, so neither is their quarantine.
+		lot.ItemID, lot.Quarantined = created.ID, 0
+		if _, err := store.CreateLot(ctx, lot); err != nil {
+			return fmt.Errorf("lot %s: %w", lot.LotNumber, err)
+		}
+	}
+	for _, serial := range record.Serials {
+		serial.ItemID = created.ID
+		if serial.OnHand() {
+			serial.Status = models.SerialInStock
+		}
+		if _, err := store.CreateSerial(ctx, serial); err != nil {
+			return fmt.Errorf("serial number %s: %w", serial.SerialNumber, err)
+		}
+	}
+
+	if lotTracked || serialized {
+		_, err := store.PatchItem(ctx, created.ID, models.InventoryPatch{LotTracked: &lotTracked, Serialized: &serialized})
+		return err
+	}
+	return nil
+}
+
+// importCategory returns the ID of the category at path, creating it and
+// any missing ancestors. An empty path is no category.
+func importCategory(ctx context.Context, inventoryManager *manager.InventoryManager, path []string) (*string, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	categories, err := inventoryManager.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *string
+	for _, name := range path {
+		var found *models.Category
+		for _, category := range categories {
+			if sameCategoryParent(category.ParentID, parentID) && strings.EqualFold(category.Name, name) {
+				found = category
+				break
+			}
+		}
+		if found == nil {
+			if found, err = inventoryManager.CreateCategory(ctx, &models.Category{Name: name, ParentID: parentID}); err != nil {
+				return nil, fmt.Errorf("category %q: %w", name, err)
+			}
+		}
+		parentID = &found.ID
+	}
+	return parentID, nil
+}
+
+func sameCategoryParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
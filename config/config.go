@@ -1,88 +1,247 @@
 package config
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"log"
-
-	"main/models"
-
-	"os"
+	"io/fs"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
 )
 
+const (
+	BackendPostgres = "postgres"
+	BackendMongo    = "mongo"
+	BackendMemory   = "memory"
+	BackendSQLite   = "sqlite"
+)
+
+// Config holds every setting of the inventory service. It is read from the
+// environment, optionally seeded from a .env file, by Load.
+type Config struct {
+	Backend      string `env:"INVENTORY_BACKEND" envDefault:"postgres"`
+	Server       ServerConfig
+	Reservations ReservationConfig
+	Alerts       AlertConfig
+	Postgres     PostgresConfig
+	Mongo        MongoConfig
+	SQLite       SQLiteConfig
+}
+
+type ServerConfig struct {
+	Addr            string        `env:"SERVER_ADDR" envDefault:":8080"`
+	ReadTimeout     time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"15s"`
+	WriteTimeout    time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"15s"`
+	IdleTimeout     time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"60s"`
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" envDefault:"10s"`
+}
+
+// ReservationConfig controls how long reservations hold stock and how often
+// expired ones are released.
+type ReservationConfig struct {
+	TTL           time.Duration `env:"RESERVATION_TTL" envDefault:"15m"`
+	MaxTTL        time.Duration `env:"RESERVATION_MAX_TTL" envDefault:"24h"`
+	SweepInterval time.Duration `env:"RESERVATION_SWEEP_INTERVAL" envDefault:"30s"`
+}
+
+// AlertConfig controls the low-stock evaluator. When WebhookURL is set, new
+// alerts are posted to it as well as logged.
+type AlertConfig struct {
+	EvaluationInterval time.Duration `env:"ALERT_EVALUATION_INTERVAL" envDefault:"1m"`
+	WebhookURL         string        `env:"ALERT_WEBHOOK_URL"`
+	WebhookTimeout     time.Duration `env:"ALERT_WEBHOOK_TIMEOUT" envDefault:"5s"`
+}
+
+// PostgresConfig describes the Postgres connection. DSN, when set, takes
+// precedence over the individual host, port, user and database settings.
+type PostgresConfig struct {
+	DSN             string        `env:"POSTGRES_DSN"`
+	Host            string        `env:"POSTGRES_HOST" envDefault:"localhost"`
+	Port            int           `env:"POSTGRES_PORT" envDefault:"5432"`
+	User            string        `env:"POSTGRES_USER"`
+	Password        string        `env:"POSTGRES_PASSWORD"`
+	DB              string        `env:"POSTGRES_DB"`
+	SSLMode         string        `env:"POSTGRES_SSLMODE" envDefault:"disable"`
+	MaxOpenConns    int           `env:"POSTGRES_MAX_OPEN_CONNS" envDefault:"25"`
+	MaxIdleConns    int           `env:"POSTGRES_MAX_IDLE_CONNS" envDefault:"5"`
+	ConnMaxLifetime time.Duration `env:"POSTGRES_CONN_MAX_LIFETIME" envDefault:"30m"`
+}
+
 type MongoConfig struct {
-	MONGO_URI         string `env:"MONGO_URL" envDefault:"mongodb://localhost:27017"`
-	MongoDBCollection string `env:"MONGODB_COLLECTION" envDefault:"inventories"`
-	MongoDBName       string `env:"MONGODB_DB_NAME" envDefault:"inventoryDB"`
-	MongoPort string `env:"MONGO_PORT" envDefault:"8080"`
+	URI            string        `env:"MONGO_URL" envDefault:"mongodb://localhost:27017"`
+	Database       string        `env:"MONGODB_DB_NAME" envDefault:"inventoryDB"`
+	Collection     string        `env:"MONGODB_COLLECTION" envDefault:"inventories"`
+	MaxPoolSize    uint64        `env:"MONGO_MAX_POOL_SIZE" envDefault:"100"`
+	MinPoolSize    uint64        `env:"MONGO_MIN_POOL_SIZE" envDefault:"0"`
+	ConnectTimeout time.Duration `env:"MONGO_CONNECT_TIMEOUT" envDefault:"10s"`
+}
+
+type SQLiteConfig struct {
+	Path string `env:"SQLITE_PATH" envDefault:"inventory.db"`
 }
 
-var MongoClient *mongo.Client
-var InventoryCollection *mongo.Collection
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
 
-func LoadEnv() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatalf("Error loading in .env file.....")
+// Load reads the configuration from the environment and validates it. A
+// .env file in the working directory is loaded first if present; variables
+// already set in the environment win over it.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error loading .env file: %w", err)
 	}
-}
 
-func InitMongoDB() {
-	var config MongoConfig
-	if err := env.Parse(&config); err != nil {
-		log.Fatalf("Failed to parse environment variables: %v", err)
+	var cfg Config
+	if err := env.Parse(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid environment: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
 
-	var err error
-	MongoClient, err = mongo.Connect(ctx, options.Client().ApplyURI(config.MONGO_URI))
-	if err != nil {
-		log.Fatalf("MongoDB connection failed: %v", err)
+// Validate checks the server, reservation and alert settings and the settings of the selected
+// backend, reporting every problem at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("SERVER_ADDR must not be empty"))
+	}
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", c.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout},
+		{"RESERVATION_TTL", c.Reservations.TTL},
+		{"RESERVATION_MAX_TTL", c.Reservations.MaxTTL},
+		{"RESERVATION_SWEEP_INTERVAL", c.Reservations.SweepInterval},
+		{"ALERT_EVALUATION_INTERVAL", c.Alerts.EvaluationInterval},
+		{"ALERT_WEBHOOK_TIMEOUT", c.Alerts.WebhookTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %s", timeout.name, timeout.value))
+		}
+	}
+	if c.Reservations.TTL > c.Reservations.MaxTTL {
+		errs = append(errs, fmt.Errorf("RESERVATION_TTL (%s) must not exceed RESERVATION_MAX_TTL (%s)", c.Reservations.TTL, c.Reservations.MaxTTL))
+	}
+	if c.Alerts.WebhookURL != "" {
+		if uri, err := url.Parse(c.Alerts.WebhookURL); err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "" {
+			errs = append(errs, fmt.Errorf("ALERT_WEBHOOK_URL must be an http:// or https:// URL, got %q", c.Alerts.WebhookURL))
+		}
 	}
 
-	InventoryCollection = MongoClient.Database(config.MongoDBName).Collection(config.MongoDBCollection)
+	switch c.Backend {
+	case BackendPostgres:
+		errs = append(errs, c.Postgres.validate()...)
+	case BackendMongo:
+		errs = append(errs, c.Mongo.validate()...)
+	case BackendSQLite:
+		if c.SQLite.Path == "" {
+			errs = append(errs, errors.New("SQLITE_PATH must not be empty"))
+		}
+	case BackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("INVENTORY_BACKEND must be one of %s, %s, %s or %s, got %q",
+			BackendPostgres, BackendMongo, BackendSQLite, BackendMemory, c.Backend))
+	}
 
-	log.Println("MongoDB initialized successfully")
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
 }
 
-var PG *gorm.DB
-
-func PostgresConnect() {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatal("Error loading .env file")
+func (c PostgresConfig) validate() []error {
+	var errs []error
+
+	if c.DSN == "" {
+		if c.Host == "" {
+			errs = append(errs, errors.New("POSTGRES_HOST is required when POSTGRES_DSN is not set"))
+		}
+		if c.Port < 1 || c.Port > 65535 {
+			errs = append(errs, fmt.Errorf("POSTGRES_PORT must be between 1 and 65535, got %d", c.Port))
+		}
+		if c.User == "" {
+			errs = append(errs, errors.New("POSTGRES_USER is required when POSTGRES_DSN is not set"))
+		}
+		if c.DB == "" {
+			errs = append(errs, errors.New("POSTGRES_DB is required when POSTGRES_DSN is not set"))
+		}
+		if !contains(sslModes, c.SSLMode) {
+			errs = append(errs, fmt.Errorf("POSTGRES_SSLMODE must be one of %s, got %q", strings.Join(sslModes, ", "), c.SSLMode))
+		}
 	}
 
-	postgresHost := os.Getenv("POSTGRES_HOST")
-	postgresPort := os.Getenv("POSTGRES_PORT")
-	postgresUser := os.Getenv("POSTGRES_USER")
-	postgresPassword := os.Getenv("POSTGRES_PASSWORD")
-	postgresDB := os.Getenv("POSTGRES_DB")
+	if c.MaxOpenConns < 1 {
+		errs = append(errs, fmt.Errorf("POSTGRES_MAX_OPEN_CONNS must be at least 1, got %d", c.MaxOpenConns))
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("POSTGRES_MAX_IDLE_CONNS must be between 0 and POSTGRES_MAX_OPEN_CONNS, got %d", c.MaxIdleConns))
+	}
+	if c.ConnMaxLifetime < 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_CONN_MAX_LIFETIME must not be negative, got %s", c.ConnMaxLifetime))
+	}
 
-	postgresDSN := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		postgresHost, postgresPort, postgresUser, postgresPassword, postgresDB)
+	return errs
+}
 
-	PGDB, err := gorm.Open(postgres.Open(postgresDSN), &gorm.Config{})
-	if err != nil {
-		log.Fatal("Error opening connection to PostgreSQL:", err)
+// ConnectionString returns DSN if set, or a key/value connection string
+// built from the individual settings.
+func (c PostgresConfig) ConnectionString() string {
+	if c.DSN != "" {
+		return c.DSN
 	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		dsnValue(c.Host), c.Port, dsnValue(c.User), dsnValue(c.Password), dsnValue(c.DB), dsnValue(c.SSLMode))
+}
 
-	PG = PGDB
+// dsnEscaper escapes the characters that end or escape a quoted value in a
+// key/value connection string.
+var dsnEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
 
-	err = PG.AutoMigrate(&models.Inventory{}) 
-	if err != nil {
-		log.Fatal("Error migrating models:", err)
+// dsnValue quotes a connection string value, so that values with spaces,
+// quotes or backslashes, or empty ones, stay a single value.
+func dsnValue(value string) string {
+	return "'" + dsnEscaper.Replace(value) + "'"
+}
+
+func (c MongoConfig) validate() []error {
+	var errs []error
+
+	if uri, err := url.Parse(c.URI); err != nil || (uri.Scheme != "mongodb" && uri.Scheme != "mongodb+srv") {
+		errs = append(errs, fmt.Errorf("MONGO_URL must be a mongodb:// or mongodb+srv:// URI, got %q", c.URI))
+	}
+	if c.Database == "" {
+		errs = append(errs, errors.New("MONGODB_DB_NAME must not be empty"))
+	}
+	if c.Collection == "" {
+		errs = append(errs, errors.New("MONGODB_COLLECTION must not be empty"))
+	}
+	if c.MaxPoolSize < 1 || c.MinPoolSize > c.MaxPoolSize {
+		errs = append(errs, fmt.Errorf("MONGO_MIN_POOL_SIZE (%d) must not exceed MONGO_MAX_POOL_SIZE (%d), which must be at least 1", c.MinPoolSize, c.MaxPoolSize))
+	}
+	if c.ConnectTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("MONGO_CONNECT_TIMEOUT must be positive, got %s", c.ConnectTimeout))
 	}
 
-	log.Println("PostgreSQL connected and tables migrated successfully!")
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,20 @@
+package config
+
+import "testing"
+
+func TestConnectionStringQuotesValues(t *testing.T) {
+	c := PostgresConfig{Host: "localhost", Port: 5432, User: "ad min", Password: `it's a \ secret`, DB: "inventory", SSLMode: "disable"}
+
+	want := `host='localhost' port=5432 user='ad min' password='it\'s a \\ secret' dbname='inventory' sslmode='disable'`
+	if got := c.ConnectionString(); got != want {
+		t.Errorf("ConnectionString() = %s, want %s", got, want)
+	}
+}
+
+func TestConnectionStringPrefersDSN(t *testing.T) {
+	c := PostgresConfig{DSN: "postgres://u:p@db/inventory", Host: "localhost", User: "u"}
+
+	if got := c.ConnectionString(); got != c.DSN {
+		t.Errorf("ConnectionString() = %s, want %s", got, c.DSN)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/glebarez/sqlite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var MongoClient *mongo.Client
+var InventoryCollection *mongo.Collection
+
+func InitMongoDB(cfg MongoConfig) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+
+	clientOptions := options.Client().
+		ApplyURI(cfg.URI).
+		SetMaxPoolSize(cfg.MaxPoolSize).
+		SetMinPoolSize(cfg.MinPoolSize).
+		SetConnectTimeout(cfg.ConnectTimeout)
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return fmt.Errorf("MongoDB connection failed: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return fmt.Errorf("MongoDB is not reachable at %s: %w", cfg.URI, err)
+	}
+
+	MongoClient = client
+	InventoryCollection = MongoClient.Database(cfg.Database).Collection(cfg.Collection)
+
+	log.Println("MongoDB initialized successfully")
+	return nil
+}
+
+var PG *gorm.DB
+
+func PostgresConnect(cfg PostgresConfig) error {
+	PGDB, err := gorm.Open(postgres.Open(cfg.ConnectionString()), &gorm.Config{})
+	if err != nil {
+		return fmt.Errorf("error opening connection to PostgreSQL: %w", err)
+	}
+
+	sqlDB, err := PGDB.DB()
+	if err != nil {
+		return fmt.Errorf("error configuring PostgreSQL pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	PG = PGDB
+
+	log.Println("PostgreSQL connected successfully!")
+	return nil
+}
+
+var SQLite *gorm.DB
+
+// SQLiteConnect opens the single-file SQLite database used by sites that
+// cannot run Postgres or MongoDB.
+func SQLiteConnect(cfg SQLiteConfig) error {
+	dsn := cfg.Path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return fmt.Errorf("error opening SQLite database: %w", err)
+	}
+
+	// A single connection serializes writers, so transactions never fail
+	// with SQLITE_BUSY when upgrading from a read to a write lock.
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("error configuring SQLite connection: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	SQLite = db
+
+	log.Printf("SQLite database %s opened successfully", cfg.Path)
+	return nil
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/responses"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetLowStockAlertsHandler lists low-stock alerts, newest first. Only open
+// alerts are listed unless status says otherwise; status=all lists every
+// alert.
+func (c *InventoryController) GetLowStockAlertsHandler(ctx echo.Context) error {
+	query := models.AlertQuery{
+		ItemID: ctx.QueryParam("item_id"),
+		Status: ctx.QueryParam("status"),
+	}
+	switch query.Status {
+	case "":
+		query.Status = models.AlertOpen
+	case "all":
+		query.Status = ""
+	}
+
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	alerts, totalCount, err := c.InventoryManager.GetLowStockAlerts(ctx.Request().Context(), query)
+	if err != nil {
+		if errors.Is(err, manager.ErrInvalidQuery) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch alerts"})
+	}
+
+	alertResponses := make([]responses.LowStockAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		alertResponses = append(alertResponses, responses.NewLowStockAlertResponse(alert))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"alerts":       alertResponses,
+		"totalRecords": totalCount,
+	})
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bomError maps the errors of bill of materials and kit operations to
+// responses.
+func bomError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidBOM), errors.Is(err, manager.ErrInvalidQuantity),
+		errors.Is(err, manager.ErrInvalidMovement):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, service.ErrInsufficientStock):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+// kitResponse responds with the item, including its buildable quantity if
+// it is a kit.
+func (c *InventoryController) kitResponse(ctx echo.Context, status int, item *models.Inventory) error {
+	buildable, err := c.InventoryManager.BuildableQuantities(ctx.Request().Context(), []*models.Inventory{item})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch buildable quantity"})
+	}
+
+	response := responses.NewInventoryResponse(item)
+	if units, ok := buildable[item.ID]; ok {
+		response.BuildableQuantity = &units
+	}
+	return ctx.JSON(status, response)
+}
+
+// bomResponse responds with the kit's bill of materials and buildable
+// quantity.
+func (c *InventoryController) bomResponse(ctx echo.Context, itemID string, components []*models.BOMComponent) error {
+	buildable, err := c.InventoryManager.BuildableQuantities(ctx.Request().Context(), []*models.Inventory{{ID: itemID}})
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch buildable quantity"})
+	}
+
+	var units *int
+	if n, ok := buildable[itemID]; ok {
+		units = &n
+	}
+	return ctx.JSON(http.StatusOK, responses.NewBOMResponse(itemID, components, units))
+}
+
+func (c *InventoryController) GetBOMHandler(ctx echo.Context) error {
+	id := ctx.Param("id")
+
+	components, err := c.InventoryManager.GetBOM(ctx.Request().Context(), id)
+	if err != nil {
+		return bomError(ctx, err, "Failed to fetch bill of materials")
+	}
+
+	return c.bomResponse(ctx, id, components)
+}
+
+func (c *InventoryController) SetBOMHandler(ctx echo.Context) error {
+	id := ctx.Param("id")
+
+	var req requests.BOMRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	components := make([]*models.BOMComponent, 0, len(req.Components))
+	for _, component := range req.Components {
+		components = append(components, &models.BOMComponent{
+			ComponentID: component.ItemID,
+			Quantity:    component.Quantity,
+		})
+	}
+
+	components, err := c.InventoryManager.SetBOM(ctx.Request().Context(), id, components)
+	if err != nil {
+		return bomError(ctx, err, "Failed to set bill of materials")
+	}
+
+	return c.bomResponse(ctx, id, components)
+}
+
+func (c *InventoryController) AssembleKitHandler(ctx echo.Context) error {
+	var req requests.KitRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	item, err := c.InventoryManager.AssembleKit(ctx.Request().Context(), ctx.Param("id"), req.Quantity, req.LocationID, requestUser(ctx, req.User))
+	if err != nil {
+		return bomError(ctx, err, "Failed to assemble kit")
+	}
+
+	return c.kitResponse(ctx, http.StatusOK, item)
+}
+
+func (c *InventoryController) DisassembleKitHandler(ctx echo.Context) error {
+	var req requests.KitRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	item, err := c.InventoryManager.DisassembleKit(ctx.Request().Context(), ctx.Param("id"), req.Quantity, req.LocationID, requestUser(ctx, req.User))
+	if err != nil {
+		return bomError(ctx, err, "Failed to disassemble kit")
+	}
+
+	return c.kitResponse(ctx, http.StatusOK, item)
+}
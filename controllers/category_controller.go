@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// categoryError maps the errors of category operations to responses.
+func categoryError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Category not found"})
+	case errors.Is(err, manager.ErrInvalidCategory):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrCategoryExists), errors.Is(err, manager.ErrCategoryInUse):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateCategoryHandler(ctx echo.Context) error {
+	var req requests.CategoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	category, err := c.InventoryManager.CreateCategory(ctx.Request().Context(), &models.Category{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return categoryError(ctx, err, "Failed to create category")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewCategoryResponse(category))
+}
+
+// GetCategoriesHandler returns the whole category tree, each category with
+// its subcategories in order.
+func (c *InventoryController) GetCategoriesHandler(ctx echo.Context) error {
+	categories, err := c.InventoryManager.GetCategories(ctx.Request().Context())
+	if err != nil {
+		return categoryError(ctx, err, "Failed to fetch categories")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"categories":   responses.NewCategoryTree(categories, nil),
+		"totalRecords": len(categories),
+	})
+}
+
+// GetCategoryByIDHandler returns a category with the tree beneath it.
+func (c *InventoryController) GetCategoryByIDHandler(ctx echo.Context) error {
+	category, err := c.InventoryManager.GetCategoryByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return categoryError(ctx, err, "Failed to fetch category")
+	}
+	categories, err := c.InventoryManager.GetCategories(ctx.Request().Context())
+	if err != nil {
+		return categoryError(ctx, err, "Failed to fetch category")
+	}
+
+	response := responses.NewCategoryResponse(category)
+	response.Children = responses.NewCategoryTree(categories, &category.ID)
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateCategoryHandler renames a category and moves it under another
+// parent.
+func (c *InventoryController) UpdateCategoryHandler(ctx echo.Context) error {
+	var req requests.CategoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	category, err := c.InventoryManager.UpdateCategory(ctx.Request().Context(), ctx.Param("id"), req.Name, req.ParentID)
+	if err != nil {
+		return categoryError(ctx, err, "Failed to update category")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewCategoryResponse(category))
+}
+
+// ReorderCategoriesHandler sets the order of the children of a category, or
+// of the top-level categories.
+func (c *InventoryController) ReorderCategoriesHandler(ctx echo.Context) error {
+	var req requests.CategoryOrderRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	categories, err := c.InventoryManager.ReorderCategories(ctx.Request().Context(), req.ParentID, req.CategoryIDs)
+	if err != nil {
+		return categoryError(ctx, err, "Failed to reorder categories")
+	}
+
+	categoryResponses := make([]responses.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		categoryResponses = append(categoryResponses, responses.NewCategoryResponse(category))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"categories":   categoryResponses,
+		"totalRecords": len(categoryResponses),
+	})
+}
+
+func (c *InventoryController) DeleteCategoryHandler(ctx echo.Context) error {
+	if err := c.InventoryManager.DeleteCategory(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return categoryError(ctx, err, "Failed to delete category")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "Category deleted successfully"})
+}
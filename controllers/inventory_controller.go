@@ -6,9 +6,14 @@ import (
 	"main/models"
 	"main/requests"
 	"main/responses"
-	"strings"
+	service "main/services"
+	"main/utils"
 
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/go-playground/validator/v10"
@@ -20,41 +25,29 @@ type InventoryController struct {
 	InventoryManager *manager.InventoryManager
 }
 
-func parseFlag(flag string) (bool, error) {
-	if flag == "" {
-		return false, nil
-	}
-	flag = strings.ToLower(flag)
-	if flag != "true" && flag != "false" {
-		return false, errors.New("flag must be 'true' or 'false'")
+// itemError maps the errors of writing and looking up items to responses.
+func itemError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidSKU), errors.Is(err, manager.ErrInvalidBarcode),
+		errors.Is(err, manager.ErrInvalidCategory):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, service.ErrSKUExists), errors.Is(err, service.ErrBarcodeExists),
+		errors.Is(err, manager.ErrItemInUse):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
 	}
-
-	return strconv.ParseBool(flag)
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
 }
 
 func (c *InventoryController) CreateItemHandler(ctx echo.Context) error {
-	flagStr := ctx.QueryParam("flag")
-	flag, err := parseFlag(flagStr)
-
-	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-
 	var req requests.InventoryRequest
 	if err := ctx.Bind(&req); err != nil {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
 	}
 
 	if err := c.Validate.Struct(req); err != nil {
-		validationErrors := err.(validator.ValidationErrors)
-		errorMessages := make(map[string]string)
-		for _, fieldError := range validationErrors {
-			errorMessages[fieldError.Field()] = "This field is required"
-		}
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Validation failed",
-			"errors":  errorMessages,
-		})
+		return validationFailed(ctx, err)
 	}
 
 	item := &models.Inventory{
@@ -63,93 +56,224 @@ func (c *InventoryController) CreateItemHandler(ctx echo.Context) error {
 		Currency: req.Currency,
 		Discount: req.Discount,
 		Vendor:   req.Vendor,
+
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		LotTracked:      req.LotTracked,
+		Serialized:      req.Serialized,
+		SKU:             req.SKU,
+		Barcodes:        req.Barcodes,
+		CategoryID:      req.CategoryID,
 	}
 
-	createdItem, err := c.InventoryManager.CreateItem(ctx.Request().Context(), flag, item)
+	createdItem, err := c.InventoryManager.CreateItem(ctx.Request().Context(), item)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to create inventory item"})
+		return itemError(ctx, err, "Failed to create inventory item")
 	}
 
-	response := responses.InventoryResponse{
-		ID:       createdItem.ID,
-		Name:     createdItem.Name,
-		Price:    createdItem.Price,
-		Currency: createdItem.Currency,
-		Discount: createdItem.Discount,
-		Vendor:   createdItem.Vendor,
-	}
+	response := responses.NewInventoryResponse(createdItem)
 
 	return ctx.JSON(http.StatusCreated, response)
 }
 
 func (c *InventoryController) GetItemsHandler(ctx echo.Context) error {
-	flagStr := ctx.QueryParam("flag")
-	flag, err := parseFlag(flagStr)
+	query, err := parseItemQuery(ctx)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
 	}
 
-	items, totalCount, err := c.InventoryManager.GetItems(ctx.Request().Context(), flag)
+	page, err := c.InventoryManager.GetItems(ctx.Request().Context(), query)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		if errors.Is(err, manager.ErrInvalidQuery) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch items"})
 	}
 
-	if len(items) == 0 {
+	if len(page.Items) == 0 {
 		return ctx.JSON(http.StatusNotFound, map[string]string{
 			"message": "No items found",
 		})
 	}
 
+	var levels map[string][]*models.StockLevel
+	if ctx.QueryParam("include") == "locations" {
+		if levels, err = c.InventoryManager.StockLevelsByItem(ctx.Request().Context(), page.Items); err != nil {
+			return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch stock levels"})
+		}
+	}
+
+	buildable, err := c.InventoryManager.BuildableQuantities(ctx.Request().Context(), page.Items)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch buildable quantities"})
+	}
+
 	var itemResponses []responses.InventoryResponse
-	for _, item := range items {
-		itemResponses = append(itemResponses, responses.InventoryResponse{
-			ID:       item.ID,
-			Name:     item.Name,
-			Price:    item.Price,
-			Currency: item.Currency,
-			Discount: item.Discount,
-			Vendor:   item.Vendor,
+	for _, item := range page.Items {
+		itemResponse := responses.NewInventoryResponse(item)
+		if levels != nil {
+			itemResponse.Locations = responses.NewStockLevelResponses(levels[item.ID])
+		}
+		if units, ok := buildable[item.ID]; ok {
+			itemResponse.BuildableQuantity = &units
+		}
+		itemResponses = append(itemResponses, itemResponse)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"items":        itemResponses,
+		"totalRecords": page.TotalCount,
+		"pagination": responses.Pagination{
+			Limit:      page.Limit,
+			Offset:     query.Offset,
+			NextCursor: page.NextCursor,
+			HasMore:    page.NextCursor != "",
+		},
+	})
+}
+
+func (c *InventoryController) SearchItemsHandler(ctx echo.Context) error {
+	limit, err := intQueryParam(ctx, "limit")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	results, err := c.InventoryManager.SearchItems(ctx.Request().Context(), ctx.QueryParam("q"), limit)
+	if err != nil {
+		if errors.Is(err, manager.ErrInvalidQuery) {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to search items"})
+	}
+
+	itemResponses := make([]responses.SearchResultResponse, 0, len(results))
+	for _, result := range results {
+		itemResponses = append(itemResponses, responses.SearchResultResponse{
+			InventoryResponse: responses.NewInventoryResponse(result.Item),
+			Score:             result.Score,
 		})
 	}
 
 	return ctx.JSON(http.StatusOK, map[string]interface{}{
 		"items":        itemResponses,
-		"totalRecords": totalCount,
+		"totalRecords": len(itemResponses),
 	})
 }
 
+// parseItemQuery reads the pagination, filter and sort query parameters of an
+// item listing.
+func parseItemQuery(ctx echo.Context) (models.ItemQuery, error) {
+	query := models.ItemQuery{
+		Cursor: ctx.QueryParam("cursor"),
+		Filter: models.ItemFilter{
+			Vendor:       ctx.QueryParam("vendor"),
+			Currency:     ctx.QueryParam("currency"),
+			NameContains: ctx.QueryParam("name_contains"),
+		},
+	}
 
-func (c *InventoryController) GetItemByIDHandler(ctx echo.Context) error {
-	flagStr := ctx.QueryParam("flag")
-	flag, err := parseFlag(flagStr)
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return query, err
+	}
+	if query.Filter.PriceMin, err = optionalIntQueryParam(ctx, "price_min"); err != nil {
+		return query, err
+	}
+	if query.Filter.PriceMax, err = optionalIntQueryParam(ctx, "price_max"); err != nil {
+		return query, err
+	}
+	if query.Filter.DiscountGT, err = optionalIntQueryParam(ctx, "discount_gt"); err != nil {
+		return query, err
+	}
+	if query.Sort, err = models.ParseSort(ctx.QueryParam("sort")); err != nil {
+		return query, err
+	}
+	if category := ctx.QueryParam("category"); category != "" {
+		query.Filter.CategoryIDs = []string{category}
+	}
+	if value := ctx.QueryParam("below_reorder_point"); value != "" {
+		if query.Filter.BelowReorderPoint, err = strconv.ParseBool(value); err != nil {
+			return query, fmt.Errorf("below_reorder_point must be true or false")
+		}
+	}
+
+	return query, nil
+}
+
+// optionalIntQueryParam parses an optional integer query parameter, returning
+// nil when it is absent.
+func optionalIntQueryParam(ctx echo.Context, name string) (*int, error) {
+	if ctx.QueryParam(name) == "" {
+		return nil, nil
+	}
+
+	n, err := intQueryParam(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// intQueryParam parses an optional integer query parameter, returning 0 when
+// it is absent.
+func intQueryParam(ctx echo.Context, name string) (int, error) {
+	value := ctx.QueryParam(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid flag"})
+		return 0, fmt.Errorf("%s must be an integer", name)
 	}
+	return n, nil
+}
 
+func (c *InventoryController) GetItemByIDHandler(ctx echo.Context) error {
 	id := ctx.Param("id")
 
-	item, err := c.InventoryManager.GetItemByID(ctx.Request().Context(), flag, id)
+	item, err := c.InventoryManager.GetItemByID(ctx.Request().Context(), id)
 	if err != nil {
-		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+		if errors.Is(err, service.ErrItemNotFound) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch item"})
 	}
 
-	return ctx.JSON(http.StatusOK, responses.InventoryResponse{
-		ID:       item.ID,
-		Name:     item.Name,
-		Price:    item.Price,
-		Currency: item.Currency,
-		Discount: item.Discount,
-		Vendor:   item.Vendor,
-	})
+	return c.kitResponse(ctx, http.StatusOK, item)
 }
 
-func (c *InventoryController) UpdateItemHandler(ctx echo.Context) error {
-	flagStr := ctx.QueryParam("flag")
-	flag, err := parseFlag(flagStr)
+// GetItemBySKUHandler looks an item up by its SKU, which may contain
+// escaped slashes.
+func (c *InventoryController) GetItemBySKUHandler(ctx echo.Context) error {
+	sku, err := url.PathUnescape(ctx.Param("sku"))
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid flag value"})
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid sku"})
 	}
 
+	item, err := c.InventoryManager.GetItemBySKU(ctx.Request().Context(), sku)
+	if err != nil {
+		return itemError(ctx, err, "Failed to fetch item")
+	}
+
+	return c.kitResponse(ctx, http.StatusOK, item)
+}
+
+// GetItemByBarcodeHandler looks an item up by a scanned EAN-13, UPC-A or
+// GTIN-14 code.
+func (c *InventoryController) GetItemByBarcodeHandler(ctx echo.Context) error {
+	item, err := c.InventoryManager.GetItemByBarcode(ctx.Request().Context(), ctx.Param("code"))
+	if err != nil {
+		return itemError(ctx, err, "Failed to fetch item")
+	}
+
+	return c.kitResponse(ctx, http.StatusOK, item)
+}
+
+func (c *InventoryController) UpdateItemHandler(ctx echo.Context) error {
 	id := ctx.Param("id")
 
 	var req requests.InventoryRequest
@@ -158,15 +282,7 @@ func (c *InventoryController) UpdateItemHandler(ctx echo.Context) error {
 	}
 
 	if err := c.Validate.Struct(req); err != nil {
-		validationErrors := err.(validator.ValidationErrors)
-		errorMessages := make(map[string]string)
-		for _, fieldError := range validationErrors {
-			errorMessages[fieldError.Field()] = "This field is required"
-		}
-		return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Validation failed",
-			"errors":  errorMessages,
-		})
+		return validationFailed(ctx, err)
 	}
 
 	item := &models.Inventory{
@@ -175,35 +291,72 @@ func (c *InventoryController) UpdateItemHandler(ctx echo.Context) error {
 		Currency: req.Currency,
 		Discount: req.Discount,
 		Vendor:   req.Vendor,
+
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		LotTracked:      req.LotTracked,
+		Serialized:      req.Serialized,
+		SKU:             req.SKU,
+		Barcodes:        req.Barcodes,
+		CategoryID:      req.CategoryID,
 	}
 
-	updatedItem, err := c.InventoryManager.UpdateItem(ctx.Request().Context(), flag, id, item)
+	updatedItem, err := c.InventoryManager.UpdateItem(ctx.Request().Context(), id, item)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to update item"})
+		return itemError(ctx, err, "Failed to update item")
 	}
 
-	return ctx.JSON(http.StatusOK, responses.InventoryResponse{
-		ID:       updatedItem.ID,
-		Name:     updatedItem.Name,
-		Price:    updatedItem.Price,
-		Currency: updatedItem.Currency,
-		Discount: updatedItem.Discount,
-		Vendor:   updatedItem.Vendor,
-	})
+	return ctx.JSON(http.StatusOK, responses.NewInventoryResponse(updatedItem))
 }
 
+// PatchItemHandler accepts a JSON Merge Patch (application/merge-patch+json
+// or application/json) or a JSON Patch (application/json-patch+json).
+func (c *InventoryController) PatchItemHandler(ctx echo.Context) error {
+	id := ctx.Param("id")
 
-func (c *InventoryController) DeleteItemHandler(ctx echo.Context) error {
-	flagStr := ctx.QueryParam("flag")
-	flag, err := parseFlag(flagStr)
+	contentType, _, err := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || contentType == echo.MIMEApplicationJSON {
+		contentType = manager.MergePatchContentType
+	}
+
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+
+	patchedItem, err := c.InventoryManager.PatchItem(ctx.Request().Context(), id, contentType, body)
 	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid flag value"})
+		switch {
+		case errors.Is(err, service.ErrItemNotFound):
+			return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+		case errors.Is(err, manager.ErrUnsupportedPatch):
+			return ctx.JSON(http.StatusUnsupportedMediaType, map[string]string{"message": err.Error()})
+		case errors.Is(err, utils.ErrPatchTestFailed):
+			return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		case errors.Is(err, utils.ErrInvalidPatch), errors.Is(err, manager.ErrInvalidSKU), errors.Is(err, manager.ErrInvalidBarcode),
+			errors.Is(err, manager.ErrInvalidCategory):
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		case errors.Is(err, service.ErrSKUExists), errors.Is(err, service.ErrBarcodeExists),
+			errors.Is(err, manager.ErrItemInUse):
+			return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to patch item"})
 	}
 
+	return ctx.JSON(http.StatusOK, responses.NewInventoryResponse(patchedItem))
+}
+
+func (c *InventoryController) DeleteItemHandler(ctx echo.Context) error {
 	id := ctx.Param("id")
 
-	err = c.InventoryManager.DeleteItem(ctx.Request().Context(), flag, id)
+	err := c.InventoryManager.DeleteItem(ctx.Request().Context(), id)
 	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+		}
+		if errors.Is(err, manager.ErrItemInUse) {
+			return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+		}
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to delete item"})
 	}
 
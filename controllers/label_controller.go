@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"main/labels"
+	manager "main/managers"
+	"main/requests"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// labelError maps the errors of label printing to responses.
+func labelError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidLabel), errors.Is(err, labels.ErrUnsupportedFormat):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+// GetItemLabelHandler renders the shelf label of an item. The format query
+// parameter picks png (the default), svg or pdf, barcode picks code128 (the
+// default) or qr, and encode picks whether the barcode holds the sku or id.
+func (c *InventoryController) GetItemLabelHandler(ctx echo.Context) error {
+	format := labels.Format(ctx.QueryParam("format"))
+	if format == "" {
+		format = labels.PNG
+	}
+	opts := manager.LabelOptions{Symbology: labels.Symbology(ctx.QueryParam("barcode")), Encode: ctx.QueryParam("encode")}
+	if opts.Symbology == "" {
+		opts.Symbology = labels.Code128
+	}
+
+	label, err := c.InventoryManager.ItemLabel(ctx.Request().Context(), ctx.Param("id"), opts)
+	if err != nil {
+		return labelError(ctx, err, "Failed to print label")
+	}
+
+	var buf bytes.Buffer
+	if err := labels.Render(&buf, label, format); err != nil {
+		return labelError(ctx, err, "Failed to print label")
+	}
+	return inlineFile(ctx, fmt.Sprintf("label-%s.%s", ctx.Param("id"), format), format, buf.Bytes())
+}
+
+// CreateLabelSheetHandler renders a PDF of labels laid out on sheets of
+// paper.
+func (c *InventoryController) CreateLabelSheetHandler(ctx echo.Context) error {
+	var req requests.LabelSheetRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	paper := labels.Paper(req.Paper)
+	if paper == "" {
+		paper = labels.A4
+	}
+	opts := manager.LabelOptions{Symbology: labels.Symbology(req.Barcode), Encode: req.Encode}
+	if opts.Symbology == "" {
+		opts.Symbology = labels.Code128
+	}
+	copies := make([]manager.LabelCopies, len(req.Items))
+	for i, item := range req.Items {
+		copies[i] = manager.LabelCopies{ItemID: item.ItemID, Copies: item.Copies}
+	}
+
+	sheet, err := c.InventoryManager.LabelSheet(ctx.Request().Context(), copies, opts)
+	if err != nil {
+		return labelError(ctx, err, "Failed to print labels")
+	}
+
+	var buf bytes.Buffer
+	if err := labels.RenderSheet(&buf, sheet, paper); err != nil {
+		return labelError(ctx, err, "Failed to print labels")
+	}
+	return inlineFile(ctx, "labels.pdf", labels.PDF, buf.Bytes())
+}
+
+// inlineFile responds with a rendered file for the browser to display.
+func inlineFile(ctx echo.Context, name string, format labels.Format, data []byte) error {
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", name))
+	return ctx.Blob(http.StatusOK, format.ContentType(), data)
+}
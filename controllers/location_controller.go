@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// locationError maps the errors of location operations to responses.
+func locationError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrLocationNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Location not found"})
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidLocation):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrLocationInUse):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateLocationHandler(ctx echo.Context) error {
+	var req requests.LocationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	location, err := c.InventoryManager.CreateLocation(ctx.Request().Context(), &models.Location{
+		Name:       req.Name,
+		Type:       req.Type,
+		ParentID:   req.ParentID,
+		Quarantine: req.Quarantine,
+	})
+	if err != nil {
+		return locationError(ctx, err, "Failed to create location")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewLocationResponse(location))
+}
+
+func (c *InventoryController) GetLocationsHandler(ctx echo.Context) error {
+	locations, err := c.InventoryManager.GetLocations(ctx.Request().Context())
+	if err != nil {
+		return locationError(ctx, err, "Failed to fetch locations")
+	}
+
+	locationResponses := make([]responses.LocationResponse, 0, len(locations))
+	for _, location := range locations {
+		locationResponses = append(locationResponses, responses.NewLocationResponse(location))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"locations":    locationResponses,
+		"totalRecords": len(locationResponses),
+	})
+}
+
+func (c *InventoryController) GetLocationByIDHandler(ctx echo.Context) error {
+	location, err := c.InventoryManager.GetLocationByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return locationError(ctx, err, "Failed to fetch location")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewLocationResponse(location))
+}
+
+func (c *InventoryController) DeleteLocationHandler(ctx echo.Context) error {
+	if err := c.InventoryManager.DeleteLocation(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		return locationError(ctx, err, "Failed to delete location")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "Location deleted successfully"})
+}
+
+// GetLocationStockHandler lists the stock kept at a location, including
+// everything inside it, as one total per item.
+func (c *InventoryController) GetLocationStockHandler(ctx echo.Context) error {
+	levels, err := c.InventoryManager.GetLocationStock(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return locationError(ctx, err, "Failed to fetch location stock")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"stock":        responses.NewStockLevelResponses(levels),
+		"totalRecords": len(levels),
+	})
+}
+
+func (c *InventoryController) GetItemLocationsHandler(ctx echo.Context) error {
+	levels, err := c.InventoryManager.GetItemStockLevels(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return locationError(ctx, err, "Failed to fetch item locations")
+	}
+
+	inTransit, err := c.InventoryManager.InTransit(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return locationError(ctx, err, "Failed to fetch item locations")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"locations":    responses.NewStockLevelResponses(levels),
+		"in_transit":   inTransit,
+		"totalRecords": len(levels),
+	})
+}
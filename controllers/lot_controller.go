@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// defaultExpiryWindow is how many days ahead GET /inventory/expiring looks
+// without a within parameter.
+const defaultExpiryWindow = 30
+
+// lotError maps the errors of lot operations to responses.
+func lotError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidLot), errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, service.ErrLotExists):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateLotHandler(ctx echo.Context) error {
+	var req requests.LotRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	lot := &models.Lot{
+		LotNumber:      req.LotNumber,
+		ManufacturedAt: parseDate(req.ManufacturedAt),
+		ExpiresAt:      parseDate(req.ExpiresAt),
+	}
+	lot, err := c.InventoryManager.CreateLot(ctx.Request().Context(), ctx.Param("id"), lot)
+	if err != nil {
+		return lotError(ctx, err, "Failed to create lot")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewLotResponse(lot))
+}
+
+// parseDate parses a calendar date the validator has already checked. An
+// empty date is nil.
+func parseDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	date, _ := time.Parse("2006-01-02", value)
+	return &date
+}
+
+// GetLotsHandler lists an item's lots, first expiring first.
+func (c *InventoryController) GetLotsHandler(ctx echo.Context) error {
+	query, err := lotQuery(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	query.InStock = ctx.QueryParam("in_stock") == "true"
+
+	lots, totalCount, err := c.InventoryManager.GetLots(ctx.Request().Context(), ctx.Param("id"), query)
+	if err != nil {
+		return lotError(ctx, err, "Failed to fetch lots")
+	}
+
+	return lotsResponse(ctx, lots, totalCount)
+}
+
+// GetExpiringLotsHandler lists the lots with stock on hand that expire
+// within a number of days, such as within=30d, including expired ones.
+func (c *InventoryController) GetExpiringLotsHandler(ctx echo.Context) error {
+	query, err := lotQuery(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	days, err := expiryWindow(ctx.QueryParam("within"))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if itemID := ctx.QueryParam("item_id"); itemID != "" {
+		query.ItemIDs = []string{itemID}
+	}
+
+	lots, totalCount, err := c.InventoryManager.ExpiringLots(ctx.Request().Context(), days, query)
+	if err != nil {
+		return lotError(ctx, err, "Failed to fetch expiring lots")
+	}
+
+	return lotsResponse(ctx, lots, totalCount)
+}
+
+func lotQuery(ctx echo.Context) (models.LotQuery, error) {
+	var query models.LotQuery
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// expiryWindow parses a number of days such as 30d.
+func expiryWindow(value string) (int, error) {
+	if value == "" {
+		return defaultExpiryWindow, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || days < 0 {
+		return 0, errors.New("within must be a number of days such as 30d")
+	}
+	return days, nil
+}
+
+func lotsResponse(ctx echo.Context, lots []*models.Lot, totalCount int64) error {
+	lotResponses := make([]responses.LotResponse, 0, len(lots))
+	for _, lot := range lots {
+		lotResponses = append(lotResponses, responses.NewLotResponse(lot))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"lots":         lotResponses,
+		"totalRecords": totalCount,
+	})
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// purchaseOrderError maps the errors of purchase order operations to
+// responses.
+func purchaseOrderError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrPurchaseOrderNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Purchase order not found"})
+	case errors.Is(err, manager.ErrInvalidPurchaseOrder), errors.Is(err, manager.ErrInvalidMovement),
+		errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrPurchaseOrderState), errors.Is(err, service.ErrPurchaseOrderConflict),
+		errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreatePurchaseOrderHandler(ctx echo.Context) error {
+	var req requests.PurchaseOrderRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	order := &models.PurchaseOrder{
+		Vendor:    req.Vendor,
+		Currency:  req.Currency,
+		Reference: req.Reference,
+	}
+	for _, line := range req.Lines {
+		// The validator has already checked the format.
+		expectedDate, _ := time.Parse("2006-01-02", line.ExpectedDate)
+		order.Lines = append(order.Lines, models.PurchaseOrderLine{
+			ItemID:       line.ItemID,
+			Quantity:     line.Quantity,
+			UnitCost:     line.UnitCost,
+			ExpectedDate: expectedDate,
+		})
+	}
+
+	order, err := c.InventoryManager.CreatePurchaseOrder(ctx.Request().Context(), order)
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to create purchase order")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewPurchaseOrderResponse(order))
+}
+
+func (c *InventoryController) GetPurchaseOrdersHandler(ctx echo.Context) error {
+	query := models.PurchaseOrderQuery{
+		Vendor: ctx.QueryParam("vendor"),
+		Status: ctx.QueryParam("status"),
+	}
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	orders, totalCount, err := c.InventoryManager.GetPurchaseOrders(ctx.Request().Context(), query)
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to fetch purchase orders")
+	}
+
+	orderResponses := make([]responses.PurchaseOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		orderResponses = append(orderResponses, responses.NewPurchaseOrderResponse(order))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"purchase_orders": orderResponses,
+		"totalRecords":    totalCount,
+	})
+}
+
+func (c *InventoryController) GetPurchaseOrderByIDHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.GetPurchaseOrderByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to fetch purchase order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPurchaseOrderResponse(order))
+}
+
+func (c *InventoryController) ReceivePurchaseOrderHandler(ctx echo.Context) error {
+	var req requests.PurchaseOrderReceiveRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	receipts := make([]manager.PurchaseOrderReceipt, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		receipts = append(receipts, manager.PurchaseOrderReceipt{
+			LineID:     line.LineID,
+			ItemID:     line.ItemID,
+			Quantity:   line.Quantity,
+			LocationID: line.LocationID,
+			LotID:      line.LotID,
+
+			SerialNumbers: line.SerialNumbers,
+		})
+	}
+
+	order, err := c.InventoryManager.ReceivePurchaseOrder(ctx.Request().Context(), ctx.Param("id"), receipts, requestUser(ctx, req.User))
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to receive purchase order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPurchaseOrderResponse(order))
+}
+
+func (c *InventoryController) ClosePurchaseOrderHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.ClosePurchaseOrder(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to close purchase order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPurchaseOrderResponse(order))
+}
+
+func (c *InventoryController) CancelPurchaseOrderHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.CancelPurchaseOrder(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return purchaseOrderError(ctx, err, "Failed to cancel purchase order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPurchaseOrderResponse(order))
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// reservationError maps the errors of reservation operations to responses.
+func reservationError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrReservationNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Reservation not found"})
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, manager.ErrInvalidReservation), errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, service.ErrInsufficientStock), errors.Is(err, manager.ErrReservationState),
+		errors.Is(err, service.ErrReservationConflict):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateReservationHandler(ctx echo.Context) error {
+	var req requests.ReservationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	reservation, err := c.InventoryManager.CreateReservation(ctx.Request().Context(), ctx.Param("id"), req.Quantity, ttl, req.Reference)
+	if err != nil {
+		return reservationError(ctx, err, "Failed to create reservation")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewReservationResponse(reservation))
+}
+
+func (c *InventoryController) GetItemReservationsHandler(ctx echo.Context) error {
+	query := models.ReservationQuery{
+		ItemID: ctx.Param("id"),
+		Status: ctx.QueryParam("status"),
+	}
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	reservations, totalCount, err := c.InventoryManager.GetReservations(ctx.Request().Context(), query)
+	if err != nil {
+		return reservationError(ctx, err, "Failed to fetch reservations")
+	}
+
+	reservationResponses := make([]responses.ReservationResponse, 0, len(reservations))
+	for _, reservation := range reservations {
+		reservationResponses = append(reservationResponses, responses.NewReservationResponse(reservation))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"reservations": reservationResponses,
+		"totalRecords": totalCount,
+	})
+}
+
+func (c *InventoryController) GetReservationByIDHandler(ctx echo.Context) error {
+	reservation, err := c.InventoryManager.GetReservationByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return reservationError(ctx, err, "Failed to fetch reservation")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReservationResponse(reservation))
+}
+
+func (c *InventoryController) ConfirmReservationHandler(ctx echo.Context) error {
+	return c.reservationActionHandler(ctx, c.InventoryManager.ConfirmReservation)
+}
+
+func (c *InventoryController) ReleaseReservationHandler(ctx echo.Context) error {
+	return c.reservationActionHandler(ctx, c.InventoryManager.ReleaseReservation)
+}
+
+func (c *InventoryController) reservationActionHandler(ctx echo.Context, action func(context.Context, string) (*models.Reservation, error)) error {
+	reservation, err := action(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return reservationError(ctx, err, "Failed to update reservation")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReservationResponse(reservation))
+}
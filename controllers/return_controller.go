@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// returnError maps the errors of return operations to responses.
+func returnError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrReturnNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Return not found"})
+	case errors.Is(err, manager.ErrInvalidReturn), errors.Is(err, manager.ErrInvalidMovement),
+		errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrReturnState), errors.Is(err, service.ErrReturnConflict),
+		errors.Is(err, service.ErrSalesOrderConflict), errors.Is(err, service.ErrInsufficientStock),
+		errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateReturnHandler(ctx echo.Context) error {
+	var req requests.ReturnRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	ret := &models.Return{
+		SalesOrderID: req.SalesOrderID,
+		Reason:       req.Reason,
+	}
+	for _, line := range req.Lines {
+		ret.Lines = append(ret.Lines, models.ReturnLine{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+		})
+	}
+
+	ret, err := c.InventoryManager.CreateReturn(ctx.Request().Context(), ret)
+	if err != nil {
+		return returnError(ctx, err, "Failed to create return")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewReturnResponse(ret))
+}
+
+func (c *InventoryController) GetReturnsHandler(ctx echo.Context) error {
+	query := models.ReturnQuery{
+		SalesOrderID: ctx.QueryParam("sales_order_id"),
+		Status:       ctx.QueryParam("status"),
+	}
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	returns, totalCount, err := c.InventoryManager.GetReturns(ctx.Request().Context(), query)
+	if err != nil {
+		return returnError(ctx, err, "Failed to fetch returns")
+	}
+
+	returnResponses := make([]responses.ReturnResponse, 0, len(returns))
+	for _, ret := range returns {
+		returnResponses = append(returnResponses, responses.NewReturnResponse(ret))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"returns":      returnResponses,
+		"totalRecords": totalCount,
+	})
+}
+
+func (c *InventoryController) GetReturnByIDHandler(ctx echo.Context) error {
+	ret, err := c.InventoryManager.GetReturnByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return returnError(ctx, err, "Failed to fetch return")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReturnResponse(ret))
+}
+
+func (c *InventoryController) ReceiveReturnHandler(ctx echo.Context) error {
+	var req requests.ReturnReceiveRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	receipts := make([]manager.ReturnReceipt, 0, len(req.Receipts))
+	for _, receipt := range req.Receipts {
+		receipts = append(receipts, manager.ReturnReceipt{
+			LineID:     receipt.LineID,
+			Condition:  receipt.Condition,
+			Quantity:   receipt.Quantity,
+			LocationID: receipt.LocationID,
+			LotID:      receipt.LotID,
+
+			SerialNumbers: receipt.SerialNumbers,
+		})
+	}
+
+	ret, err := c.InventoryManager.ReceiveReturn(ctx.Request().Context(), ctx.Param("id"), receipts, requestUser(ctx, req.User))
+	if err != nil {
+		return returnError(ctx, err, "Failed to receive return")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReturnResponse(ret))
+}
+
+func (c *InventoryController) ReleaseReturnHandler(ctx echo.Context) error {
+	var req requests.ReturnReleaseRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	releases := make([]manager.ReturnRelease, 0, len(req.Releases))
+	for _, release := range req.Releases {
+		releases = append(releases, manager.ReturnRelease{
+			LineID:         release.LineID,
+			Disposition:    release.Disposition,
+			Quantity:       release.Quantity,
+			FromLocationID: release.FromLocationID,
+			LocationID:     release.LocationID,
+			LotID:          release.LotID,
+			SerialNumbers:  release.SerialNumbers,
+		})
+	}
+
+	ret, err := c.InventoryManager.ReleaseReturn(ctx.Request().Context(), ctx.Param("id"), releases, requestUser(ctx, req.User))
+	if err != nil {
+		return returnError(ctx, err, "Failed to release returned units")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReturnResponse(ret))
+}
+
+func (c *InventoryController) CancelReturnHandler(ctx echo.Context) error {
+	ret, err := c.InventoryManager.CancelReturn(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return returnError(ctx, err, "Failed to cancel return")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewReturnResponse(ret))
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// salesOrderError maps the errors of sales order operations to responses.
+func salesOrderError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSalesOrderNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Sales order not found"})
+	case errors.Is(err, manager.ErrInvalidSalesOrder), errors.Is(err, manager.ErrInvalidMovement),
+		errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrSalesOrderState), errors.Is(err, service.ErrSalesOrderConflict),
+		errors.Is(err, service.ErrInsufficientStock), errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateSalesOrderHandler(ctx echo.Context) error {
+	var req requests.SalesOrderRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	order := &models.SalesOrder{
+		Customer:  req.Customer,
+		Reference: req.Reference,
+	}
+	for _, line := range req.Lines {
+		order.Lines = append(order.Lines, models.SalesOrderLine{
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+		})
+	}
+
+	order, err := c.InventoryManager.CreateSalesOrder(ctx.Request().Context(), order)
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to create sales order")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) GetSalesOrdersHandler(ctx echo.Context) error {
+	query := models.SalesOrderQuery{
+		Customer: ctx.QueryParam("customer"),
+		Status:   ctx.QueryParam("status"),
+	}
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	orders, totalCount, err := c.InventoryManager.GetSalesOrders(ctx.Request().Context(), query)
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to fetch sales orders")
+	}
+
+	orderResponses := make([]responses.SalesOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		orderResponses = append(orderResponses, responses.NewSalesOrderResponse(order))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"sales_orders": orderResponses,
+		"totalRecords": totalCount,
+	})
+}
+
+func (c *InventoryController) GetSalesOrderByIDHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.GetSalesOrderByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to fetch sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) AllocateSalesOrderHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.AllocateSalesOrder(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to allocate sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) GetSalesOrderPickListHandler(ctx echo.Context) error {
+	picks, err := c.InventoryManager.SalesOrderPickList(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to build pick list")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"picks": responses.NewSalesOrderPickResponses(picks),
+	})
+}
+
+func (c *InventoryController) PickSalesOrderHandler(ctx echo.Context) error {
+	var req requests.SalesOrderPickRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	picks := make([]models.SalesOrderPick, 0, len(req.Picks))
+	for _, pick := range req.Picks {
+		picks = append(picks, models.SalesOrderPick{
+			LineID:     pick.LineID,
+			LocationID: pick.LocationID,
+			Quantity:   pick.Quantity,
+		})
+	}
+
+	order, err := c.InventoryManager.PickSalesOrder(ctx.Request().Context(), ctx.Param("id"), picks)
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to pick sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) ShipSalesOrderHandler(ctx echo.Context) error {
+	var req requests.SalesOrderShipRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+
+	order, err := c.InventoryManager.ShipSalesOrder(ctx.Request().Context(), ctx.Param("id"), req.SerialNumbers,
+		requestUser(ctx, req.User))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to ship sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) DeliverSalesOrderHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.DeliverSalesOrder(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to deliver sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
+
+func (c *InventoryController) CancelSalesOrderHandler(ctx echo.Context) error {
+	order, err := c.InventoryManager.CancelSalesOrder(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return salesOrderError(ctx, err, "Failed to cancel sales order")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSalesOrderResponse(order))
+}
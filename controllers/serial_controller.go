@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// serialError maps the errors of serial number operations to responses.
+func serialError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, service.ErrSerialNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Serial number not found"})
+	case errors.Is(err, manager.ErrInvalidSerial), errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrSerialState), errors.Is(err, service.ErrSerialExists),
+		errors.Is(err, service.ErrSerialConflict), errors.Is(err, service.ErrInsufficientStock):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+// GetSerialsHandler lists the units of a serialized item by serial number,
+// optionally only those with the given status.
+func (c *InventoryController) GetSerialsHandler(ctx echo.Context) error {
+	query := models.SerialQuery{Status: ctx.QueryParam("status")}
+
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	serials, totalCount, err := c.InventoryManager.GetSerials(ctx.Request().Context(), ctx.Param("id"), query)
+	if err != nil {
+		return serialError(ctx, err, "Failed to fetch serial numbers")
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"serials":      newSerialResponses(serials),
+		"totalRecords": totalCount,
+	})
+}
+
+// RegisterSerialsHandler records the serial numbers of units already on
+// hand.
+func (c *InventoryController) RegisterSerialsHandler(ctx echo.Context) error {
+	var req requests.SerialRegistrationRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	serials, err := c.InventoryManager.RegisterSerials(ctx.Request().Context(), ctx.Param("id"), req.SerialNumbers)
+	if err != nil {
+		return serialError(ctx, err, "Failed to register serial numbers")
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"serials": newSerialResponses(serials),
+	})
+}
+
+func (c *InventoryController) GetSerialHandler(ctx echo.Context) error {
+	serial, err := c.InventoryManager.GetSerial(ctx.Request().Context(), ctx.Param("serial"))
+	if err != nil {
+		return serialError(ctx, err, "Failed to fetch serial number")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSerialResponse(serial))
+}
+
+func (c *InventoryController) ReserveSerialHandler(ctx echo.Context) error {
+	serial, err := c.InventoryManager.ReserveSerial(ctx.Request().Context(), ctx.Param("serial"))
+	if err != nil {
+		return serialError(ctx, err, "Failed to reserve serial number")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSerialResponse(serial))
+}
+
+func (c *InventoryController) ReleaseSerialHandler(ctx echo.Context) error {
+	serial, err := c.InventoryManager.ReleaseSerial(ctx.Request().Context(), ctx.Param("serial"))
+	if err != nil {
+		return serialError(ctx, err, "Failed to release serial number")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewSerialResponse(serial))
+}
+
+func newSerialResponses(serials []*models.Serial) []responses.SerialResponse {
+	serialResponses := make([]responses.SerialResponse, 0, len(serials))
+	for _, serial := range serials {
+		serialResponses = append(serialResponses, responses.NewSerialResponse(serial))
+	}
+	return serialResponses
+}
@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+// validationFailed renders validator errors the way the inventory handlers
+// always have.
+func validationFailed(ctx echo.Context, err error) error {
+	errorMessages := make(map[string]string)
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, fieldError := range validationErrors {
+			if fieldError.Tag() == "required" {
+				errorMessages[fieldError.Field()] = "This field is required"
+			} else {
+				errorMessages[fieldError.Field()] = "This field failed the '" + fieldError.Tag() + "' rule"
+			}
+		}
+	}
+
+	return ctx.JSON(http.StatusBadRequest, map[string]interface{}{
+		"message": "Validation failed",
+		"errors":  errorMessages,
+	})
+}
+
+// stockError maps the errors of stock operations to responses.
+func stockError(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+	case errors.Is(err, service.ErrInsufficientStock):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrInvalidQuantity), errors.Is(err, manager.ErrInvalidMovement):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to update stock"})
+}
+
+func (c *InventoryController) AdjustStockHandler(ctx echo.Context) error {
+	var req requests.StockAdjustmentRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	user := requestUser(ctx, req.User)
+	item, err := c.InventoryManager.AdjustStock(ctx.Request().Context(), ctx.Param("id"), req.Quantity, req.Reference, user)
+	if err != nil {
+		return stockError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewInventoryResponse(item))
+}
+
+// requestUser returns the user named in a request body, falling back to the
+// X-User header.
+func requestUser(ctx echo.Context, user string) string {
+	if user != "" {
+		return user
+	}
+	return ctx.Request().Header.Get("X-User")
+}
+
+func (c *InventoryController) CreateMovementHandler(ctx echo.Context) error {
+	var req requests.StockMovementRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	movement := &models.StockMovement{
+		Delta:      req.Delta,
+		Reason:     req.Reason,
+		Reference:  req.Reference,
+		User:       requestUser(ctx, req.User),
+		LocationID: req.LocationID,
+
+		SerialNumbers: req.SerialNumbers,
+	}
+	if req.LotID != nil {
+		movement.Lots = []models.MovementLot{{LotID: *req.LotID, Delta: req.Delta}}
+	}
+
+	movement, item, err := c.InventoryManager.RecordMovement(ctx.Request().Context(), ctx.Param("id"), movement)
+	if err != nil {
+		return stockError(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]interface{}{
+		"movement": responses.NewStockMovementResponse(movement),
+		"item":     responses.NewInventoryResponse(item),
+	})
+}
+
+func (c *InventoryController) GetMovementsHandler(ctx echo.Context) error {
+	var query models.MovementQuery
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	movements, totalCount, ledgerOnHand, err := c.InventoryManager.ListMovements(ctx.Request().Context(), ctx.Param("id"), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrItemNotFound):
+			return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Item not found"})
+		case errors.Is(err, manager.ErrInvalidQuery):
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+		}
+		return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to fetch stock movements"})
+	}
+
+	movementResponses := make([]responses.StockMovementResponse, 0, len(movements))
+	for _, movement := range movements {
+		movementResponses = append(movementResponses, responses.NewStockMovementResponse(movement))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"movements":      movementResponses,
+		"totalRecords":   totalCount,
+		"ledger_on_hand": ledgerOnHand,
+	})
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"errors"
+	manager "main/managers"
+	"main/models"
+	"main/requests"
+	"main/responses"
+	service "main/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// transferError maps the errors of transfer operations to responses.
+func transferError(ctx echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrTransferNotFound):
+		return ctx.JSON(http.StatusNotFound, map[string]string{"message": "Transfer not found"})
+	case errors.Is(err, manager.ErrInvalidTransfer), errors.Is(err, manager.ErrInvalidMovement),
+		errors.Is(err, manager.ErrInvalidQuery):
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case errors.Is(err, manager.ErrTransferState), errors.Is(err, service.ErrTransferConflict),
+		errors.Is(err, service.ErrInsufficientStock):
+		return ctx.JSON(http.StatusConflict, map[string]string{"message": err.Error()})
+	}
+	return ctx.JSON(http.StatusInternalServerError, map[string]string{"message": message})
+}
+
+func (c *InventoryController) CreateTransferHandler(ctx echo.Context) error {
+	var req requests.TransferRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	transfer, err := c.InventoryManager.CreateTransfer(ctx.Request().Context(), &models.Transfer{
+		ItemID:         req.ItemID,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Quantity:       req.Quantity,
+		Reference:      req.Reference,
+		User:           requestUser(ctx, req.User),
+	})
+	if err != nil {
+		return transferError(ctx, err, "Failed to create transfer")
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewTransferResponse(transfer))
+}
+
+func (c *InventoryController) GetTransfersHandler(ctx echo.Context) error {
+	query := models.TransferQuery{
+		ItemID:     ctx.QueryParam("item_id"),
+		LocationID: ctx.QueryParam("location_id"),
+		Status:     ctx.QueryParam("status"),
+	}
+	var err error
+	if query.Limit, err = intQueryParam(ctx, "limit"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	if query.Offset, err = intQueryParam(ctx, "offset"); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+
+	transfers, totalCount, err := c.InventoryManager.GetTransfers(ctx.Request().Context(), query)
+	if err != nil {
+		return transferError(ctx, err, "Failed to fetch transfers")
+	}
+
+	transferResponses := make([]responses.TransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferResponses = append(transferResponses, responses.NewTransferResponse(transfer))
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]interface{}{
+		"transfers":    transferResponses,
+		"totalRecords": totalCount,
+	})
+}
+
+func (c *InventoryController) GetTransferByIDHandler(ctx echo.Context) error {
+	transfer, err := c.InventoryManager.GetTransferByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return transferError(ctx, err, "Failed to fetch transfer")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewTransferResponse(transfer))
+}
+
+func (c *InventoryController) ShipTransferHandler(ctx echo.Context) error {
+	var req requests.TransferShipRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+
+	transfer, err := c.InventoryManager.ShipTransfer(ctx.Request().Context(), ctx.Param("id"), requestUser(ctx, req.User))
+	if err != nil {
+		return transferError(ctx, err, "Failed to ship transfer")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewTransferResponse(transfer))
+}
+
+func (c *InventoryController) ReceiveTransferHandler(ctx echo.Context) error {
+	var req requests.TransferReceiveRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid request format"})
+	}
+	if err := c.Validate.Struct(req); err != nil {
+		return validationFailed(ctx, err)
+	}
+
+	transfer, err := c.InventoryManager.ReceiveTransfer(ctx.Request().Context(), ctx.Param("id"), req.Quantity, requestUser(ctx, req.User))
+	if err != nil {
+		return transferError(ctx, err, "Failed to receive transfer")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewTransferResponse(transfer))
+}
+
+func (c *InventoryController) CancelTransferHandler(ctx echo.Context) error {
+	transfer, err := c.InventoryManager.CancelTransfer(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		return transferError(ctx, err, "Failed to cancel transfer")
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewTransferResponse(transfer))
+}
@@ -0,0 +1,214 @@
+// Package labels renders printable shelf labels for inventory items as PNG,
+// SVG or PDF, one at a time or laid out on sheets of paper. Everything is
+// drawn in Go, fonts included.
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+type Format string
+
+const (
+	PNG Format = "png"
+	SVG Format = "svg"
+	PDF Format = "pdf"
+)
+
+// ContentType is the media type of labels rendered in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case PNG:
+		return "image/png"
+	case SVG:
+		return "image/svg+xml"
+	case PDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+type Symbology string
+
+const (
+	Code128 Symbology = "code128"
+	QR      Symbology = "qr"
+)
+
+type Paper string
+
+const (
+	A4     Paper = "a4"
+	Letter Paper = "letter"
+)
+
+// Width and Height are the size of a label in millimetres, that of the
+// common 21 and 24 per sheet label stock.
+const (
+	Width  = 63.5
+	Height = 38.1
+)
+
+// padding is the blank border inside a label, in millimetres.
+const padding = 3.0
+
+// charWidth is the advance of every character of the monospaced fonts, in
+// ems. Text is measured with it, so that it fits the same way in every
+// format.
+const charWidth = 0.6
+
+var ErrUnsupportedFormat = errors.New("unsupported label format")
+
+// Label is the content of a shelf label: the item's title and price above a
+// barcode encoding Content, which is printed under it.
+type Label struct {
+	Title   string
+	Price   string
+	Content string
+
+	symbol *symbol
+}
+
+// New encodes content in the symbology, failing if it cannot hold it, such
+// as non-ASCII text in a Code 128 barcode.
+func New(title, price string, symbology Symbology, content string) (*Label, error) {
+	symbol, err := encode(symbology, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Label{Title: title, Price: price, Content: content, symbol: symbol}, nil
+}
+
+// Render writes a single label in the format.
+func Render(w io.Writer, label *Label, format Format) error {
+	var doc document
+	switch format {
+	case PNG:
+		doc = newPNGCanvas(Width, Height)
+	case SVG:
+		doc = newSVGCanvas(Width, Height)
+	case PDF:
+		doc = newPDFCanvas(Width, Height)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+
+	label.draw(doc, 0, 0)
+	return doc.encode(w)
+}
+
+// canvas is a drawing surface measured in millimetres from its top left
+// corner.
+type canvas interface {
+	// rect fills a black rectangle.
+	rect(x, y, w, h float64)
+	// text draws s with its baseline at y, size being the font size.
+	text(x, y, size float64, bold bool, s string)
+	// snap rounds a length down to what the canvas can draw exactly, so
+	// that every bar of a barcode comes out equally wide.
+	snap(length float64) float64
+}
+
+// document is a canvas that can be written out as a file.
+type document interface {
+	canvas
+	encode(w io.Writer) error
+}
+
+// draw lays the label out with its top left corner at x, y. A Code 128
+// barcode runs across the bottom of the label; a QR code takes its right
+// side.
+func (l *Label) draw(c canvas, x, y float64) {
+	inner := Width - 2*padding
+
+	if !l.symbol.linear {
+		size := Height - 2*padding - 6
+		l.symbol.draw(c, x+Width-padding-size, y+(Height-size)/2, size, size)
+
+		column := inner - size - padding
+		baseline := y + padding + 3
+		for _, line := range wrap(l.Title, column, 3, 3) {
+			c.text(x+padding, baseline, 3, false, line)
+			baseline += 3.6
+		}
+		baseline += 2.4
+		c.text(x+padding, baseline, shrink(l.Price, column, 4.5), true, l.Price)
+		baseline += 4
+		for _, line := range wrap(l.Content, column, 2.2, 2) {
+			c.text(x+padding, baseline, 2.2, false, line)
+			baseline += 2.6
+		}
+		return
+	}
+
+	c.text(x+padding, y+padding+3.5, 3.5, false, fit(l.Title, inner, 3.5))
+	c.text(x+padding, y+padding+10, shrink(l.Price, inner, 5.5), true, l.Price)
+
+	barsTop := y + padding + 13.5
+	captionSize := 2.5
+	l.symbol.draw(c, x+padding, barsTop, inner, y+Height-padding-captionSize-1-barsTop)
+
+	caption := fit(l.Content, inner, captionSize)
+	captionX := x + (Width-textWidth(caption, captionSize))/2
+	c.text(captionX, y+Height-padding, captionSize, false, caption)
+}
+
+func textWidth(s string, size float64) float64 {
+	return float64(utf8.RuneCountInString(s)) * charWidth * size
+}
+
+// shrink returns the largest font size up to size at which s is no wider
+// than width, so that prices are never cut short.
+func shrink(s string, width, size float64) float64 {
+	if n := utf8.RuneCountInString(s); n > 0 && textWidth(s, size) > width {
+		return width / (charWidth * float64(n))
+	}
+	return size
+}
+
+// fit shortens s with an ellipsis until it is no wider than width.
+func fit(s string, width, size float64) string {
+	limit := int(width / (charWidth * size))
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	if limit < 1 {
+		return ""
+	}
+	return string([]rune(s)[:limit-1]) + "…"
+}
+
+// wrap breaks s into at most maxLines lines no wider than width, at spaces
+// where it can, shortening the last line if s does not fit.
+func wrap(s string, width, size float64, maxLines int) []string {
+	limit := int(width / (charWidth * size))
+	if limit < 1 {
+		return nil
+	}
+
+	var lines []string
+	rest := []rune(strings.TrimSpace(s))
+	for len(rest) > 0 && len(lines) < maxLines-1 {
+		if len(rest) <= limit {
+			break
+		}
+		cut := limit
+		for i := limit; i > 0; i-- {
+			if rest[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimSpace(string(rest[:cut])))
+		rest = []rune(strings.TrimSpace(string(rest[cut:])))
+	}
+	if len(rest) > 0 {
+		lines = append(lines, fit(string(rest), width, size))
+	}
+	return lines
+}
@@ -0,0 +1,265 @@
+package labels
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func modules(t *testing.T, symbology Symbology, content string) []string {
+	t.Helper()
+	s, err := encode(symbology, content)
+	if err != nil {
+		t.Fatalf("encode(%s, %q): %v", symbology, content, err)
+	}
+	rows := make([]string, len(s.modules))
+	for i, row := range s.modules {
+		var b strings.Builder
+		for _, dark := range row {
+			if dark {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		}
+		rows[i] = b.String()
+	}
+	return rows
+}
+
+func TestCode128(t *testing.T) {
+	const (
+		startB = "11010010000"
+		startC = "11010011100"
+		stop   = "1100011101011"
+	)
+	tests := []struct {
+		content string
+		start   string
+		// symbols is the number of data symbols, checksum the character of
+		// code set B whose value is the checksum.
+		symbols  int
+		checksum string
+	}{
+		// (104 + 1*33 + 2*34 + 3*35 + 4*13 + 5*17 + 6*18 + 7*19) % 103 = 70
+		{"ABC-123", startB, 7, "f"},
+		// (105 + 1*1 + 2*23 + 3*45 + 4*67 + 5*89) % 103 = 73
+		{"0123456789", startC, 5, "i"},
+	}
+	for _, tt := range tests {
+		rows := modules(t, Code128, tt.content)
+		if len(rows) != 1 {
+			t.Fatalf("%s: %d rows, want 1", tt.content, len(rows))
+		}
+		bars := rows[0]
+		// Every symbol is 11 modules wide, the stop pattern 13.
+		if want := 11*(tt.symbols+2) + 13; len(bars) != want {
+			t.Errorf("%s: %d modules wide, want %d", tt.content, len(bars), want)
+		}
+		if !strings.HasPrefix(bars, tt.start) || !strings.HasSuffix(bars, stop) {
+			t.Errorf("%s: bars %s do not run from the start to the stop pattern", tt.content, bars)
+		}
+
+		// A lone lower-case letter is encoded in code set B after the
+		// start symbol.
+		want := modules(t, Code128, tt.checksum)[0][11:22]
+		if got := bars[len(bars)-13-11 : len(bars)-13]; got != want {
+			t.Errorf("%s: checksum symbol %s, want %s (%q)", tt.content, got, want, tt.checksum)
+		}
+	}
+
+	if _, err := New("Widget", "$1.00", Code128, "Café"); err == nil {
+		t.Error("encoding non-ASCII text in Code 128 succeeded")
+	}
+}
+
+// qrFormatInfo reads the 15 format information bits next to the top left
+// finder pattern, in the order ISO/IEC 18004 places them.
+func qrFormatInfo(rows []string) int {
+	bit := func(x, y int) int {
+		if rows[y][x] == '1' {
+			return 1
+		}
+		return 0
+	}
+	var points [][2]int
+	for x := 0; x < 6; x++ {
+		points = append(points, [2]int{x, 8})
+	}
+	points = append(points, [2]int{7, 8}, [2]int{8, 8}, [2]int{8, 7})
+	for y := 5; y >= 0; y-- {
+		points = append(points, [2]int{8, y})
+	}
+
+	info := 0
+	for _, p := range points {
+		info = info<<1 | bit(p[0], p[1])
+	}
+	return info
+}
+
+// qrFormatWord is the masked BCH(15, 5) code word of an error correction
+// level's two bits and a data mask.
+func qrFormatWord(level, mask int) int {
+	data := level<<3 | mask
+	word := data << 10
+	for i := 14; i >= 10; i-- {
+		if word&(1<<i) != 0 {
+			word ^= 0x537 << (i - 10)
+		}
+	}
+	return (data<<10 | word) ^ 0x5412
+}
+
+func TestQRVersionAndErrorCorrection(t *testing.T) {
+	// Level M has the bits 00.
+	const levelM = 0
+
+	tests := []struct {
+		content string
+		version int
+	}{
+		{"12345", 1},
+		{"HELLO WORLD", 1},
+		{"https://example.com/i/0123456789", 3},
+		{strings.Repeat("a", 100), 6},
+	}
+	for _, tt := range tests {
+		rows := modules(t, QR, tt.content)
+		if size := 17 + 4*tt.version; len(rows) != size || len(rows[0]) != size {
+			t.Errorf("%q: %dx%d modules, want version %d of %dx%d", tt.content, len(rows[0]), len(rows), tt.version, size, size)
+			continue
+		}
+
+		info, level := qrFormatInfo(rows), -1
+		for l := 0; l < 4; l++ {
+			for mask := 0; mask < 8; mask++ {
+				if qrFormatWord(l, mask) == info {
+					level = l
+				}
+			}
+		}
+		if level != levelM {
+			t.Errorf("%q: format information %015b has error correction bits %d, want %d (M)", tt.content, info, level, levelM)
+		}
+	}
+}
+
+func TestRenderSVGGolden(t *testing.T) {
+	tests := []struct {
+		golden    string
+		symbology Symbology
+		content   string
+	}{
+		{"label_code128.svg", Code128, "WID-0001"},
+		{"label_qr.svg", QR, "https://example.com/items/WID-0001"},
+	}
+	for _, tt := range tests {
+		label, err := New("Widget <Large> & Heavy", "$12.50", tt.symbology, tt.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := Render(&out, label, SVG); err != nil {
+			t.Fatal(err)
+		}
+
+		decoder := xml.NewDecoder(bytes.NewReader(out.Bytes()))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: malformed SVG: %v", tt.golden, err)
+			}
+		}
+
+		path := filepath.Join("testdata", tt.golden)
+		if *update {
+			if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("%s: SVG differs from the golden file, run go test -update to see how", tt.golden)
+		}
+	}
+}
+
+// checkPDF checks the structure of a PDF file and returns its number of
+// pages.
+func checkPDF(t *testing.T, data []byte) int {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.")) {
+		t.Fatalf("PDF starts with %q", data[:min(len(data), 8)])
+	}
+	trailer := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if trailer == nil {
+		t.Fatal("PDF does not end with a startxref trailer")
+	}
+	offset, _ := strconv.Atoi(string(trailer[1]))
+	if offset >= len(data) || !bytes.HasPrefix(data[offset:], []byte("xref")) {
+		t.Fatalf("startxref %d does not point at the cross-reference table", offset)
+	}
+	if !bytes.Contains(data, []byte("/Type /Catalog")) {
+		t.Fatal("PDF has no catalog")
+	}
+	return len(regexp.MustCompile(`/Type /Page\b[^s]`).FindAll(data, -1))
+}
+
+func TestRenderPDFAndPNG(t *testing.T) {
+	label, err := New("Widget", "$12.50", QR, "WID-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := Render(&out, label, PDF); err != nil {
+		t.Fatal(err)
+	}
+	if pages := checkPDF(t, out.Bytes()); pages != 1 {
+		t.Errorf("label PDF has %d pages, want 1", pages)
+	}
+
+	sheet := make([]*Label, A4.PerSheet()+1)
+	for i := range sheet {
+		sheet[i] = label
+	}
+	out.Reset()
+	if err := RenderSheet(&out, sheet, A4); err != nil {
+		t.Fatal(err)
+	}
+	if pages := checkPDF(t, out.Bytes()); pages != 2 {
+		t.Errorf("sheet of %d labels has %d pages, want 2", len(sheet), pages)
+	}
+
+	out.Reset()
+	if err := Render(&out, label, PNG); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatalf("malformed PNG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() <= bounds.Dy() {
+		t.Errorf("PNG is %dx%d, want a landscape label", bounds.Dx(), bounds.Dy())
+	}
+
+	if err := Render(&out, label, "gif"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("rendering a GIF: %v, want ErrUnsupportedFormat", err)
+	}
+}
@@ -0,0 +1,52 @@
+package labels
+
+import (
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+)
+
+const pdfFontFamily = "gomono"
+
+// pdfCanvas draws on the current page of a PDF measured in millimetres.
+type pdfCanvas struct {
+	pdf *gofpdf.Fpdf
+}
+
+// newPDFCanvas starts a PDF with pages of the given size, embedding the Go
+// Mono fonts so the labels print the same everywhere.
+func newPDFCanvas(w, h float64) *pdfCanvas {
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{UnitStr: "mm", Size: gofpdf.SizeType{Wd: w, Ht: h}})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", gomono.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", gomonobold.TTF)
+	pdf.SetFillColor(0, 0, 0)
+	pdf.AddPage()
+	return &pdfCanvas{pdf: pdf}
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64) {
+	c.pdf.Rect(x, y, w, h, "F")
+}
+
+func (c *pdfCanvas) text(x, y, size float64, bold bool, s string) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	c.pdf.SetFont(pdfFontFamily, style, 0)
+	c.pdf.SetFontUnitSize(size)
+	c.pdf.Text(x, y, s)
+}
+
+// snap leaves lengths alone, as vector output draws them exactly.
+func (c *pdfCanvas) snap(length float64) float64 {
+	return length
+}
+
+func (c *pdfCanvas) encode(w io.Writer) error {
+	return c.pdf.Output(w)
+}
@@ -0,0 +1,102 @@
+package labels
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngDPI is the resolution labels are rasterized at, enough for label
+// printers and for scanning the barcodes off a screen.
+const pngDPI = 300
+
+const pxPerMM = pngDPI / 25.4
+
+var (
+	monoFont     = mustParseFont(gomono.TTF)
+	monoBoldFont = mustParseFont(gomonobold.TTF)
+)
+
+// mustParseFont parses a bundled font, which cannot fail short of a broken
+// build.
+func mustParseFont(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+type pngCanvas struct {
+	img   *image.Gray
+	faces map[pngFaceKey]font.Face
+}
+
+type pngFaceKey struct {
+	size float64
+	bold bool
+}
+
+func newPNGCanvas(w, h float64) *pngCanvas {
+	img := image.NewGray(image.Rect(0, 0, px(w), px(h)))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img, faces: make(map[pngFaceKey]font.Face)}
+}
+
+func px(mm float64) int {
+	return int(math.Round(mm * pxPerMM))
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64) {
+	x0, y0 := px(x), px(y)
+	r := image.Rect(x0, y0, x0+px(w), y0+px(h))
+	draw.Draw(c.img, r, image.Black, image.Point{}, draw.Src)
+}
+
+func (c *pngCanvas) text(x, y, size float64, bold bool, s string) {
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(color.Black),
+		Face: c.face(size, bold),
+		Dot:  fixed.P(px(x), px(y)),
+	}
+	d.DrawString(s)
+}
+
+// face returns the font face for a size in millimetres, which at 72 DPI is
+// its size in pixels.
+func (c *pngCanvas) face(size float64, bold bool) font.Face {
+	key := pngFaceKey{size, bold}
+	if face, ok := c.faces[key]; ok {
+		return face
+	}
+
+	f := monoFont
+	if bold {
+		f = monoBoldFont
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size * pxPerMM, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		panic(err)
+	}
+	c.faces[key] = face
+	return face
+}
+
+// snap rounds down to whole pixels, never below one.
+func (c *pngCanvas) snap(length float64) float64 {
+	return math.Max(1, math.Floor(length*pxPerMM)) / pxPerMM
+}
+
+func (c *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
@@ -0,0 +1,44 @@
+package labels
+
+import (
+	"strconv"
+	"strings"
+)
+
+// minorUnitDigits lists the ISO 4217 currencies whose minor unit is not a
+// hundredth, with the number of decimals they are written with.
+var minorUnitDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// FormatPrice formats an amount in the minor unit of the currency, such as
+// cents for USD, as the currency code followed by the amount with thousands
+// separators: 123456 USD is "USD 1,234.56".
+func FormatPrice(amount int, currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	digits, ok := minorUnitDigits[currency]
+	if !ok {
+		digits = 2
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	s := strconv.Itoa(amount)
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+
+	whole, fraction := s[:len(s)-digits], s[len(s)-digits:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	if fraction != "" {
+		whole += "." + fraction
+	}
+	return strings.TrimSpace(currency + " " + sign + whole)
+}
@@ -0,0 +1,48 @@
+package labels
+
+import (
+	"io"
+	"math"
+)
+
+// sheetMargin is the least blank border left around the labels on a sheet,
+// which most printers cannot print into.
+const sheetMargin = 7.0
+
+// Size is the width and height of the paper in millimetres.
+func (p Paper) Size() (w, h float64) {
+	if p == Letter {
+		return 215.9, 279.4
+	}
+	return 210, 297
+}
+
+// PerSheet is the number of labels that fit on a sheet of the paper.
+func (p Paper) PerSheet() int {
+	cols, rows := p.grid()
+	return cols * rows
+}
+
+func (p Paper) grid() (cols, rows int) {
+	w, h := p.Size()
+	return int(math.Floor((w - 2*sheetMargin) / Width)), int(math.Floor((h - 2*sheetMargin) / Height))
+}
+
+// RenderSheet writes the labels as a PDF laid out in rows on sheets of the
+// paper, centred on each page and starting a new page whenever one is full.
+func RenderSheet(w io.Writer, labels []*Label, paper Paper) error {
+	pageW, pageH := paper.Size()
+	cols, rows := paper.grid()
+	left := (pageW - float64(cols)*Width) / 2
+	top := (pageH - float64(rows)*Height) / 2
+
+	doc := newPDFCanvas(pageW, pageH)
+	for i, label := range labels {
+		slot := i % (cols * rows)
+		if slot == 0 && i > 0 {
+			doc.pdf.AddPage()
+		}
+		label.draw(doc, left+float64(slot%cols)*Width, top+float64(slot/cols)*Height)
+	}
+	return doc.encode(w)
+}
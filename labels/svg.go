@@ -0,0 +1,60 @@
+package labels
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// svgFontFamily prefers the Go Mono font the other formats embed, falling
+// back to whatever monospaced font the viewer has.
+const svgFontFamily = `'Go Mono', 'DejaVu Sans Mono', monospace`
+
+// svgCanvas writes SVG elements in millimetre user units.
+type svgCanvas struct {
+	w, h float64
+	body bytes.Buffer
+}
+
+func newSVGCanvas(w, h float64) *svgCanvas {
+	return &svgCanvas{w: w, h: h}
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64) {
+	fmt.Fprintf(&c.body, `<rect x="%s" y="%s" width="%s" height="%s"/>`+"\n", num(x), num(y), num(w), num(h))
+}
+
+func (c *svgCanvas) text(x, y, size float64, bold bool, s string) {
+	weight := ""
+	if bold {
+		weight = ` font-weight="bold"`
+	}
+	fmt.Fprintf(&c.body, `<text x="%s" y="%s" font-size="%s"%s>`, num(x), num(y), num(size), weight)
+	xml.EscapeText(&c.body, []byte(s))
+	c.body.WriteString("</text>\n")
+}
+
+// snap leaves lengths alone, as vector output draws them exactly.
+func (c *svgCanvas) snap(length float64) float64 {
+	return length
+}
+
+func (c *svgCanvas) encode(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%smm" height="%smm" viewBox="0 0 %s %s">
+<rect width="100%%" height="100%%" fill="#fff"/>
+<g fill="#000" font-family="%s" shape-rendering="crispEdges">
+%s</g>
+</svg>
+`, num(c.w), num(c.h), num(c.w), num(c.h), svgFontFamily, c.body.Bytes())
+	return err
+}
+
+// num formats a length to a ten-thousandth of a millimetre, well past what
+// a printer resolves.
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e4)/1e4, 'f', -1, 64)
+}
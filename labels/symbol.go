@@ -0,0 +1,92 @@
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+var ErrUnsupportedSymbology = errors.New("unsupported barcode symbology")
+
+// Quiet zones, the blank margins scanners need around a symbol, in modules.
+const (
+	code128QuietZone = 10
+	qrQuietZone      = 4
+)
+
+// symbol is an encoded barcode as a grid of modules, the smallest bars or
+// squares it is made of. A linear symbol has a single row.
+type symbol struct {
+	modules [][]bool
+	cols    int
+	linear  bool
+}
+
+func encode(symbology Symbology, content string) (*symbol, error) {
+	if content == "" {
+		return nil, errors.New("nothing to encode")
+	}
+
+	var code barcode.Barcode
+	var err error
+	switch symbology {
+	case Code128:
+		code, err = code128.Encode(content)
+	case QR:
+		code, err = qr.Encode(content, qr.M, qr.Auto)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSymbology, symbology)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s cannot encode %q: %v", symbology, content, err)
+	}
+
+	bounds := code.Bounds()
+	s := &symbol{cols: bounds.Dx(), linear: symbology == Code128}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := make([]bool, 0, s.cols)
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gray := color.GrayModel.Convert(code.At(x, y)).(color.Gray)
+			row = append(row, gray.Y < 0x80)
+		}
+		s.modules = append(s.modules, row)
+	}
+	return s, nil
+}
+
+// draw fits the symbol and its quiet zone into the w by h box at x, y,
+// centred. Linear symbols stretch to the height of the box; others keep
+// their modules square. Runs of dark modules are drawn as one rectangle.
+func (s *symbol) draw(c canvas, x, y, w, h float64) {
+	quietZone := qrQuietZone
+	if s.linear {
+		quietZone = code128QuietZone
+	}
+
+	module := c.snap(w / float64(s.cols+2*quietZone))
+	moduleHeight := h
+	if !s.linear {
+		module = c.snap(min(w, h) / float64(s.cols+2*quietZone))
+		moduleHeight = module
+	}
+	x += (w - module*float64(s.cols)) / 2
+	y += (h - moduleHeight*float64(len(s.modules))) / 2
+
+	for row, modules := range s.modules {
+		for col := 0; col < len(modules); {
+			if !modules[col] {
+				col++
+				continue
+			}
+			start := col
+			for col < len(modules) && modules[col] {
+				col++
+			}
+			c.rect(x+module*float64(start), y+moduleHeight*float64(row), module*float64(col-start), moduleHeight)
+		}
+	}
+}
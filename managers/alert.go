@@ -0,0 +1,134 @@
+package managers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+	"time"
+)
+
+// AlertNotifier is told about every low-stock alert the evaluator opens.
+type AlertNotifier interface {
+	NotifyLowStock(ctx context.Context, alert *models.LowStockAlert, item *models.Inventory) error
+}
+
+// GetLowStockAlerts returns a page of alerts, newest first, and the number
+// of alerts matching the query.
+func (m *InventoryManager) GetLowStockAlerts(ctx context.Context, query models.AlertQuery) ([]*models.LowStockAlert, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.AlertOpen, models.AlertResolved:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown alert status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetAlerts(ctx, query)
+}
+
+// EvaluateLowStock opens an alert, and notifies Notifier, for every item
+// whose available stock is below its reorder point and has no open alert
+// yet. Open alerts of items that have recovered, or been deleted, are
+// resolved. It returns how many alerts it opened and resolved.
+func (m *InventoryManager) EvaluateLowStock(ctx context.Context, now time.Time) (int, int, error) {
+	openAlerts := make(map[string]*models.LowStockAlert)
+	alertQuery := models.AlertQuery{Status: models.AlertOpen, Limit: MaxPageSize}
+	for {
+		alerts, totalCount, err := m.Store.GetAlerts(ctx, alertQuery)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, alert := range alerts {
+			openAlerts[alert.ItemID] = alert
+		}
+		alertQuery.Offset += len(alerts)
+		if len(alerts) == 0 || int64(alertQuery.Offset) >= totalCount {
+			break
+		}
+	}
+
+	opened := 0
+	low := make(map[string]bool)
+	itemQuery := models.ItemQuery{Limit: MaxPageSize, Filter: models.ItemFilter{BelowReorderPoint: true}}
+	for {
+		page, err := m.Store.GetItems(ctx, itemQuery)
+		if err != nil {
+			return opened, 0, err
+		}
+
+		for _, item := range page.Items {
+			low[item.ID] = true
+			if openAlerts[item.ID] != nil {
+				continue
+			}
+
+			alert, err := m.Store.CreateAlert(ctx, &models.LowStockAlert{
+				ItemID:          item.ID,
+				Available:       item.Available(),
+				ReorderPoint:    item.ReorderPoint,
+				ReorderQuantity: item.ReorderQuantity,
+				Status:          models.AlertOpen,
+				CreatedAt:       now,
+			})
+			if err != nil {
+				log.Printf("Error opening low-stock alert for item %s: %v", item.ID, err)
+				continue
+			}
+			opened++
+
+			if m.Notifier != nil {
+				if err := m.Notifier.NotifyLowStock(ctx, alert, item); err != nil {
+					log.Printf("Error sending low-stock notification for item %s: %v", item.ID, err)
+				}
+			}
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		itemQuery.Cursor = page.NextCursor
+	}
+
+	resolved := 0
+	for itemID, alert := range openAlerts {
+		if low[itemID] {
+			continue
+		}
+		if err := m.Store.ResolveAlert(ctx, alert.ID, now); err != nil {
+			log.Printf("Error resolving low-stock alert %s: %v", alert.ID, err)
+			continue
+		}
+		resolved++
+	}
+
+	return opened, resolved, nil
+}
+
+// WatchLowStock calls EvaluateLowStock every interval until ctx is done.
+func (m *InventoryManager) WatchLowStock(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			opened, resolved, err := m.EvaluateLowStock(ctx, time.Now().UTC().Truncate(time.Millisecond))
+			if err != nil {
+				log.Printf("Error evaluating low stock: %v", err)
+			}
+			if opened > 0 || resolved > 0 {
+				log.Printf("Opened %d and resolved %d low-stock alerts", opened, resolved)
+			}
+		}
+	}
+}
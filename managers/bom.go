@@ -0,0 +1,204 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+)
+
+var ErrInvalidBOM = errors.New("invalid bill of materials")
+
+// SetBOM replaces the bill of materials of a kit. Components must be other
+// existing items, each listed once, and no component may itself be built
+// from the kit. An empty list turns the kit back into a plain item.
+func (m *InventoryManager) SetBOM(ctx context.Context, parentID string, components []*models.BOMComponent) ([]*models.BOMComponent, error) {
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := m.Store.GetItemByID(ctx, parentID); err != nil {
+			return err
+		}
+
+		seen := make(map[string]bool, len(components))
+		for i, component := range components {
+			if component.Quantity <= 0 {
+				return fmt.Errorf("%w: component %d: quantity must be positive", ErrInvalidBOM, i+1)
+			}
+			if component.ComponentID == parentID {
+				return fmt.Errorf("%w: component %d: a kit cannot contain itself", ErrInvalidBOM, i+1)
+			}
+			if seen[component.ComponentID] {
+				return fmt.Errorf("%w: component %d: item %s is listed twice", ErrInvalidBOM, i+1, component.ComponentID)
+			}
+			seen[component.ComponentID] = true
+
+			_, err := m.Store.GetItemByID(ctx, component.ComponentID)
+			if errors.Is(err, service.ErrItemNotFound) {
+				return fmt.Errorf("%w: component %d: item %q not found", ErrInvalidBOM, i+1, component.ComponentID)
+			}
+			if err != nil {
+				return err
+			}
+			component.ParentID = parentID
+		}
+
+		if len(components) > 0 {
+			if err := m.checkBOMCycle(ctx, parentID, components); err != nil {
+				return err
+			}
+		}
+		return m.Store.SetBOM(ctx, parentID, components)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// checkBOMCycle fails if any of the components is built, directly or
+// through other kits, from the kit parentID.
+func (m *InventoryManager) checkBOMCycle(ctx context.Context, parentID string, components []*models.BOMComponent) error {
+	visited := make(map[string]bool)
+	pending := make([]string, 0, len(components))
+	for _, component := range components {
+		pending = append(pending, component.ComponentID)
+	}
+
+	for len(pending) > 0 {
+		nested, err := m.Store.GetBOMComponents(ctx, models.BOMQuery{ParentIDs: pending})
+		if err != nil {
+			return err
+		}
+		for _, id := range pending {
+			visited[id] = true
+		}
+
+		pending = pending[:0]
+		for _, component := range nested {
+			if component.ComponentID == parentID {
+				return fmt.Errorf("%w: item %s is already built from this kit", ErrInvalidBOM, component.ParentID)
+			}
+			if !visited[component.ComponentID] {
+				visited[component.ComponentID] = true
+				pending = append(pending, component.ComponentID)
+			}
+		}
+	}
+	return nil
+}
+
+// GetBOM returns the bill of materials of a kit, empty for a plain item.
+func (m *InventoryManager) GetBOM(ctx context.Context, parentID string) ([]*models.BOMComponent, error) {
+	if _, err := m.Store.GetItemByID(ctx, parentID); err != nil {
+		return nil, err
+	}
+	return m.Store.GetBOMComponents(ctx, models.BOMQuery{ParentIDs: []string{parentID}})
+}
+
+// BuildableQuantities returns how many units of each kit among items could
+// be assembled from the components available now, keyed by item ID. Items
+// without a bill of materials are left out.
+func (m *InventoryManager) BuildableQuantities(ctx context.Context, items []*models.Inventory) (map[string]int, error) {
+	buildable := make(map[string]int)
+	if len(items) == 0 {
+		return buildable, nil
+	}
+
+	parentIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		parentIDs = append(parentIDs, item.ID)
+	}
+	components, err := m.Store.GetBOMComponents(ctx, models.BOMQuery{ParentIDs: parentIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]int)
+	for _, component := range components {
+		if _, ok := available[component.ComponentID]; !ok {
+			item, err := m.Store.GetItemByID(ctx, component.ComponentID)
+			if err != nil {
+				return nil, err
+			}
+			available[component.ComponentID] = max(item.Available(), 0)
+		}
+
+		units := available[component.ComponentID] / component.Quantity
+		if current, ok := buildable[component.ParentID]; !ok || units < current {
+			buildable[component.ParentID] = units
+		}
+	}
+	return buildable, nil
+}
+
+// AssembleKit builds quantity units of a kit, taking its components out of
+// stock and adding the kit, at the location if one is given. The ledger
+// records every step as an assembly, all in one transaction.
+func (m *InventoryManager) AssembleKit(ctx context.Context, parentID string, quantity int, locationID *string, user string) (*models.Inventory, error) {
+	return m.changeKits(ctx, parentID, quantity, locationID, user, models.MovementAssembly)
+}
+
+// DisassembleKit takes quantity units of a kit apart, putting its
+// components back into stock.
+func (m *InventoryManager) DisassembleKit(ctx context.Context, parentID string, quantity int, locationID *string, user string) (*models.Inventory, error) {
+	return m.changeKits(ctx, parentID, quantity, locationID, user, models.MovementDisassembly)
+}
+
+func (m *InventoryManager) changeKits(ctx context.Context, parentID string, quantity int, locationID *string, user, reason string) (*models.Inventory, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: must be positive", ErrInvalidQuantity)
+	}
+
+	// Assembly adds kits and removes components; disassembly the reverse.
+	sign := 1
+	if reason == models.MovementDisassembly {
+		sign = -1
+	}
+
+	var item *models.Inventory
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		components, err := m.GetBOM(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return fmt.Errorf("%w: item %s has no components", ErrInvalidBOM, parentID)
+		}
+
+		reference := fmt.Sprintf("%s of %d x kit %s", reason, quantity, parentID)
+		// Stock is taken out before any is put in, so the ledger shows the
+		// components of a kit, or the kit itself, used up first.
+		kit := &models.StockMovement{ItemID: parentID, Delta: sign * quantity}
+		var movements []*models.StockMovement
+		if sign < 0 {
+			movements = append(movements, kit)
+		}
+		for _, component := range components {
+			movements = append(movements, &models.StockMovement{
+				ItemID: component.ComponentID,
+				Delta:  -sign * quantity * component.Quantity,
+			})
+		}
+		if sign > 0 {
+			movements = append(movements, kit)
+		}
+
+		for _, movement := range movements {
+			movement.Reason, movement.Reference, movement.User, movement.LocationID = reason, reference, user, locationID
+			_, changed, err := m.RecordMovement(ctx, movement.ItemID, movement)
+			if err != nil {
+				return fmt.Errorf("item %s: %w", movement.ItemID, err)
+			}
+			if movement.ItemID == parentID {
+				item = changed
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
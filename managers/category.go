@@ -0,0 +1,298 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"strings"
+)
+
+var (
+	ErrInvalidCategory = errors.New("invalid category")
+	ErrCategoryExists  = errors.New("category already exists")
+	ErrCategoryInUse   = errors.New("category in use")
+)
+
+// CreateCategory adds a category at the top level or under a parent, after
+// its existing siblings. Siblings must have different names.
+func (m *InventoryManager) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidCategory)
+	}
+
+	var created *models.Category
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.Store.LockCategories(ctx); err != nil {
+			return err
+		}
+		categories, err := m.Store.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+		if err := checkCategoryParent(categories, category); err != nil {
+			return err
+		}
+
+		category.Position = nextCategoryPosition(categories, category.ParentID)
+		created, err = m.Store.CreateCategory(ctx, category)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetCategories returns every category, siblings in their order.
+func (m *InventoryManager) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	return m.Store.GetCategories(ctx)
+}
+
+func (m *InventoryManager) GetCategoryByID(ctx context.Context, id string) (*models.Category, error) {
+	return m.Store.GetCategoryByID(ctx, id)
+}
+
+// UpdateCategory renames a category and moves it, with everything beneath
+// it, under another parent or to the top level. A moved category goes after
+// its new siblings. A category cannot move under itself or its descendants.
+func (m *InventoryManager) UpdateCategory(ctx context.Context, id, name string, parentID *string) (*models.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidCategory)
+	}
+
+	var category *models.Category
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.Store.LockCategories(ctx); err != nil {
+			return err
+		}
+		var err error
+		if category, err = m.Store.GetCategoryByID(ctx, id); err != nil {
+			return err
+		}
+		categories, err := m.Store.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+
+		moved := !sameParent(category.ParentID, parentID)
+		if moved && parentID != nil {
+			for _, descendant := range categorySubtree(categories, id) {
+				if descendant == *parentID {
+					return fmt.Errorf("%w: a category cannot be moved under itself or its descendants", ErrInvalidCategory)
+				}
+			}
+		}
+
+		category.Name, category.ParentID = name, parentID
+		if err := checkCategoryParent(categories, category); err != nil {
+			return err
+		}
+		if moved {
+			category.Position = nextCategoryPosition(categories, parentID)
+		}
+		return m.Store.UpdateCategory(ctx, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// ReorderCategories puts the children of a parent, or the top-level
+// categories when parentID is nil, in the order of ids, which must list
+// each of them exactly once.
+func (m *InventoryManager) ReorderCategories(ctx context.Context, parentID *string, ids []string) ([]*models.Category, error) {
+	var siblings []*models.Category
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.Store.LockCategories(ctx); err != nil {
+			return err
+		}
+		if parentID != nil {
+			if _, err := m.Store.GetCategoryByID(ctx, *parentID); err != nil {
+				return err
+			}
+		}
+		categories, err := m.Store.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[string]*models.Category)
+		for _, category := range categories {
+			if sameParent(category.ParentID, parentID) {
+				byID[category.ID] = category
+			}
+		}
+		if len(ids) != len(byID) {
+			return fmt.Errorf("%w: the order must list all %d categories exactly once", ErrInvalidCategory, len(byID))
+		}
+
+		siblings = make([]*models.Category, 0, len(ids))
+		for position, id := range ids {
+			category, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%w: %s is not one of the categories being ordered, or is listed twice", ErrInvalidCategory, id)
+			}
+			delete(byID, id)
+
+			category.Position = position
+			if err := m.Store.UpdateCategory(ctx, category); err != nil {
+				return err
+			}
+			siblings = append(siblings, category)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return siblings, nil
+}
+
+// DeleteCategory removes a category that has no subcategories and no items.
+func (m *InventoryManager) DeleteCategory(ctx context.Context, id string) error {
+	return m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.Store.LockCategories(ctx); err != nil {
+			return err
+		}
+		if _, err := m.Store.GetCategoryByID(ctx, id); err != nil {
+			return err
+		}
+
+		categories, err := m.Store.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+		for _, category := range categories {
+			if category.ParentID != nil && *category.ParentID == id {
+				return fmt.Errorf("%w: it contains other categories", ErrCategoryInUse)
+			}
+		}
+
+		page, err := m.Store.GetItems(ctx, models.ItemQuery{Limit: 1, Filter: models.ItemFilter{CategoryIDs: []string{id}}})
+		if err != nil {
+			return err
+		}
+		if page.TotalCount > 0 {
+			return fmt.Errorf("%w: it has items", ErrCategoryInUse)
+		}
+
+		return m.Store.DeleteCategory(ctx, id)
+	})
+}
+
+// checkItemCategory fails unless the category an item is put in exists.
+func (m *InventoryManager) checkItemCategory(ctx context.Context, categoryID *string) error {
+	if categoryID == nil {
+		return nil
+	}
+	_, err := m.Store.GetCategoryByID(ctx, *categoryID)
+	if errors.Is(err, service.ErrCategoryNotFound) {
+		return fmt.Errorf("%w: category %s not found", ErrInvalidCategory, *categoryID)
+	}
+	return err
+}
+
+// expandCategoryFilter widens the categories an item listing is filtered by
+// to every category beneath them.
+func (m *InventoryManager) expandCategoryFilter(ctx context.Context, filter *models.ItemFilter) error {
+	if len(filter.CategoryIDs) == 0 {
+		return nil
+	}
+
+	categories, err := m.Store.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	var expanded []string
+	seen := make(map[string]bool)
+	for _, id := range filter.CategoryIDs {
+		if !known[id] {
+			return fmt.Errorf("%w: category %s not found", ErrInvalidQuery, id)
+		}
+		for _, descendant := range categorySubtree(categories, id) {
+			if !seen[descendant] {
+				seen[descendant] = true
+				expanded = append(expanded, descendant)
+			}
+		}
+	}
+	filter.CategoryIDs = expanded
+	return nil
+}
+
+// checkCategoryParent fails unless the parent of category exists and none
+// of its other children has the same name.
+func checkCategoryParent(categories []*models.Category, category *models.Category) error {
+	if category.ParentID != nil {
+		found := false
+		for _, other := range categories {
+			if other.ID == *category.ParentID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: parent category not found", ErrInvalidCategory)
+		}
+	}
+
+	for _, other := range categories {
+		if other.ID != category.ID && sameParent(other.ParentID, category.ParentID) &&
+			strings.EqualFold(other.Name, category.Name) {
+			return fmt.Errorf("%w: %q is already a category there", ErrCategoryExists, category.Name)
+		}
+	}
+	return nil
+}
+
+// nextCategoryPosition returns the position after the last child of
+// parentID.
+func nextCategoryPosition(categories []*models.Category, parentID *string) int {
+	next := 0
+	for _, category := range categories {
+		if sameParent(category.ParentID, parentID) && category.Position >= next {
+			next = category.Position + 1
+		}
+	}
+	return next
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// categorySubtree returns id and the IDs of every category beneath it, each
+// once even if the parents loop.
+func categorySubtree(categories []*models.Category, id string) []string {
+	children := make(map[string][]string)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	subtree := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(subtree); i++ {
+		for _, child := range children[subtree[i]] {
+			if !seen[child] {
+				seen[child] = true
+				subtree = append(subtree, child)
+			}
+		}
+	}
+	return subtree
+}
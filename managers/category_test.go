@@ -0,0 +1,58 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentCategoryMovesCannotLoop(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	a, err := m.CreateCategory(ctx, &models.Category{Name: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.CreateCategory(ctx, &models.Category{Name: "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errA, errB error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errA = m.UpdateCategory(ctx, a.ID, a.Name, &b.ID)
+	}()
+	go func() {
+		defer wg.Done()
+		_, errB = m.UpdateCategory(ctx, b.ID, b.Name, &a.ID)
+	}()
+	wg.Wait()
+
+	if (errA == nil) == (errB == nil) {
+		t.Fatalf("moving A under B: %v, B under A: %v; want exactly one to fail", errA, errB)
+	}
+	for _, err := range []error{errA, errB} {
+		if err != nil && !errors.Is(err, ErrInvalidCategory) {
+			t.Errorf("losing move: %v, want ErrInvalidCategory", err)
+		}
+	}
+}
+
+func TestCategorySubtreeStopsAtLoops(t *testing.T) {
+	a, b, c := "a", "b", "c"
+	categories := []*models.Category{
+		{ID: a, ParentID: &c},
+		{ID: b, ParentID: &a},
+		{ID: c, ParentID: &b},
+	}
+
+	if subtree := categorySubtree(categories, a); len(subtree) != 3 {
+		t.Errorf("categorySubtree = %v, want each of the 3 categories once", subtree)
+	}
+}
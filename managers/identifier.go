@@ -0,0 +1,122 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	"strings"
+	"unicode"
+)
+
+// MaxSKULength is the longest SKU an item can have.
+const MaxSKULength = 64
+
+var (
+	ErrInvalidSKU     = errors.New("invalid sku")
+	ErrInvalidBarcode = errors.New("invalid barcode")
+)
+
+func (m *InventoryManager) GetItemBySKU(ctx context.Context, sku string) (*models.Inventory, error) {
+	sku, err := normalizeSKU(sku)
+	if err != nil {
+		return nil, err
+	}
+	if sku == "" {
+		return nil, fmt.Errorf("%w: sku must not be empty", ErrInvalidSKU)
+	}
+
+	return m.Store.GetItemBySKU(ctx, sku)
+}
+
+// GetItemByBarcode finds the item with the barcode in any of its EAN-13,
+// UPC-A or GTIN-14 forms, as a scanner reads it.
+func (m *InventoryManager) GetItemByBarcode(ctx context.Context, code string) (*models.Inventory, error) {
+	gtin, err := normalizeBarcode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Store.GetItemByBarcode(ctx, gtin)
+}
+
+// normalizeIdentifiers validates the SKU and barcodes of item, trimming the
+// SKU and converting the barcodes to 14-digit GTINs.
+func normalizeIdentifiers(item *models.Inventory) error {
+	sku, err := normalizeSKU(item.SKU)
+	if err != nil {
+		return err
+	}
+	barcodes, err := normalizeBarcodes(item.Barcodes)
+	if err != nil {
+		return err
+	}
+
+	item.SKU, item.Barcodes = sku, barcodes
+	return nil
+}
+
+func normalizeSKU(sku string) (string, error) {
+	sku = strings.TrimSpace(sku)
+	if len(sku) > MaxSKULength {
+		return "", fmt.Errorf("%w: sku must not be longer than %d characters", ErrInvalidSKU, MaxSKULength)
+	}
+	if strings.IndexFunc(sku, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("%w: sku must not contain spaces", ErrInvalidSKU)
+	}
+	return sku, nil
+}
+
+// normalizeBarcodes converts codes to GTINs, rejecting codes that name the
+// same GTIN twice.
+func normalizeBarcodes(codes []string) ([]string, error) {
+	gtins := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		gtin, err := normalizeBarcode(code)
+		if err != nil {
+			return nil, err
+		}
+		if seen[gtin] {
+			return nil, fmt.Errorf("%w: %s is listed twice", ErrInvalidBarcode, code)
+		}
+		seen[gtin] = true
+		gtins = append(gtins, gtin)
+	}
+	return gtins, nil
+}
+
+// normalizeBarcode checks the check digit of a UPC-A (12 digits), EAN-13
+// (13 digits) or GTIN-14 (14 digits) code and returns it as a GTIN-14,
+// padded with leading zeros as GS1 stores GTINs.
+func normalizeBarcode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 12 || len(code) > 14 {
+		return "", fmt.Errorf("%w: %q must have 12 (UPC-A), 13 (EAN-13) or 14 (GTIN-14) digits", ErrInvalidBarcode, code)
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q must contain only digits", ErrInvalidBarcode, code)
+		}
+	}
+
+	if check := gtinCheckDigit(code[:len(code)-1]); code[len(code)-1] != check {
+		return "", fmt.Errorf("%w: %q has check digit %c, expected %c", ErrInvalidBarcode, code, code[len(code)-1], check)
+	}
+	return strings.Repeat("0", 14-len(code)) + code, nil
+}
+
+// gtinCheckDigit computes the GS1 check digit of the digits before it:
+// weighting them 3 and 1 alternately from the right, the check digit
+// brings their sum up to a multiple of ten.
+func gtinCheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,119 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		code, want string
+	}{
+		{"4006381333931", "04006381333931"},  // EAN-13
+		{"5901234123457", "05901234123457"},  // EAN-13
+		{"9780306406157", "09780306406157"},  // ISBN-13
+		{"036000291452", "00036000291452"},   // UPC-A
+		{" 036000291452 ", "00036000291452"}, // scanners add whitespace
+		{"0036000291452", "00036000291452"},  // UPC-A as EAN-13
+		{"10012345678902", "10012345678902"}, // GTIN-14
+		{"00000000000000", "00000000000000"}, // all zeros checks out
+		{"4006381333932", ""},                // wrong check digit
+		{"036000291453", ""},                 // wrong check digit
+		{"10012345678901", ""},               // wrong check digit
+		{"03600029145", ""},                  // UPC-E and shorter are not supported
+		{"100123456789020", ""},              // too long
+		{"40063813339A1", ""},                // not a digit
+		{"400638133393\u0663", ""},           // not an ASCII digit
+		{"", ""},                             // empty
+	}
+	for _, tt := range tests {
+		got, err := normalizeBarcode(tt.code)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidBarcode) {
+				t.Errorf("normalizeBarcode(%q) = %q, %v, want ErrInvalidBarcode", tt.code, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeBarcode(%q) = %q, %v, want %q", tt.code, got, err, tt.want)
+		}
+	}
+
+	if _, err := normalizeBarcodes([]string{"036000291452", "0036000291452"}); !errors.Is(err, ErrInvalidBarcode) {
+		t.Errorf("one GTIN listed twice: %v, want ErrInvalidBarcode", err)
+	}
+}
+
+func TestNormalizeSKU(t *testing.T) {
+	tests := []struct {
+		sku, want string
+		err       bool
+	}{
+		{"WID-001", "WID-001", false},
+		{"  WID-001\t", "WID-001", false},
+		{"", "", false},
+		{"WID 001", "", true},
+		{"WID\u00a0001", "", true},
+		{strings.Repeat("X", MaxSKULength), strings.Repeat("X", MaxSKULength), false},
+		{strings.Repeat("X", MaxSKULength+1), "", true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeSKU(tt.sku)
+		if tt.err {
+			if !errors.Is(err, ErrInvalidSKU) {
+				t.Errorf("normalizeSKU(%q): %v, want ErrInvalidSKU", tt.sku, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeSKU(%q) = %q, %v, want %q", tt.sku, got, err, tt.want)
+		}
+	}
+}
+
+func TestIdentifiersAreUnique(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	item := createTestItem(t, m, &models.Inventory{SKU: " WID-001 ", Barcodes: []string{"036000291452"}}, 0)
+	if item.SKU != "WID-001" || len(item.Barcodes) != 1 || item.Barcodes[0] != "00036000291452" {
+		t.Errorf("stored SKU %q and barcodes %v, want WID-001 and the GTIN-14", item.SKU, item.Barcodes)
+	}
+
+	other := &models.Inventory{Name: "Gadget", Price: 100, Currency: "USD", Vendor: "Acme", SKU: "WID-001"}
+	if _, err := m.CreateItem(ctx, other); !errors.Is(err, service.ErrSKUExists) {
+		t.Errorf("reusing a SKU: %v, want ErrSKUExists", err)
+	}
+	other.SKU, other.Barcodes = "GAD-001", []string{"0036000291452"}
+	if _, err := m.CreateItem(ctx, other); !errors.Is(err, service.ErrBarcodeExists) {
+		t.Errorf("reusing a barcode in its EAN-13 form: %v, want ErrBarcodeExists", err)
+	}
+	other.Barcodes = nil
+	if _, err := m.CreateItem(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+
+	// An item keeps its own identifiers when it is updated.
+	update := *item
+	update.Vendor = "Globex"
+	if _, err := m.UpdateItem(ctx, item.ID, &update); err != nil {
+		t.Errorf("updating an item with its own identifiers: %v", err)
+	}
+
+	for _, code := range []string{"036000291452", "0036000291452", "00036000291452"} {
+		found, err := m.GetItemByBarcode(ctx, code)
+		if err != nil || found.ID != item.ID {
+			t.Errorf("GetItemByBarcode(%q) = %v, want the item", code, err)
+		}
+	}
+	if found, err := m.GetItemBySKU(ctx, " WID-001"); err != nil || found.ID != item.ID {
+		t.Errorf("GetItemBySKU = %v, want the item", err)
+	}
+	if _, err := m.GetItemBySKU(ctx, "wid-001"); !errors.Is(err, service.ErrItemNotFound) {
+		t.Errorf("GetItemBySKU in lower case: %v, want ErrItemNotFound", err)
+	}
+}
@@ -7,101 +7,212 @@ import (
 	"log"
 	"main/models"
 	service "main/services"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+	"time"
 )
 
-type InventoryManager struct{}
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
 
-func (m *InventoryManager) GetItems(ctx context.Context, flag bool) ([]*models.Inventory, int64, error) {
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
 
-	switch flag {
-	case true:
-		items, totalCount, err := service.GetItems(ctx)
-		if err != nil {
-			return nil, 0, err
-		}
-		return items, totalCount, nil
+	DefaultReservationTTL    = 15 * time.Minute
+	DefaultMaxReservationTTL = 24 * time.Hour
+)
 
-	case false:
-		items, totalCount, err := service.GetItemsPostgres(ctx)
-		if err != nil {
-			return nil, 0, err
-		}
-		return items, totalCount, nil
+var (
+	ErrInvalidQuery = errors.New("invalid query")
+	ErrItemInUse    = errors.New("item in use")
+)
+
+type InventoryManager struct {
+	Store service.InventoryStore
 
-	default:
-		return nil, 0, errors.New("invalid flag type")
+	// ReservationTTL is how long a reservation holds stock when the caller
+	// does not say; no reservation may hold it longer than MaxReservationTTL.
+	ReservationTTL    time.Duration
+	MaxReservationTTL time.Duration
+
+	// Notifier, if set, is told about new low-stock alerts.
+	Notifier AlertNotifier
+}
+
+func NewInventoryManager(store service.InventoryStore) *InventoryManager {
+	return &InventoryManager{
+		Store:             store,
+		ReservationTTL:    DefaultReservationTTL,
+		MaxReservationTTL: DefaultMaxReservationTTL,
 	}
 }
 
-func (m *InventoryManager) CreateItem(ctx context.Context, flag bool, item *models.Inventory) (*models.Inventory, error) {
-	
+// GetItems returns one page of the items matching query.Filter, ordered by
+// query.Sort and then by ID. A zero limit selects DefaultPageSize and larger
+// limits are capped at MaxPageSize.
+func (m *InventoryManager) GetItems(ctx context.Context, query models.ItemQuery) (*models.ItemPage, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	if query.Cursor != "" && query.Offset > 0 {
+		return nil, fmt.Errorf("%w: cursor and offset cannot be combined", ErrInvalidQuery)
+	}
+	if filter := query.Filter; filter.PriceMin != nil && filter.PriceMax != nil && *filter.PriceMin > *filter.PriceMax {
+		return nil, fmt.Errorf("%w: price_min must not exceed price_max", ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+	if err := m.expandCategoryFilter(ctx, &query.Filter); err != nil {
+		return nil, err
+	}
 
-	switch flag {
-	case true:
-		return service.CreateItem(ctx, item)
+	page, err := m.Store.GetItems(ctx, query)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	case false:
-		return service.CreateItemPostgres(ctx, item)
+	page.Limit = query.Limit
+	return page, nil
+}
 
-	default:
-		return nil, errors.New("invalid flag type")
+func (m *InventoryManager) CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
+	if err := normalizeIdentifiers(item); err != nil {
+		return nil, err
+	}
+	if err := m.checkItemCategory(ctx, item.CategoryID); err != nil {
+		return nil, err
 	}
+	return m.Store.CreateItem(ctx, item)
 }
 
-func (m *InventoryManager) GetItemByID(ctx context.Context, flag bool, id string) (*models.Inventory, error) {
-	switch flag {
-	case true:
-		return service.GetItemByID(ctx, id)
-	case false:
-		return service.GetItemByIDPostgres(ctx, id)
-	default:
-		return nil, errors.New("invalid flag type")
-	}
+func (m *InventoryManager) GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
+	return m.Store.GetItemByID(ctx, id)
 }
 
-func (m *InventoryManager) UpdateItem(ctx context.Context, flag bool, id string, item *models.Inventory) (*models.Inventory, error) {
-	log.Printf("Updating item with flag: %v, ID: %v", flag, id)
+func (m *InventoryManager) UpdateItem(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error) {
+	log.Printf("Updating item with ID: %v", id)
 
-	switch flag {
-	case true:
-		objectID, err := primitive.ObjectIDFromHex(id)
-		if err != nil {
-			log.Printf("Invalid ID format: %v", err)
-			return nil, err
-		}
+	if err := normalizeIdentifiers(item); err != nil {
+		return nil, err
+	}
+	if err := m.checkItemCategory(ctx, item.CategoryID); err != nil {
+		return nil, err
+	}
 
-		updatedItem, err := service.UpdateItem(ctx, objectID, item)
+	var updatedItem *models.Inventory
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := m.Store.GetItemByID(ctx, id)
 		if err != nil {
-			log.Printf("Error updating item in service: %v", err)
-			return nil, fmt.Errorf("failed to update item: %v", err)
+			return err
 		}
-		return updatedItem, nil
-
-	case false:
-		return service.UpdateItemPostgres(ctx, id, item)
-
-	default:
-		return nil, errors.New("invalid flag type")
+		if err := checkTrackingChange(current, &item.LotTracked, &item.Serialized); err != nil {
+			return err
+		}
+		updatedItem, err = m.Store.UpdateItem(ctx, id, item)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error updating item in store: %v", err)
+		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
+	return updatedItem, nil
 }
 
-func (m *InventoryManager) DeleteItem(ctx context.Context, flag bool, id string) error {
-	switch flag {
-	case true:
+// checkTrackingChange refuses to turn lot tracking or serial numbers on or
+// off while the item has stock, whose lots or serials would no longer add
+// up. A nil lotTracked or serialized leaves that setting as it is.
+func checkTrackingChange(item *models.Inventory, lotTracked, serialized *bool) error {
+	if lotTracked != nil && *lotTracked != item.LotTracked && item.OnHand != 0 {
+		return fmt.Errorf("%w: lot tracking can only change while the item has no stock", ErrItemInUse)
+	}
+	if serialized != nil && *serialized != item.Serialized && item.OnHand != 0 {
+		return fmt.Errorf("%w: serial numbers can only be turned on or off while the item has no stock", ErrItemInUse)
+	}
+	return nil
+}
 
-		objectID, err := primitive.ObjectIDFromHex(id)
+// DeleteItem removes an item, together with its bill of materials if it is
+// a kit. An item that is still in use, see checkItemUnused, or that is a
+// component of a kit cannot be deleted.
+func (m *InventoryManager) DeleteItem(ctx context.Context, id string) error {
+	return m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		item, err := m.Store.GetItemByID(ctx, id)
 		if err != nil {
 			return err
 		}
+		if err := m.checkItemUnused(ctx, item); err != nil {
+			return err
+		}
 
-		return service.DeleteItem(ctx, objectID.Hex())
-
-	case false:
-		return service.DeleteItemPostgres(ctx, id)
+		kits, err := m.Store.GetBOMComponents(ctx, models.BOMQuery{ComponentIDs: []string{id}})
+		if err != nil {
+			return err
+		}
+		if len(kits) > 0 {
+			return fmt.Errorf("%w: it is a component of kit %s", ErrItemInUse, kits[0].ParentID)
+		}
+		return m.Store.DeleteItem(ctx, id)
+	})
+}
 
-	default:
-		return errors.New("invalid flag type")
+// checkItemUnused fails with ErrItemInUse while the item has stock on hand
+// or reserved, is on an open purchase or sales order, or has lots or serial
+// numbers.
+func (m *InventoryManager) checkItemUnused(ctx context.Context, item *models.Inventory) error {
+	if item.OnHand != 0 || item.Reserved != 0 {
+		return fmt.Errorf("%w: it has %d units on hand and %d reserved", ErrItemInUse, item.OnHand, item.Reserved)
+	}
+	order, err := m.openPurchaseOrderFor(ctx, item.ID)
+	if err != nil {
+		return err
 	}
+	if order != nil {
+		return fmt.Errorf("%w: it is on open purchase order %s", ErrItemInUse, order.ID)
+	}
+	salesOrder, err := m.openSalesOrderFor(ctx, item.ID)
+	if err != nil {
+		return err
+	}
+	if salesOrder != nil {
+		return fmt.Errorf("%w: it is on open sales order %s", ErrItemInUse, salesOrder.ID)
+	}
+	_, lots, err := m.Store.GetLots(ctx, models.LotQuery{ItemIDs: []string{item.ID}, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if lots > 0 {
+		return fmt.Errorf("%w: it has %d lots", ErrItemInUse, lots)
+	}
+	_, serials, err := m.Store.GetSerials(ctx, models.SerialQuery{ItemID: item.ID, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if serials > 0 {
+		return fmt.Errorf("%w: it has %d serial numbers", ErrItemInUse, serials)
+	}
+	return nil
 }
 
+func (m *InventoryManager) SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: q must not be empty", ErrInvalidQuery)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidQuery)
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	return m.Store.SearchItems(ctx, query, limit)
+}
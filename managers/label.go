@@ -0,0 +1,115 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/labels"
+	"main/models"
+)
+
+// MaxSheetLabels is the most labels a single sheet request can print.
+const MaxSheetLabels = 1000
+
+// Values of LabelOptions.Encode.
+const (
+	LabelEncodeSKU = "sku"
+	LabelEncodeID  = "id"
+)
+
+var ErrInvalidLabel = errors.New("invalid label")
+
+// LabelOptions choose the barcode printed on labels and what it encodes:
+// the item's SKU, its ID, or by default the SKU of items that have one and
+// the ID of the others.
+type LabelOptions struct {
+	Symbology labels.Symbology
+	Encode    string
+}
+
+// LabelCopies asks for a number of labels for an item.
+type LabelCopies struct {
+	ItemID string
+	Copies int
+}
+
+// ItemLabel builds the shelf label of an item.
+func (m *InventoryManager) ItemLabel(ctx context.Context, id string, opts LabelOptions) (*labels.Label, error) {
+	if err := checkLabelOptions(opts); err != nil {
+		return nil, err
+	}
+
+	item, err := m.Store.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return itemLabel(item, opts)
+}
+
+// LabelSheet builds the labels for a sheet, in the order asked for, each
+// item repeated as many times as it has copies.
+func (m *InventoryManager) LabelSheet(ctx context.Context, copies []LabelCopies, opts LabelOptions) ([]*labels.Label, error) {
+	if err := checkLabelOptions(opts); err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, c := range copies {
+		if c.Copies < 1 {
+			return nil, fmt.Errorf("%w: copies must be positive", ErrInvalidLabel)
+		}
+		total += c.Copies
+		if total > MaxSheetLabels {
+			return nil, fmt.Errorf("%w: at most %d labels can be printed at once", ErrInvalidLabel, MaxSheetLabels)
+		}
+	}
+
+	built := make(map[string]*labels.Label, len(copies))
+	sheet := make([]*labels.Label, 0, total)
+	for _, c := range copies {
+		label, ok := built[c.ItemID]
+		if !ok {
+			item, err := m.Store.GetItemByID(ctx, c.ItemID)
+			if err != nil {
+				return nil, err
+			}
+			if label, err = itemLabel(item, opts); err != nil {
+				return nil, err
+			}
+			built[c.ItemID] = label
+		}
+		for i := 0; i < c.Copies; i++ {
+			sheet = append(sheet, label)
+		}
+	}
+	return sheet, nil
+}
+
+func checkLabelOptions(opts LabelOptions) error {
+	switch opts.Symbology {
+	case labels.Code128, labels.QR:
+	default:
+		return fmt.Errorf("%w: unknown barcode %q", ErrInvalidLabel, opts.Symbology)
+	}
+	switch opts.Encode {
+	case "", LabelEncodeSKU, LabelEncodeID:
+	default:
+		return fmt.Errorf("%w: cannot encode %q", ErrInvalidLabel, opts.Encode)
+	}
+	return nil
+}
+
+func itemLabel(item *models.Inventory, opts LabelOptions) (*labels.Label, error) {
+	content := item.SKU
+	switch {
+	case opts.Encode == LabelEncodeID, opts.Encode == "" && item.SKU == "":
+		content = item.ID
+	case item.SKU == "":
+		return nil, fmt.Errorf("%w: item %s has no sku", ErrInvalidLabel, item.ID)
+	}
+
+	label, err := labels.New(item.Name, labels.FormatPrice(item.Price, item.Currency), opts.Symbology, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLabel, err)
+	}
+	return label, nil
+}
@@ -0,0 +1,170 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"sort"
+)
+
+var (
+	ErrInvalidLocation = errors.New("invalid location")
+	ErrLocationInUse   = errors.New("location in use")
+)
+
+// CreateLocation adds a warehouse, or a zone or bin under the parent type
+// models.LocationParentTypes requires.
+func (m *InventoryManager) CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error) {
+	parentType, ok := models.LocationParentTypes[location.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLocation, location.Type)
+	}
+
+	if parentType == "" {
+		if location.ParentID != nil {
+			return nil, fmt.Errorf("%w: a %s cannot have a parent", ErrInvalidLocation, location.Type)
+		}
+		return m.Store.CreateLocation(ctx, location)
+	}
+
+	if location.ParentID == nil {
+		return nil, fmt.Errorf("%w: a %s must be inside a %s", ErrInvalidLocation, location.Type, parentType)
+	}
+	parent, err := m.Store.GetLocationByID(ctx, *location.ParentID)
+	if errors.Is(err, service.ErrLocationNotFound) {
+		return nil, fmt.Errorf("%w: parent location not found", ErrInvalidLocation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if parent.Type != parentType {
+		return nil, fmt.Errorf("%w: a %s must be inside a %s, not a %s", ErrInvalidLocation, location.Type, parentType, parent.Type)
+	}
+
+	return m.Store.CreateLocation(ctx, location)
+}
+
+func (m *InventoryManager) GetLocations(ctx context.Context) ([]*models.Location, error) {
+	return m.Store.GetLocations(ctx)
+}
+
+func (m *InventoryManager) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	return m.Store.GetLocationByID(ctx, id)
+}
+
+// DeleteLocation removes a location that has no child locations, holds no
+// stock and is not the source or destination of an open transfer.
+func (m *InventoryManager) DeleteLocation(ctx context.Context, id string) error {
+	return m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		locations, err := m.Store.GetLocations(ctx)
+		if err != nil {
+			return err
+		}
+		for _, location := range locations {
+			if location.ParentID != nil && *location.ParentID == id {
+				return fmt.Errorf("%w: it contains other locations", ErrLocationInUse)
+			}
+		}
+
+		levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{LocationIDs: []string{id}})
+		if err != nil {
+			return err
+		}
+		if len(levels) > 0 {
+			return fmt.Errorf("%w: it holds stock", ErrLocationInUse)
+		}
+
+		for _, status := range []string{models.TransferDraft, models.TransferShipped} {
+			_, count, err := m.Store.GetTransfers(ctx, models.TransferQuery{LocationID: id, Status: status, Limit: 1})
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: it has %s transfers", ErrLocationInUse, status)
+			}
+		}
+
+		return m.Store.DeleteLocation(ctx, id)
+	})
+}
+
+// GetLocationStock returns the on-hand quantity of every item stocked at the
+// location or anywhere inside it, one level per item.
+func (m *InventoryManager) GetLocationStock(ctx context.Context, id string) ([]*models.StockLevel, error) {
+	if _, err := m.Store.GetLocationByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	locations, err := m.Store.GetLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{LocationIDs: locationSubtree(locations, id)})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]*models.StockLevel)
+	var stock []*models.StockLevel
+	for _, level := range levels {
+		total, ok := totals[level.ItemID]
+		if !ok {
+			total = &models.StockLevel{ItemID: level.ItemID, LocationID: id}
+			totals[level.ItemID] = total
+			stock = append(stock, total)
+		}
+		total.OnHand += level.OnHand
+	}
+	sort.Slice(stock, func(i, j int) bool { return stock[i].ItemID < stock[j].ItemID })
+
+	return stock, nil
+}
+
+// locationSubtree returns id and the IDs of every location inside it.
+func locationSubtree(locations []*models.Location, id string) []string {
+	children := make(map[string][]string)
+	for _, location := range locations {
+		if location.ParentID != nil {
+			children[*location.ParentID] = append(children[*location.ParentID], location.ID)
+		}
+	}
+
+	subtree := []string{id}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, children[subtree[i]]...)
+	}
+	return subtree
+}
+
+// GetItemStockLevels returns where the item's stock is kept.
+func (m *InventoryManager) GetItemStockLevels(ctx context.Context, itemID string) ([]*models.StockLevel, error) {
+	if _, err := m.Store.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	return m.Store.GetStockLevels(ctx, models.StockLevelQuery{ItemIDs: []string{itemID}})
+}
+
+// StockLevelsByItem returns the stock levels of the items, keyed by item ID.
+func (m *InventoryManager) StockLevelsByItem(ctx context.Context, items []*models.Inventory) (map[string][]*models.StockLevel, error) {
+	byItem := make(map[string][]*models.StockLevel, len(items))
+	if len(items) == 0 {
+		return byItem, nil
+	}
+
+	itemIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{ItemIDs: itemIDs})
+	if err != nil {
+		return nil, err
+	}
+	for _, level := range levels {
+		byItem[level.ItemID] = append(byItem[level.ItemID], level)
+	}
+	return byItem, nil
+}
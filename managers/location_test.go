@@ -0,0 +1,52 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"testing"
+)
+
+func createTestLocation(t *testing.T, m *InventoryManager, name string) *models.Location {
+	t.Helper()
+	location, err := m.CreateLocation(context.Background(), &models.Location{Name: name, Type: models.LocationWarehouse})
+	if err != nil {
+		t.Fatalf("CreateLocation: %v", err)
+	}
+	return location
+}
+
+func TestDeleteLocationWithOpenTransfer(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 0)
+	from, to := createTestLocation(t, m, "North"), createTestLocation(t, m, "South")
+	ctx := context.Background()
+
+	_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: 3, Reason: models.MovementReceipt, LocationID: &from.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer, err := m.CreateTransfer(ctx, &models.Transfer{ItemID: item.ID, FromLocationID: from.ID, ToLocationID: to.ID, Quantity: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.DeleteLocation(ctx, to.ID); !errors.Is(err, ErrLocationInUse) {
+		t.Errorf("deleting the destination of a draft: %v, want ErrLocationInUse", err)
+	}
+	if _, err := m.ShipTransfer(ctx, transfer.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	for _, location := range []*models.Location{from, to} {
+		if err := m.DeleteLocation(ctx, location.ID); !errors.Is(err, ErrLocationInUse) {
+			t.Errorf("deleting %s with a transfer in transit: %v, want ErrLocationInUse", location.Name, err)
+		}
+	}
+
+	if _, err := m.ReceiveTransfer(ctx, transfer.ID, 0, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteLocation(ctx, from.ID); err != nil {
+		t.Errorf("deleting the emptied source of a received transfer: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var ErrInvalidLot = errors.New("invalid lot")
+
+// CreateLot adds an empty lot to a lot-tracked item. Stock goes into it
+// through movements that name the lot.
+func (m *InventoryManager) CreateLot(ctx context.Context, itemID string, lot *models.Lot) (*models.Lot, error) {
+	if lot.LotNumber == "" {
+		return nil, fmt.Errorf("%w: lot number is required", ErrInvalidLot)
+	}
+	if lot.ManufacturedAt != nil && lot.ExpiresAt != nil && lot.ExpiresAt.Before(*lot.ManufacturedAt) {
+		return nil, fmt.Errorf("%w: a lot cannot expire before it is manufactured", ErrInvalidLot)
+	}
+
+	item, err := m.Store.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !item.LotTracked {
+		return nil, fmt.Errorf("%w: item is not lot-tracked", ErrInvalidLot)
+	}
+
+	lot.ItemID = itemID
+	lot.OnHand = 0
+	lot.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	return m.Store.CreateLot(ctx, lot)
+}
+
+// GetLots returns a page of an item's lots, first expiring first.
+func (m *InventoryManager) GetLots(ctx context.Context, itemID string, query models.LotQuery) ([]*models.Lot, int64, error) {
+	if _, err := m.Store.GetItemByID(ctx, itemID); err != nil {
+		return nil, 0, err
+	}
+
+	query.ItemIDs = []string{itemID}
+	return m.getLots(ctx, query)
+}
+
+// ExpiringLots returns a page of the lots with stock on hand that expire
+// within the given number of days, including lots that already expired.
+func (m *InventoryManager) ExpiringLots(ctx context.Context, days int, query models.LotQuery) ([]*models.Lot, int64, error) {
+	if days < 0 {
+		return nil, 0, fmt.Errorf("%w: the expiry window must not be negative", ErrInvalidQuery)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	expiresBefore := today.AddDate(0, 0, days+1)
+	query.ExpiresBefore = &expiresBefore
+	query.InStock = true
+	return m.getLots(ctx, query)
+}
+
+func (m *InventoryManager) getLots(ctx context.Context, query models.LotQuery) ([]*models.Lot, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetLots(ctx, query)
+}
+
+// applyLots changes the lots of a lot-tracked item by the movement's delta,
+// which has already been applied to item. Stock coming in must name its
+// lot. Stock going out comes from the lots the movement names or, if it
+// names none, from the unexpired lots first expired, first out and then
+// from stock that belongs to no lot. Transfers move stock between
+// locations and leave lots alone.
+func (m *InventoryManager) applyLots(ctx context.Context, item *models.Inventory, movement *models.StockMovement) error {
+	if movement.Reason == models.MovementTransfer || !item.LotTracked {
+		if len(movement.Lots) > 0 {
+			return fmt.Errorf("%w: only receipts, issues and adjustments of lot-tracked items name lots", ErrInvalidMovement)
+		}
+		return nil
+	}
+
+	if len(movement.Lots) == 0 {
+		if movement.Delta > 0 {
+			return fmt.Errorf("%w: the item is lot-tracked, name the lot the stock goes into", ErrInvalidMovement)
+		}
+		lots, err := m.consumeLots(ctx, item, -movement.Delta)
+		if err != nil {
+			return err
+		}
+		movement.Lots = lots
+	} else {
+		total := 0
+		for _, entry := range movement.Lots {
+			if entry.Delta == 0 || (entry.Delta > 0) != (movement.Delta > 0) {
+				return fmt.Errorf("%w: lot quantities must have the sign of the delta", ErrInvalidMovement)
+			}
+			total += entry.Delta
+		}
+		if total != movement.Delta {
+			return fmt.Errorf("%w: lot quantities must add up to the delta", ErrInvalidMovement)
+		}
+	}
+
+	for _, entry := range movement.Lots {
+		lot, err := m.Store.GetLotByID(ctx, entry.LotID)
+		if errors.Is(err, service.ErrLotNotFound) || (err == nil && lot.ItemID != item.ID) {
+			return fmt.Errorf("%w: lot %q not found", ErrInvalidMovement, entry.LotID)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := m.Store.AdjustLotStock(ctx, lot.ID, entry.Delta); err != nil {
+			if errors.Is(err, service.ErrInsufficientStock) {
+				return fmt.Errorf("%w: lot %s has only %d units", err, lot.LotNumber, lot.Available())
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// lotEntry puts all of a movement's delta into the lot lotID, if there is
+// one.
+func lotEntry(lotID *string, delta int) []models.MovementLot {
+	if lotID == nil {
+		return nil
+	}
+	return []models.MovementLot{{LotID: *lotID, Delta: delta}}
+}
+
+// consumeLots picks quantity units of item from its unexpired lots, first
+// expiring first, leaving whatever they do not cover to stock that belongs
+// to no lot. Units a lot holds in quarantine are not picked. item already
+// has the units taken off its on-hand quantity.
+func (m *InventoryManager) consumeLots(ctx context.Context, item *models.Inventory, quantity int) ([]models.MovementLot, error) {
+	lots, _, err := m.Store.GetLots(ctx, models.LotQuery{ItemIDs: []string{item.ID}, InStock: true})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	unlotted := item.OnHand + quantity
+	var entries []models.MovementLot
+	for _, lot := range lots {
+		unlotted -= lot.OnHand
+		if quantity == 0 || lot.Available() <= 0 || lot.ExpiredOn(now) {
+			continue
+		}
+		take := min(lot.Available(), quantity)
+		entries = append(entries, models.MovementLot{LotID: lot.ID, Delta: -take})
+		quantity -= take
+	}
+	if quantity > unlotted {
+		return nil, fmt.Errorf("%w: the rest of the stock has expired, name the lot to take it from", service.ErrInsufficientStock)
+	}
+	return entries, nil
+}
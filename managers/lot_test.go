@@ -0,0 +1,194 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"testing"
+	"time"
+)
+
+func TestShipmentTakesLotsFirstExpiredFirstOut(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{LotTracked: true}, 0)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	lots := make(map[string]string)
+	for _, lot := range []struct {
+		number  string
+		expires *time.Time
+	}{
+		{"LATE", ptr(today.AddDate(0, 2, 0))},
+		{"NONE", nil},
+		{"SOON", ptr(today.AddDate(0, 0, 10))},
+		{"EXPIRED", ptr(today.AddDate(0, 0, -1))},
+	} {
+		created, err := m.CreateLot(ctx, item.ID, &models.Lot{LotNumber: lot.number, ExpiresAt: lot.expires})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lots[created.ID] = lot.number
+		_, _, err = m.RecordMovement(ctx, item.ID, &models.StockMovement{
+			Delta: 2, Reason: models.MovementReceipt, Lots: lotEntry(&created.ID, 2),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	movement, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: -5, Reason: models.MovementShipment})
+	if err != nil {
+		t.Fatal(err)
+	}
+	taken := make(map[string]int)
+	var order []string
+	for _, entry := range movement.Lots {
+		taken[lots[entry.LotID]] -= entry.Delta
+		order = append(order, lots[entry.LotID])
+	}
+	if len(order) != 3 || order[0] != "SOON" || order[1] != "LATE" || order[2] != "NONE" {
+		t.Errorf("lots taken in order %v, want SOON, LATE, NONE", order)
+	}
+	if taken["SOON"] != 2 || taken["LATE"] != 2 || taken["NONE"] != 1 || taken["EXPIRED"] != 0 {
+		t.Errorf("taken %v, want 2 from SOON and LATE, 1 from NONE and none from EXPIRED", taken)
+	}
+
+	_, _, err = m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: -2, Reason: models.MovementShipment})
+	if !errors.Is(err, service.ErrInsufficientStock) {
+		t.Errorf("shipping into the expired lot: %v, want ErrInsufficientStock", err)
+	}
+}
+
+func TestQuarantinedLotUnitsAreHeld(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{LotTracked: true}, 0)
+	quarantine := createQuarantine(t, m)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	receive := func(lot *models.Lot, quantity int) {
+		t.Helper()
+		_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{
+			Delta: quantity, Reason: models.MovementReceipt, Lots: lotEntry(&lot.ID, quantity),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	lots := make(map[string]*models.Lot)
+	for _, lot := range []struct {
+		number  string
+		expires time.Time
+	}{
+		{"FIRST", today.AddDate(0, 0, 5)},
+		{"SOON", today.AddDate(0, 0, 10)},
+		{"LATE", today.AddDate(0, 2, 0)},
+	} {
+		created, err := m.CreateLot(ctx, item.ID, &models.Lot{LotNumber: lot.number, ExpiresAt: ptr(lot.expires)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lots[lot.number] = created
+	}
+	receive(lots["SOON"], 2)
+	receive(lots["LATE"], 2)
+
+	order := shipTestOrder(t, m, item, 3)
+	ret, err := m.CreateReturn(ctx, &models.Return{SalesOrderID: order.ID, Lines: []models.ReturnLine{{ItemID: item.ID, Quantity: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := ret.Lines[0].ID
+	_, err = m.ReceiveReturn(ctx, ret.ID, []ReturnReceipt{
+		{LineID: line, Condition: models.ConditionDamaged, Quantity: 1, LocationID: &quarantine.ID, LotID: &lots["SOON"].ID},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive(lots["FIRST"], 1)
+
+	_, _, err = m.RecordMovement(ctx, item.ID, &models.StockMovement{
+		Delta: -1, Reason: models.MovementAdjustment, Lots: lotEntry(&lots["SOON"].ID, -1),
+	})
+	if !errors.Is(err, service.ErrInsufficientStock) {
+		t.Errorf("taking a quarantined unit out of its lot: %v, want ErrInsufficientStock", err)
+	}
+	movement, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: -2, Reason: models.MovementShipment})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range movement.Lots {
+		if entry.LotID == lots["SOON"].ID {
+			t.Errorf("shipment took %d quarantined units of lot SOON", -entry.Delta)
+		}
+	}
+
+	receive(lots["FIRST"], 1)
+	_, err = m.ReleaseReturn(ctx, ret.ID, []ReturnRelease{
+		{LineID: line, Disposition: models.DispositionScrap, Quantity: 1, FromLocationID: quarantine.ID},
+	}, "test")
+	if !errors.Is(err, ErrInvalidReturn) {
+		t.Errorf("releasing without naming the lot: %v, want ErrInvalidReturn", err)
+	}
+	_, err = m.ReleaseReturn(ctx, ret.ID, []ReturnRelease{
+		{LineID: line, Disposition: models.DispositionScrap, Quantity: 1, FromLocationID: quarantine.ID, LotID: &lots["SOON"].ID},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	held, _, err := m.GetLots(ctx, item.ID, models.LotQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lot := range held {
+		want := map[string]int{"FIRST": 1, "SOON": 0, "LATE": 0}[lot.LotNumber]
+		if lot.OnHand != want || lot.Quarantined != 0 {
+			t.Errorf("lot %s after scrapping: %d on hand, %d quarantined, want %d and 0", lot.LotNumber, lot.OnHand, lot.Quarantined, want)
+		}
+	}
+}
+
+func TestDeleteItemWithLots(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{LotTracked: true}, 0)
+	ctx := context.Background()
+
+	if _, err := m.CreateLot(ctx, item.ID, &models.Lot{LotNumber: "EMPTY"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteItem(ctx, item.ID); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("deleting an item with lots: %v, want ErrItemInUse", err)
+	}
+}
+
+func TestLotTrackingChangesOnlyWithoutStock(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 2)
+	ctx := context.Background()
+
+	update := *item
+	update.LotTracked = true
+	if _, err := m.UpdateItem(ctx, item.ID, &update); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("turning lot tracking on with stock: %v, want ErrItemInUse", err)
+	}
+	if _, err := m.PatchItem(ctx, item.ID, MergePatchContentType, []byte(`{"lot_tracked": true}`)); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("patching lot tracking on with stock: %v, want ErrItemInUse", err)
+	}
+	if _, err := m.PatchItem(ctx, item.ID, MergePatchContentType, []byte(`{"vendor": "Globex"}`)); err != nil {
+		t.Errorf("patching another field with stock: %v", err)
+	}
+
+	if _, err := m.AdjustStock(ctx, item.ID, -2, "count", "test"); err != nil {
+		t.Fatal(err)
+	}
+	patched, err := m.PatchItem(ctx, item.ID, MergePatchContentType, []byte(`{"lot_tracked": true}`))
+	if err != nil || !patched.LotTracked {
+		t.Errorf("patching lot tracking on without stock: %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,86 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"testing"
+)
+
+func newTestManager(t *testing.T) *InventoryManager {
+	t.Helper()
+	return NewInventoryManager(service.NewMemoryStore())
+}
+
+// createTestItem creates an item with onHand units in stock.
+func createTestItem(t *testing.T, m *InventoryManager, item *models.Inventory, onHand int) *models.Inventory {
+	t.Helper()
+	ctx := context.Background()
+	if item == nil {
+		item = &models.Inventory{}
+	}
+	if item.Name == "" {
+		item.Name = "Widget"
+	}
+	item.Price, item.Currency, item.Vendor = 100, "USD", "Acme"
+
+	created, err := m.CreateItem(ctx, item)
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	if onHand > 0 {
+		if created, err = m.AdjustStock(ctx, created.ID, onHand, "opening", "test"); err != nil {
+			t.Fatalf("AdjustStock: %v", err)
+		}
+	}
+	return created
+}
+
+func TestDeleteItemInUse(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	item := createTestItem(t, m, nil, 2)
+	if err := m.DeleteItem(ctx, item.ID); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("deleting an item with stock: %v, want ErrItemInUse", err)
+	}
+	if _, err := m.AdjustStock(ctx, item.ID, -2, "count", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteItem(ctx, item.ID); err != nil {
+		t.Errorf("deleting an item without stock: %v", err)
+	}
+}
+
+func TestDeleteItemOnOpenOrder(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+	item := createTestItem(t, m, nil, 0)
+
+	order, err := m.CreatePurchaseOrder(ctx, &models.PurchaseOrder{Vendor: "Acme", Lines: []models.PurchaseOrderLine{{ItemID: item.ID, Quantity: 5}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteItem(ctx, item.ID); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("deleting an item on an open purchase order: %v, want ErrItemInUse", err)
+	}
+	if _, err := m.CancelPurchaseOrder(ctx, order.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	salesOrder, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{{ItemID: item.ID, Quantity: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DeleteItem(ctx, item.ID); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("deleting an item on an open sales order: %v, want ErrItemInUse", err)
+	}
+	if _, err := m.CancelSalesOrder(ctx, salesOrder.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.DeleteItem(ctx, item.ID); err != nil {
+		t.Errorf("deleting an item on no open order: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package managers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"main/models"
+	"main/utils"
+	"math"
+	"reflect"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var ErrUnsupportedPatch = errors.New("unsupported patch content type")
+
+// PatchItem applies a JSON Merge Patch (RFC 7396) or, for
+// JSONPatchContentType, a JSON Patch (RFC 6902) to the item's JSON form and
+// stores only the fields the patch changed.
+func (m *InventoryManager) PatchItem(ctx context.Context, id string, contentType string, body []byte) (*models.Inventory, error) {
+	item, err := m.Store.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := inventoryDocument(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched map[string]interface{}
+	switch contentType {
+	case MergePatchContentType:
+		patched, err = utils.ApplyMergePatch(doc, body)
+	case JSONPatchContentType:
+		patched, err = utils.ApplyJSONPatch(doc, body)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPatch, contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := inventoryPatchFromDocuments(doc, patched)
+	if err != nil {
+		return nil, err
+	}
+	if err := normalizePatchIdentifiers(&patch); err != nil {
+		return nil, err
+	}
+	if patch.CategoryID != nil && *patch.CategoryID != "" {
+		if err := m.checkItemCategory(ctx, patch.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if patch.IsEmpty() {
+		return item, nil
+	}
+
+	var patchedItem *models.Inventory
+	err = m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		current, err := m.Store.GetItemByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkTrackingChange(current, patch.LotTracked, patch.Serialized); err != nil {
+			return err
+		}
+		patchedItem, err = m.Store.PatchItem(ctx, id, patch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patchedItem, nil
+}
+
+// inventoryDocument returns the JSON object form of item that patches are
+// applied to.
+func inventoryDocument(item *models.Inventory) (map[string]interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	// Patches add barcodes to an array, even when the item has none.
+	if doc["barcodes"] == nil {
+		doc["barcodes"] = []interface{}{}
+	}
+	return doc, nil
+}
+
+// inventoryPatchFromDocuments compares the item document before and after a
+// patch and returns the changed fields, rejecting removed, unknown and
+// read-only fields and values of the wrong type.
+func inventoryPatchFromDocuments(before, after map[string]interface{}) (models.InventoryPatch, error) {
+	var patch models.InventoryPatch
+
+	for field := range before {
+		if _, ok := after[field]; ok {
+			continue
+		}
+		// A merge patch sets category_id to null by removing it.
+		if field == "category_id" {
+			if before[field] != nil {
+				patch.CategoryID, _ = patchOptionalString(field, nil)
+			}
+			continue
+		}
+		return patch, fmt.Errorf("%w: field %q cannot be removed", utils.ErrInvalidPatch, field)
+	}
+
+	for field, value := range after {
+		if previous, ok := before[field]; ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+
+		var err error
+		switch field {
+		case "product_name":
+			patch.Name, err = patchString(field, value)
+		case "price":
+			patch.Price, err = patchInt(field, value)
+		case "currency":
+			patch.Currency, err = patchString(field, value)
+		case "discount":
+			patch.Discount, err = patchInt(field, value)
+		case "vendor":
+			patch.Vendor, err = patchString(field, value)
+		case "reorder_point":
+			patch.ReorderPoint, err = patchInt(field, value)
+		case "reorder_quantity":
+			patch.ReorderQuantity, err = patchInt(field, value)
+		case "lot_tracked":
+			patch.LotTracked, err = patchBool(field, value)
+		case "serialized":
+			patch.Serialized, err = patchBool(field, value)
+		case "sku":
+			patch.SKU, err = patchString(field, value)
+		case "barcodes":
+			patch.Barcodes, err = patchStrings(field, value)
+		case "category_id":
+			patch.CategoryID, err = patchOptionalString(field, value)
+		default:
+			if _, ok := before[field]; ok {
+				err = fmt.Errorf("%w: field %q is read-only", utils.ErrInvalidPatch, field)
+			} else {
+				err = fmt.Errorf("%w: unknown field %q", utils.ErrInvalidPatch, field)
+			}
+		}
+		if err != nil {
+			return patch, err
+		}
+	}
+
+	return patch, nil
+}
+
+func patchString(field string, value interface{}) (*string, error) {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return nil, fmt.Errorf("%w: %s must be a non-empty string", utils.ErrInvalidPatch, field)
+	}
+	return &s, nil
+}
+
+// patchOptionalString reads a string that can be null, returned as an
+// empty string.
+func patchOptionalString(field string, value interface{}) (*string, error) {
+	if value == nil {
+		empty := ""
+		return &empty, nil
+	}
+	return patchString(field, value)
+}
+
+func patchInt(field string, value interface{}) (*int, error) {
+	f, ok := value.(float64)
+	if !ok || f != math.Trunc(f) || f < 0 || f > math.MaxInt32 {
+		return nil, fmt.Errorf("%w: %s must be a non-negative integer", utils.ErrInvalidPatch, field)
+	}
+	n := int(f)
+	return &n, nil
+}
+
+func patchStrings(field string, value interface{}) (*[]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s must be an array of strings", utils.ErrInvalidPatch, field)
+	}
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must be an array of strings", utils.ErrInvalidPatch, field)
+		}
+		strs = append(strs, s)
+	}
+	return &strs, nil
+}
+
+// normalizePatchIdentifiers validates the SKU and barcodes a patch sets, as
+// normalizeIdentifiers does for whole items.
+func normalizePatchIdentifiers(patch *models.InventoryPatch) error {
+	if patch.SKU != nil {
+		sku, err := normalizeSKU(*patch.SKU)
+		if err != nil {
+			return err
+		}
+		if sku == "" {
+			return fmt.Errorf("%w: sku must not be empty", ErrInvalidSKU)
+		}
+		patch.SKU = &sku
+	}
+	if patch.Barcodes != nil {
+		barcodes, err := normalizeBarcodes(*patch.Barcodes)
+		if err != nil {
+			return err
+		}
+		patch.Barcodes = &barcodes
+	}
+	return nil
+}
+
+func patchBool(field string, value interface{}) (*bool, error) {
+	b, ok := value.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s must be a boolean", utils.ErrInvalidPatch, field)
+	}
+	return &b, nil
+}
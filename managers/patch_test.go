@@ -0,0 +1,69 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"main/utils"
+	"testing"
+)
+
+func TestPatchItem(t *testing.T) {
+	m := NewInventoryManager(service.NewMemoryStore())
+	ctx := context.Background()
+
+	tests := []struct {
+		name, contentType, patch string
+		err                      error
+		want                     models.Inventory
+	}{
+		{name: "merge", contentType: MergePatchContentType, patch: `{"price":150,"vendor":"Globex"}`,
+			want: models.Inventory{Name: "Widget", Price: 150, Currency: "USD", Vendor: "Globex"}},
+		{name: "merge nothing", contentType: MergePatchContentType, patch: `{"price":100}`,
+			want: models.Inventory{Name: "Widget", Price: 100, Currency: "USD", Vendor: "Acme"}},
+		{name: "merge null", contentType: MergePatchContentType, patch: `{"vendor":null}`, err: utils.ErrInvalidPatch},
+		{name: "merge read-only", contentType: MergePatchContentType, patch: `{"id":"other"}`, err: utils.ErrInvalidPatch},
+		{name: "merge unknown", contentType: MergePatchContentType, patch: `{"colour":"red"}`, err: utils.ErrInvalidPatch},
+		{name: "merge negative", contentType: MergePatchContentType, patch: `{"price":-1}`, err: utils.ErrInvalidPatch},
+		{name: "merge fraction", contentType: MergePatchContentType, patch: `{"discount":1.5}`, err: utils.ErrInvalidPatch},
+		{name: "merge empty name", contentType: MergePatchContentType, patch: `{"product_name":""}`, err: utils.ErrInvalidPatch},
+		{name: "json patch", contentType: JSONPatchContentType,
+			patch: `[{"op":"test","path":"/price","value":100},{"op":"replace","path":"/price","value":120},{"op":"copy","from":"/vendor","path":"/product_name"}]`,
+			want:  models.Inventory{Name: "Acme", Price: 120, Currency: "USD", Vendor: "Acme"}},
+		{name: "json patch test failure", contentType: JSONPatchContentType,
+			patch: `[{"op":"test","path":"/price","value":99},{"op":"replace","path":"/price","value":120}]`, err: utils.ErrPatchTestFailed},
+		{name: "json patch read-only", contentType: JSONPatchContentType, patch: `[{"op":"replace","path":"/id","value":"other"}]`, err: utils.ErrInvalidPatch},
+		{name: "json patch remove", contentType: JSONPatchContentType, patch: `[{"op":"remove","path":"/currency"}]`, err: utils.ErrInvalidPatch},
+		{name: "unsupported", contentType: "application/json", patch: `{"price":1}`, err: ErrUnsupportedPatch},
+	}
+	for _, tt := range tests {
+		item, err := m.CreateItem(ctx, &models.Inventory{Name: "Widget", Price: 100, Currency: "USD", Vendor: "Acme"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		patched, err := m.PatchItem(ctx, item.ID, tt.contentType, []byte(tt.patch))
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s: %v, want %v", tt.name, err, tt.err)
+			}
+			patched, err = m.GetItemByID(ctx, item.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want = *item
+		} else if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if patched.ID != item.ID || patched.Name != tt.want.Name || patched.Price != tt.want.Price ||
+			patched.Currency != tt.want.Currency || patched.Vendor != tt.want.Vendor {
+			t.Errorf("%s: item is %+v, want %+v", tt.name, *patched, tt.want)
+		}
+	}
+
+	if _, err := m.PatchItem(ctx, "missing", MergePatchContentType, []byte(`{"price":1}`)); !errors.Is(err, service.ErrItemNotFound) {
+		t.Errorf("patching a missing item: %v, want ErrItemNotFound", err)
+	}
+}
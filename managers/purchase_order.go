@@ -0,0 +1,243 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var (
+	ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+	ErrPurchaseOrderState   = errors.New("purchase order is not in the right state")
+)
+
+// PurchaseOrderReceipt receives Quantity units against one line of a
+// purchase order. The line is named by LineID, or by ItemID when the order
+// has a single line for that item.
+type PurchaseOrderReceipt struct {
+	LineID     string
+	ItemID     string
+	Quantity   int
+	LocationID *string
+	// LotID names the lot of a lot-tracked item the units go into.
+	LotID *string
+	// SerialNumbers lists the units received of a serialized item.
+	SerialNumbers []string
+}
+
+// CreatePurchaseOrder opens a purchase order. Every line must name an
+// existing item.
+func (m *InventoryManager) CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	if order.Vendor == "" {
+		return nil, fmt.Errorf("%w: vendor is required", ErrInvalidPurchaseOrder)
+	}
+	if len(order.Lines) == 0 {
+		return nil, fmt.Errorf("%w: at least one line is required", ErrInvalidPurchaseOrder)
+	}
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity must be positive", ErrInvalidPurchaseOrder, i+1)
+		}
+		if line.UnitCost < 0 {
+			return nil, fmt.Errorf("%w: line %d: unit cost must not be negative", ErrInvalidPurchaseOrder, i+1)
+		}
+		_, err := m.Store.GetItemByID(ctx, line.ItemID)
+		if errors.Is(err, service.ErrItemNotFound) {
+			return nil, fmt.Errorf("%w: line %d: item %q not found", ErrInvalidPurchaseOrder, i+1, line.ItemID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		line.Received = 0
+	}
+
+	order.Status = models.PurchaseOrderOpen
+	order.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	order.ClosedAt = nil
+	order.Version = 0
+
+	return m.Store.CreatePurchaseOrder(ctx, order)
+}
+
+// GetPurchaseOrders returns a page of purchase orders, newest first, and the
+// number of orders matching the query.
+func (m *InventoryManager) GetPurchaseOrders(ctx context.Context, query models.PurchaseOrderQuery) ([]*models.PurchaseOrder, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.PurchaseOrderOpen, models.PurchaseOrderPartiallyReceived, models.PurchaseOrderClosed, models.PurchaseOrderCancelled:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown purchase order status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetPurchaseOrders(ctx, query)
+}
+
+func (m *InventoryManager) GetPurchaseOrderByID(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	return m.Store.GetPurchaseOrderByID(ctx, id)
+}
+
+// ReceivePurchaseOrder books receipts against an open purchase order. Each
+// receipt adds stock through the ledger; a line may receive more or less
+// than was ordered. The order closes once every line is fully received.
+// All receipts and the order update are written in one transaction.
+func (m *InventoryManager) ReceivePurchaseOrder(ctx context.Context, id string, receipts []PurchaseOrderReceipt, user string) (*models.PurchaseOrder, error) {
+	if len(receipts) == 0 {
+		return nil, fmt.Errorf("%w: nothing to receive", ErrInvalidPurchaseOrder)
+	}
+
+	var order *models.PurchaseOrder
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = m.Store.GetPurchaseOrderByID(ctx, id); err != nil {
+			return err
+		}
+		if order.Status != models.PurchaseOrderOpen && order.Status != models.PurchaseOrderPartiallyReceived {
+			return fmt.Errorf("%w: cannot receive against a %s order", ErrPurchaseOrderState, order.Status)
+		}
+
+		for i, receipt := range receipts {
+			if receipt.Quantity <= 0 {
+				return fmt.Errorf("%w: receipt %d: quantity must be positive", ErrInvalidPurchaseOrder, i+1)
+			}
+			line, err := purchaseOrderLine(order, receipt)
+			if err != nil {
+				return fmt.Errorf("%w: receipt %d: %v", ErrInvalidPurchaseOrder, i+1, err)
+			}
+
+			_, _, err = m.RecordMovement(ctx, line.ItemID, &models.StockMovement{
+				Delta:         receipt.Quantity,
+				Reason:        models.MovementReceipt,
+				Reference:     purchaseOrderReference(order),
+				User:          user,
+				LocationID:    receipt.LocationID,
+				Lots:          lotEntry(receipt.LotID, receipt.Quantity),
+				SerialNumbers: receipt.SerialNumbers,
+			})
+			if err != nil {
+				return err
+			}
+			line.Received += receipt.Quantity
+		}
+
+		order.Status = models.PurchaseOrderPartiallyReceived
+		if order.FullyReceived() {
+			closedAt := time.Now().UTC().Truncate(time.Millisecond)
+			order.Status, order.ClosedAt = models.PurchaseOrderClosed, &closedAt
+		}
+		return m.Store.UpdatePurchaseOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ClosePurchaseOrder closes an order that will not receive anything more,
+// such as one the vendor has under-delivered.
+func (m *InventoryManager) ClosePurchaseOrder(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	order, err := m.Store.GetPurchaseOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderOpen && order.Status != models.PurchaseOrderPartiallyReceived {
+		return nil, fmt.Errorf("%w: cannot close a %s order", ErrPurchaseOrderState, order.Status)
+	}
+
+	closedAt := time.Now().UTC().Truncate(time.Millisecond)
+	order.Status, order.ClosedAt = models.PurchaseOrderClosed, &closedAt
+	if err := m.Store.UpdatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CancelPurchaseOrder abandons an order nothing has been received against.
+func (m *InventoryManager) CancelPurchaseOrder(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	order, err := m.Store.GetPurchaseOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderOpen {
+		return nil, fmt.Errorf("%w: only an open order without receipts can be cancelled, this one is %s", ErrPurchaseOrderState, order.Status)
+	}
+
+	closedAt := time.Now().UTC().Truncate(time.Millisecond)
+	order.Status, order.ClosedAt = models.PurchaseOrderCancelled, &closedAt
+	if err := m.Store.UpdatePurchaseOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// purchaseOrderLine finds the line of order a receipt is for.
+func purchaseOrderLine(order *models.PurchaseOrder, receipt PurchaseOrderReceipt) (*models.PurchaseOrderLine, error) {
+	var found *models.PurchaseOrderLine
+	for i := range order.Lines {
+		line := &order.Lines[i]
+		switch {
+		case receipt.LineID != "":
+			if line.ID == receipt.LineID {
+				return line, nil
+			}
+		case line.ItemID == receipt.ItemID:
+			if found != nil {
+				return nil, fmt.Errorf("item %q is on several lines, name the line", receipt.ItemID)
+			}
+			found = line
+		}
+	}
+
+	if found == nil {
+		if receipt.LineID != "" {
+			return nil, fmt.Errorf("line %q not found", receipt.LineID)
+		}
+		return nil, fmt.Errorf("item %q is not on this order", receipt.ItemID)
+	}
+	return found, nil
+}
+
+// openPurchaseOrderFor returns a purchase order that still expects stock of
+// the item, or nil if there is none.
+func (m *InventoryManager) openPurchaseOrderFor(ctx context.Context, itemID string) (*models.PurchaseOrder, error) {
+	for _, status := range []string{models.PurchaseOrderOpen, models.PurchaseOrderPartiallyReceived} {
+		query := models.PurchaseOrderQuery{Status: status, Limit: MaxPageSize}
+		for {
+			orders, totalCount, err := m.Store.GetPurchaseOrders(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			for _, order := range orders {
+				for _, line := range order.Lines {
+					if line.ItemID == itemID {
+						return order, nil
+					}
+				}
+			}
+
+			query.Offset += len(orders)
+			if len(orders) == 0 || int64(query.Offset) >= totalCount {
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+// purchaseOrderReference is the ledger reference of a purchase order's
+// receipts.
+func purchaseOrderReference(order *models.PurchaseOrder) string {
+	return "purchase order " + order.ID
+}
@@ -0,0 +1,202 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var (
+	ErrInvalidReservation = errors.New("invalid reservation")
+	ErrReservationState   = errors.New("reservation is not in the right state")
+)
+
+// CreateReservation holds quantity units of the item's available stock for
+// ttl, or for ReservationTTL when ttl is zero. Taking the stock and
+// recording the reservation happen in one transaction, and the stock check
+// is a single conditional update, so two reservations can never both take
+// the last unit.
+func (m *InventoryManager) CreateReservation(ctx context.Context, itemID string, quantity int, ttl time.Duration, reference string) (*models.Reservation, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidReservation)
+	}
+	if ttl < 0 || ttl > m.MaxReservationTTL {
+		return nil, fmt.Errorf("%w: ttl must be between 0 and %s", ErrInvalidReservation, m.MaxReservationTTL)
+	}
+	if ttl == 0 {
+		ttl = m.ReservationTTL
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reservation := &models.Reservation{
+		ItemID:    itemID,
+		Quantity:  quantity,
+		Status:    models.ReservationActive,
+		Reference: reference,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := m.Store.AdjustStock(ctx, itemID, 0, quantity); err != nil {
+			return err
+		}
+		var err error
+		reservation, err = m.Store.CreateReservation(ctx, reservation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// GetReservations returns a page of reservations, oldest first, and the
+// number of reservations matching the query.
+func (m *InventoryManager) GetReservations(ctx context.Context, query models.ReservationQuery) ([]*models.Reservation, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.ReservationActive, models.ReservationConfirmed, models.ReservationReleased, models.ReservationExpired:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown reservation status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetReservations(ctx, query)
+}
+
+func (m *InventoryManager) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	return m.Store.GetReservationByID(ctx, id)
+}
+
+// ConfirmReservation stops an active reservation from expiring. Its stock
+// stays reserved until the reservation is released.
+func (m *InventoryManager) ConfirmReservation(ctx context.Context, id string) (*models.Reservation, error) {
+	return m.changeReservation(ctx, id, models.ReservationConfirmed, false)
+}
+
+// ReleaseReservation ends an active or confirmed reservation and returns its
+// stock to the available quantity.
+func (m *InventoryManager) ReleaseReservation(ctx context.Context, id string) (*models.Reservation, error) {
+	return m.changeReservation(ctx, id, models.ReservationReleased, true)
+}
+
+// changeReservation moves a reservation to status to, optionally giving its
+// stock back, in one transaction. Expired reservations cannot be changed,
+// even before the sweeper has got to them.
+func (m *InventoryManager) changeReservation(ctx context.Context, id, to string, release bool) (*models.Reservation, error) {
+	var reservation *models.Reservation
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if reservation, err = m.Store.GetReservationByID(ctx, id); err != nil {
+			return err
+		}
+
+		switch {
+		case reservation.Status == models.ReservationActive && !time.Now().Before(reservation.ExpiresAt):
+			return fmt.Errorf("%w: it has expired", ErrReservationState)
+		case reservation.Status == models.ReservationActive,
+			reservation.Status == models.ReservationConfirmed && to == models.ReservationReleased:
+		default:
+			return fmt.Errorf("%w: cannot move from %s to %s", ErrReservationState, reservation.Status, to)
+		}
+
+		if err := m.Store.UpdateReservationStatus(ctx, id, reservation.Status, to); err != nil {
+			return err
+		}
+		if release {
+			if _, err := m.Store.AdjustStock(ctx, reservation.ItemID, 0, -reservation.Quantity); err != nil {
+				return err
+			}
+		}
+
+		reservation.Status = to
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ExpireReservations releases the stock of every active reservation that
+// expired at or before now and returns how many it expired. Each one is
+// expired in its own transaction, so one failure does not hold up the rest.
+func (m *InventoryManager) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	query := models.ReservationQuery{Status: models.ReservationActive, ExpiresBefore: &now, Limit: MaxPageSize}
+
+	expired := 0
+	for {
+		reservations, _, err := m.Store.GetReservations(ctx, query)
+		if err != nil {
+			return expired, err
+		}
+
+		failed := 0
+		for _, reservation := range reservations {
+			if err := m.expireReservation(ctx, reservation); err != nil {
+				log.Printf("Error expiring reservation %s: %v", reservation.ID, err)
+				failed++
+				continue
+			}
+			expired++
+		}
+
+		// Expired reservations drop out of the query, so only the ones that
+		// failed need skipping.
+		query.Offset += failed
+		if len(reservations) < query.Limit {
+			return expired, nil
+		}
+	}
+}
+
+func (m *InventoryManager) expireReservation(ctx context.Context, reservation *models.Reservation) error {
+	return m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		err := m.Store.UpdateReservationStatus(ctx, reservation.ID, models.ReservationActive, models.ReservationExpired)
+		if err != nil {
+			return err
+		}
+		// The stock of a deleted item went with it.
+		_, err = m.Store.AdjustStock(ctx, reservation.ItemID, 0, -reservation.Quantity)
+		if errors.Is(err, service.ErrItemNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+// SweepReservations calls ExpireReservations every interval until ctx is
+// done.
+func (m *InventoryManager) SweepReservations(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := m.ExpireReservations(ctx, time.Now().UTC())
+			if err != nil {
+				log.Printf("Error sweeping reservations: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Expired %d reservations", expired)
+			}
+		}
+	}
+}
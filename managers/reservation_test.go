@@ -0,0 +1,70 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	service "main/services"
+	"sync"
+	"testing"
+)
+
+func TestCreateReservationLastUnit(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 1)
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = m.CreateReservation(context.Background(), item.ID, 1, 0, "")
+		}(i)
+	}
+	wg.Wait()
+
+	reserved := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			reserved++
+		case !errors.Is(err, service.ErrInsufficientStock):
+			t.Errorf("CreateReservation: %v", err)
+		}
+	}
+	if reserved != 1 {
+		t.Fatalf("%d reservations took the last unit, want 1", reserved)
+	}
+
+	item, err := m.GetItemByID(context.Background(), item.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Reserved != 1 {
+		t.Errorf("reserved = %d, want 1", item.Reserved)
+	}
+}
+
+func TestReleaseReservationReturnsStock(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 2)
+	ctx := context.Background()
+
+	reservation, err := m.CreateReservation(ctx, item.ID, 2, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.CreateReservation(ctx, item.ID, 1, 0, ""); !errors.Is(err, service.ErrInsufficientStock) {
+		t.Fatalf("CreateReservation over available stock: %v, want ErrInsufficientStock", err)
+	}
+	if _, err := m.ReleaseReservation(ctx, reservation.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ReleaseReservation(ctx, reservation.ID); !errors.Is(err, ErrReservationState) {
+		t.Errorf("releasing twice: %v, want ErrReservationState", err)
+	}
+	if _, err := m.CreateReservation(ctx, item.ID, 2, 0, ""); err != nil {
+		t.Errorf("CreateReservation after release: %v", err)
+	}
+}
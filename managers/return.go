@@ -0,0 +1,522 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var (
+	ErrInvalidReturn = errors.New("invalid return")
+	ErrReturnState   = errors.New("return is not in the right state")
+)
+
+// ReturnReceipt receives Quantity units of a return line that arrived in
+// Condition. Restocked units go to LocationID, or to the unlocated stock
+// when it is nil; quarantined units must go to a quarantine location.
+type ReturnReceipt struct {
+	LineID     string
+	Condition  string
+	Quantity   int
+	LocationID *string
+	// LotID names the lot of a lot-tracked item the units go back into.
+	LotID *string
+	// SerialNumbers lists the units received of a serialized item.
+	SerialNumbers []string
+}
+
+// ReturnRelease takes Quantity quarantined units of a return line out of
+// the quarantine location FromLocationID, either restocking them at
+// LocationID or scrapping them. Units of a lot-tracked item are taken from
+// the lot LotID they were received into, units of a serialized item are
+// named in SerialNumbers.
+type ReturnRelease struct {
+	LineID         string
+	Disposition    string
+	Quantity       int
+	FromLocationID string
+	LocationID     *string
+	LotID          *string
+	SerialNumbers  []string
+}
+
+// CreateReturn authorizes the return of stock a sales order has shipped. No
+// item can be returned more often than the order shipped it, counting the
+// order's earlier returns. Authorizing a return bumps the order's version,
+// so of two concurrent returns for the same order only one passes the check.
+func (m *InventoryManager) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	if len(ret.Lines) == 0 {
+		return nil, fmt.Errorf("%w: at least one line is required", ErrInvalidReturn)
+	}
+	for i, line := range ret.Lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity must be positive", ErrInvalidReturn, i+1)
+		}
+	}
+
+	var created *models.Return
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		order, err := m.Store.GetSalesOrderByID(ctx, ret.SalesOrderID)
+		if errors.Is(err, service.ErrSalesOrderNotFound) {
+			return fmt.Errorf("%w: sales order %q not found", ErrInvalidReturn, ret.SalesOrderID)
+		}
+		if err != nil {
+			return err
+		}
+		if order.Status != models.SalesOrderShipped && order.Status != models.SalesOrderDelivered {
+			return fmt.Errorf("%w: sales order %s has not shipped", ErrInvalidReturn, order.ID)
+		}
+
+		returnable := make(map[string]int)
+		for _, line := range order.Lines {
+			returnable[line.ItemID] += line.Quantity
+		}
+		returned, err := m.returnedQuantities(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		for i, line := range ret.Lines {
+			if _, ok := returnable[line.ItemID]; !ok {
+				return fmt.Errorf("%w: line %d: item %q was not shipped by the sales order", ErrInvalidReturn, i+1, line.ItemID)
+			}
+			returned[line.ItemID] += line.Quantity
+			if returned[line.ItemID] > returnable[line.ItemID] {
+				return fmt.Errorf("%w: line %d: only %d units of item %s were shipped", ErrInvalidReturn, i+1, returnable[line.ItemID], line.ItemID)
+			}
+		}
+		if err := m.Store.UpdateSalesOrder(ctx, order); err != nil {
+			return err
+		}
+
+		for i := range ret.Lines {
+			ret.Lines[i].Received, ret.Lines[i].Restocked, ret.Lines[i].Scrapped = 0, 0, 0
+		}
+		ret.Status = models.ReturnAuthorized
+		ret.Entries = nil
+		ret.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+		ret.ReceivedAt, ret.ClosedAt = nil, nil
+		ret.Version = 0
+
+		created, err = m.Store.CreateReturn(ctx, ret)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// returnedQuantities adds up, per item, the quantities the sales order's
+// returns that are not cancelled authorize.
+func (m *InventoryManager) returnedQuantities(ctx context.Context, salesOrderID string) (map[string]int, error) {
+	query := models.ReturnQuery{SalesOrderID: salesOrderID, Limit: MaxPageSize}
+
+	returned := make(map[string]int)
+	for {
+		returns, totalCount, err := m.Store.GetReturns(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, ret := range returns {
+			if ret.Status == models.ReturnCancelled {
+				continue
+			}
+			for _, line := range ret.Lines {
+				returned[line.ItemID] += line.Quantity
+			}
+		}
+
+		query.Offset += len(returns)
+		if len(returns) == 0 || int64(query.Offset) >= totalCount {
+			return returned, nil
+		}
+	}
+}
+
+// GetReturns returns a page of returns, newest first, and the number of
+// returns matching the query.
+func (m *InventoryManager) GetReturns(ctx context.Context, query models.ReturnQuery) ([]*models.Return, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.ReturnAuthorized, models.ReturnReceived, models.ReturnClosed, models.ReturnCancelled:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown return status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetReturns(ctx, query)
+}
+
+func (m *InventoryManager) GetReturnByID(ctx context.Context, id string) (*models.Return, error) {
+	return m.Store.GetReturnByID(ctx, id)
+}
+
+// ReceiveReturn books the units that came back for an authorized return.
+// Each receipt is recorded in the ledger as a return; units in a condition
+// that needs quarantine are also reserved, so they cannot be sold. Units
+// that did not come back are not expected any more. The return closes at
+// once unless some units are left in quarantine.
+func (m *InventoryManager) ReceiveReturn(ctx context.Context, id string, receipts []ReturnReceipt, user string) (*models.Return, error) {
+	if len(receipts) == 0 {
+		return nil, fmt.Errorf("%w: nothing to receive", ErrInvalidReturn)
+	}
+
+	var ret *models.Return
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if ret, err = m.Store.GetReturnByID(ctx, id); err != nil {
+			return err
+		}
+		if ret.Status != models.ReturnAuthorized {
+			return fmt.Errorf("%w: only an authorized return can be received, this one is %s", ErrReturnState, ret.Status)
+		}
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		for i, receipt := range receipts {
+			line := returnLine(ret, receipt.LineID)
+			if line == nil {
+				return fmt.Errorf("%w: receipt %d: line %q not found", ErrInvalidReturn, i+1, receipt.LineID)
+			}
+			disposition, ok := models.ConditionDispositions[receipt.Condition]
+			if !ok {
+				return fmt.Errorf("%w: receipt %d: unknown condition %q", ErrInvalidReturn, i+1, receipt.Condition)
+			}
+			if receipt.Quantity <= 0 {
+				return fmt.Errorf("%w: receipt %d: quantity must be positive", ErrInvalidReturn, i+1)
+			}
+			if line.Received+receipt.Quantity > line.Quantity {
+				return fmt.Errorf("%w: receipt %d: only %d units of the line are authorized", ErrInvalidReturn, i+1, line.Quantity)
+			}
+			err := m.checkReturnLocation(ctx, fmt.Sprintf("receipt %d", i+1), receipt.LocationID, disposition == models.DispositionQuarantine)
+			if err != nil {
+				return err
+			}
+
+			_, _, err = m.RecordMovement(ctx, line.ItemID, &models.StockMovement{
+				Delta:         receipt.Quantity,
+				Reason:        models.MovementReturn,
+				Reference:     returnReference(ret),
+				User:          user,
+				LocationID:    receipt.LocationID,
+				Lots:          lotEntry(receipt.LotID, receipt.Quantity),
+				SerialNumbers: receipt.SerialNumbers,
+			})
+			if err != nil {
+				return err
+			}
+			if disposition == models.DispositionQuarantine {
+				if _, err := m.Store.AdjustStock(ctx, line.ItemID, 0, receipt.Quantity); err != nil {
+					return err
+				}
+				if receipt.LotID != nil {
+					if _, err := m.Store.HoldLotStock(ctx, *receipt.LotID, receipt.Quantity); err != nil {
+						return err
+					}
+				}
+			} else {
+				err := m.restockSerials(ctx, fmt.Sprintf("receipt %d", i+1), line.ItemID, receipt.Quantity, receipt.SerialNumbers)
+				if err != nil {
+					return err
+				}
+				line.Restocked += receipt.Quantity
+			}
+
+			line.Received += receipt.Quantity
+			ret.Entries = append(ret.Entries, models.ReturnEntry{
+				LineID:      line.ID,
+				ItemID:      line.ItemID,
+				Condition:   receipt.Condition,
+				Disposition: disposition,
+				LocationID:  receipt.LocationID,
+				LotID:       receipt.LotID,
+				Quantity:    receipt.Quantity,
+				CreatedAt:   now,
+			})
+		}
+
+		ret.Status, ret.ReceivedAt = models.ReturnReceived, &now
+		closeSettledReturn(ret, now)
+		return m.Store.UpdateReturn(ctx, ret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// ReleaseReturn settles quarantined units of a received return: restocked
+// units move to sellable stock, scrapped units leave the inventory. Either
+// way the ledger records the movement and the units' reservation is
+// released. The return closes once its quarantine is empty.
+func (m *InventoryManager) ReleaseReturn(ctx context.Context, id string, releases []ReturnRelease, user string) (*models.Return, error) {
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("%w: nothing to release", ErrInvalidReturn)
+	}
+
+	var ret *models.Return
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if ret, err = m.Store.GetReturnByID(ctx, id); err != nil {
+			return err
+		}
+		if ret.Status != models.ReturnReceived {
+			return fmt.Errorf("%w: only a received return has units in quarantine, this one is %s", ErrReturnState, ret.Status)
+		}
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		for i, release := range releases {
+			line := returnLine(ret, release.LineID)
+			if line == nil {
+				return fmt.Errorf("%w: release %d: line %q not found", ErrInvalidReturn, i+1, release.LineID)
+			}
+			if release.Quantity <= 0 {
+				return fmt.Errorf("%w: release %d: quantity must be positive", ErrInvalidReturn, i+1)
+			}
+			quarantined := quarantinedAt(ret, line.ID, release.FromLocationID, release.LotID)
+			if release.Quantity > quarantined {
+				return fmt.Errorf("%w: release %d: only %d units of the line are quarantined there", ErrInvalidReturn, i+1, quarantined)
+			}
+
+			if _, err := m.Store.AdjustStock(ctx, line.ItemID, 0, -release.Quantity); err != nil {
+				return err
+			}
+			if release.LotID != nil {
+				if _, err := m.Store.HoldLotStock(ctx, *release.LotID, -release.Quantity); err != nil {
+					return err
+				}
+			}
+			movement := &models.StockMovement{
+				Delta:      -release.Quantity,
+				Reference:  returnReference(ret),
+				User:       user,
+				LocationID: &release.FromLocationID,
+			}
+			switch release.Disposition {
+			case models.DispositionRestock:
+				if err := m.checkReturnLocation(ctx, fmt.Sprintf("release %d", i+1), release.LocationID, false); err != nil {
+					return err
+				}
+				err = m.restockSerials(ctx, fmt.Sprintf("release %d", i+1), line.ItemID, release.Quantity, release.SerialNumbers)
+				if err != nil {
+					return err
+				}
+				movement.Reason = models.MovementTransfer
+				if _, _, err := m.RecordMovement(ctx, line.ItemID, movement); err != nil {
+					return err
+				}
+				_, _, err = m.RecordMovement(ctx, line.ItemID, &models.StockMovement{
+					Delta:      release.Quantity,
+					Reason:     models.MovementTransfer,
+					Reference:  returnReference(ret),
+					User:       user,
+					LocationID: release.LocationID,
+				})
+				line.Restocked += release.Quantity
+			case models.DispositionScrap:
+				if release.LocationID != nil {
+					return fmt.Errorf("%w: release %d: scrapped units go nowhere", ErrInvalidReturn, i+1)
+				}
+				// The units come out of quarantine first, so the
+				// adjustment below scraps them like any unit in stock.
+				err = m.restockSerials(ctx, fmt.Sprintf("release %d", i+1), line.ItemID, release.Quantity, release.SerialNumbers)
+				if err != nil {
+					return err
+				}
+				movement.Reason = models.MovementAdjustment
+				movement.Lots = lotEntry(release.LotID, -release.Quantity)
+				movement.SerialNumbers = release.SerialNumbers
+				_, _, err = m.RecordMovement(ctx, line.ItemID, movement)
+				line.Scrapped += release.Quantity
+			default:
+				return fmt.Errorf("%w: release %d: unknown disposition %q", ErrInvalidReturn, i+1, release.Disposition)
+			}
+			if err != nil {
+				return err
+			}
+
+			fromLocationID := release.FromLocationID
+			ret.Entries = append(ret.Entries, models.ReturnEntry{
+				LineID:         line.ID,
+				ItemID:         line.ItemID,
+				Disposition:    release.Disposition,
+				FromLocationID: &fromLocationID,
+				LocationID:     release.LocationID,
+				LotID:          release.LotID,
+				Quantity:       release.Quantity,
+				CreatedAt:      now,
+			})
+		}
+
+		closeSettledReturn(ret, now)
+		return m.Store.UpdateReturn(ctx, ret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// CancelReturn withdraws an authorization before anything was received.
+func (m *InventoryManager) CancelReturn(ctx context.Context, id string) (*models.Return, error) {
+	ret, err := m.Store.GetReturnByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != models.ReturnAuthorized {
+		return nil, fmt.Errorf("%w: only an authorized return can be cancelled, this one is %s", ErrReturnState, ret.Status)
+	}
+
+	closedAt := time.Now().UTC().Truncate(time.Millisecond)
+	ret.Status, ret.ClosedAt = models.ReturnCancelled, &closedAt
+	if err := m.Store.UpdateReturn(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// checkReturnLocation checks that the returned units of step can go to
+// locationID: a quarantine location when quarantine is set, and otherwise no
+// location or one that is not a quarantine location.
+func (m *InventoryManager) checkReturnLocation(ctx context.Context, step string, locationID *string, quarantine bool) error {
+	if locationID == nil {
+		if quarantine {
+			return fmt.Errorf("%w: %s: quarantined units need a quarantine location", ErrInvalidReturn, step)
+		}
+		return nil
+	}
+
+	location, err := m.Store.GetLocationByID(ctx, *locationID)
+	if errors.Is(err, service.ErrLocationNotFound) {
+		return fmt.Errorf("%w: %s: location %q not found", ErrInvalidReturn, step, *locationID)
+	}
+	if err != nil {
+		return err
+	}
+	if location.Quarantine != quarantine {
+		if quarantine {
+			return fmt.Errorf("%w: %s: location %s is not a quarantine location", ErrInvalidReturn, step, location.ID)
+		}
+		return fmt.Errorf("%w: %s: location %s is a quarantine location", ErrInvalidReturn, step, location.ID)
+	}
+	return nil
+}
+
+// restockSerials puts the returned units of a serialized item that are
+// restocked back in stock. Units held in quarantine keep the returned status
+// until they are released.
+func (m *InventoryManager) restockSerials(ctx context.Context, step, itemID string, quantity int, serialNumbers []string) error {
+	item, err := m.Store.GetItemByID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if !item.Serialized {
+		if len(serialNumbers) > 0 {
+			return fmt.Errorf("%w: %s: the item is not serialized", ErrInvalidReturn, step)
+		}
+		return nil
+	}
+	if len(serialNumbers) != quantity {
+		return fmt.Errorf("%w: %s: the item is serialized, name a serial number for each of the %d units", ErrInvalidReturn, step, quantity)
+	}
+	if err := checkSerialNumbers(serialNumbers); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidReturn, step, err)
+	}
+
+	serials, err := m.returnedSerials(ctx, step, itemID, serialNumbers)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, serial := range serials {
+		if err := m.Store.UpdateSerialStatus(ctx, serial.ID, serial.Status, models.SerialInStock, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returnedSerials looks up units of the item that came back from a customer
+// and have not been restocked yet.
+func (m *InventoryManager) returnedSerials(ctx context.Context, step, itemID string, serialNumbers []string) ([]*models.Serial, error) {
+	if len(serialNumbers) == 0 {
+		return nil, nil
+	}
+
+	serials, _, err := m.Store.GetSerials(ctx, models.SerialQuery{ItemID: itemID, SerialNumbers: serialNumbers, Status: models.SerialReturned})
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(serials))
+	for _, serial := range serials {
+		found[serial.SerialNumber] = true
+	}
+	for _, serialNumber := range serialNumbers {
+		if !found[serialNumber] {
+			return nil, fmt.Errorf("%w: %s: serial number %s is not a returned unit of the item", ErrInvalidReturn, step, serialNumber)
+		}
+	}
+	return serials, nil
+}
+
+func returnLine(ret *models.Return, lineID string) *models.ReturnLine {
+	for i := range ret.Lines {
+		if ret.Lines[i].ID == lineID {
+			return &ret.Lines[i]
+		}
+	}
+	return nil
+}
+
+// quarantinedAt returns how many units of a return line are in quarantine
+// at the location in the lot lotID, going by the return's entries.
+func quarantinedAt(ret *models.Return, lineID, locationID string, lotID *string) int {
+	quarantined := 0
+	for _, entry := range ret.Entries {
+		if entry.LineID != lineID || !sameLot(entry.LotID, lotID) {
+			continue
+		}
+		switch {
+		case entry.Disposition == models.DispositionQuarantine && entry.LocationID != nil && *entry.LocationID == locationID:
+			quarantined += entry.Quantity
+		case entry.FromLocationID != nil && *entry.FromLocationID == locationID:
+			quarantined -= entry.Quantity
+		}
+	}
+	return quarantined
+}
+
+func sameLot(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// closeSettledReturn closes a received return none of whose units is left
+// in quarantine.
+func closeSettledReturn(ret *models.Return, now time.Time) {
+	for _, line := range ret.Lines {
+		if line.Quarantined() > 0 {
+			return
+		}
+	}
+	ret.Status, ret.ClosedAt = models.ReturnClosed, &now
+}
+
+// returnReference is the ledger reference of a return's movements.
+func returnReference(ret *models.Return) string {
+	return "return " + ret.ID
+}
@@ -0,0 +1,154 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"sync"
+	"testing"
+)
+
+func createQuarantine(t *testing.T, m *InventoryManager) *models.Location {
+	t.Helper()
+	location, err := m.CreateLocation(context.Background(), &models.Location{Name: "Quarantine", Type: models.LocationWarehouse, Quarantine: true})
+	if err != nil {
+		t.Fatalf("CreateLocation: %v", err)
+	}
+	return location
+}
+
+func TestReturnStateMachine(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 5)
+	quarantine := createQuarantine(t, m)
+	ctx := context.Background()
+	order := shipTestOrder(t, m, item, 3)
+
+	if _, err := m.CreateReturn(ctx, &models.Return{SalesOrderID: order.ID, Lines: []models.ReturnLine{{ItemID: item.ID, Quantity: 4}}}); !errors.Is(err, ErrInvalidReturn) {
+		t.Errorf("returning more than was shipped: %v, want ErrInvalidReturn", err)
+	}
+	ret, err := m.CreateReturn(ctx, &models.Return{SalesOrderID: order.ID, Lines: []models.ReturnLine{{ItemID: item.ID, Quantity: 3}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.CreateReturn(ctx, &models.Return{SalesOrderID: order.ID, Lines: []models.ReturnLine{{ItemID: item.ID, Quantity: 1}}}); !errors.Is(err, ErrInvalidReturn) {
+		t.Errorf("returning a unit twice: %v, want ErrInvalidReturn", err)
+	}
+	line := ret.Lines[0].ID
+
+	if _, err := m.ReleaseReturn(ctx, ret.ID, []ReturnRelease{{LineID: line, Disposition: models.DispositionScrap, Quantity: 1, FromLocationID: quarantine.ID}}, "test"); !errors.Is(err, ErrReturnState) {
+		t.Errorf("releasing an authorized return: %v, want ErrReturnState", err)
+	}
+	if _, err := m.ReceiveReturn(ctx, ret.ID, []ReturnReceipt{{LineID: line, Condition: models.ConditionDamaged, Quantity: 2}}, "test"); !errors.Is(err, ErrInvalidReturn) {
+		t.Errorf("quarantining without a location: %v, want ErrInvalidReturn", err)
+	}
+	ret, err = m.ReceiveReturn(ctx, ret.ID, []ReturnReceipt{
+		{LineID: line, Condition: models.ConditionNew, Quantity: 1},
+		{LineID: line, Condition: models.ConditionDamaged, Quantity: 2, LocationID: &quarantine.ID},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Status != models.ReturnReceived || ret.Lines[0].Quarantined() != 2 {
+		t.Errorf("after receipt: %s with %d in quarantine, want received with 2", ret.Status, ret.Lines[0].Quarantined())
+	}
+	if item, err = m.GetItemByID(ctx, item.ID); err != nil || item.OnHand != 5 || item.Available() != 3 {
+		t.Errorf("after receipt: on hand %d, available %d, want 5 and 3", item.OnHand, item.Available())
+	}
+	if _, err := m.CancelReturn(ctx, ret.ID); !errors.Is(err, ErrReturnState) {
+		t.Errorf("cancelling a received return: %v, want ErrReturnState", err)
+	}
+
+	ret, err = m.ReleaseReturn(ctx, ret.ID, []ReturnRelease{
+		{LineID: line, Disposition: models.DispositionRestock, Quantity: 1, FromLocationID: quarantine.ID},
+		{LineID: line, Disposition: models.DispositionScrap, Quantity: 1, FromLocationID: quarantine.ID},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Status != models.ReturnClosed || ret.ClosedAt == nil {
+		t.Errorf("after emptying quarantine: %s, want closed", ret.Status)
+	}
+	if item, err = m.GetItemByID(ctx, item.ID); err != nil || item.OnHand != 4 || item.Reserved != 0 {
+		t.Errorf("after release: on hand %d, reserved %d, want 4 and 0", item.OnHand, item.Reserved)
+	}
+	if _, err := m.ReceiveReturn(ctx, ret.ID, []ReturnReceipt{{LineID: line, Condition: models.ConditionNew, Quantity: 1}}, "test"); !errors.Is(err, ErrReturnState) {
+		t.Errorf("receiving a closed return: %v, want ErrReturnState", err)
+	}
+}
+
+func TestConcurrentReturnsCannotExceedShipment(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 1)
+	order := shipTestOrder(t, m, item, 1)
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = m.CreateReturn(context.Background(), &models.Return{
+				SalesOrderID: order.ID,
+				Lines:        []models.ReturnLine{{ItemID: item.ID, Quantity: 1}},
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	authorized := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			authorized++
+		case !errors.Is(err, ErrInvalidReturn):
+			t.Errorf("CreateReturn: %v", err)
+		}
+	}
+	if authorized != 1 {
+		t.Errorf("%d returns authorized for the one unit shipped, want 1", authorized)
+	}
+}
+
+func TestReturnScrapsQuarantinedSerials(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{Serialized: true}, 0)
+	quarantine := createQuarantine(t, m)
+	ctx := context.Background()
+
+	_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: 1, Reason: models.MovementReceipt, SerialNumbers: []string{"SN-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := shipTestOrder(t, m, item, 1, "SN-1")
+	ret, err := m.CreateReturn(ctx, &models.Return{SalesOrderID: order.ID, Lines: []models.ReturnLine{{ItemID: item.ID, Quantity: 1}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := ret.Lines[0].ID
+
+	_, err = m.ReceiveReturn(ctx, ret.ID, []ReturnReceipt{
+		{LineID: line, Condition: models.ConditionDefective, Quantity: 1, LocationID: &quarantine.ID, SerialNumbers: []string{"SN-1"}},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ReserveSerial(ctx, "SN-1"); !errors.Is(err, ErrSerialState) {
+		t.Errorf("reserving a quarantined unit: %v, want ErrSerialState", err)
+	}
+
+	_, err = m.ReleaseReturn(ctx, ret.ID, []ReturnRelease{
+		{LineID: line, Disposition: models.DispositionScrap, Quantity: 1, FromLocationID: quarantine.ID, SerialNumbers: []string{"SN-1"}},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := m.GetSerial(ctx, "SN-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial.Status != models.SerialScrapped {
+		t.Errorf("scrapped unit is %s, want scrapped", serial.Status)
+	}
+}
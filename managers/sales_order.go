@@ -0,0 +1,510 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidSalesOrder = errors.New("invalid sales order")
+	ErrSalesOrderState   = errors.New("sales order is not in the right state")
+)
+
+// CreateSalesOrder records a pending sales order. Every line must name an
+// existing item; no stock is set aside until the order is allocated.
+func (m *InventoryManager) CreateSalesOrder(ctx context.Context, order *models.SalesOrder) (*models.SalesOrder, error) {
+	if order.Customer == "" {
+		return nil, fmt.Errorf("%w: customer is required", ErrInvalidSalesOrder)
+	}
+	if len(order.Lines) == 0 {
+		return nil, fmt.Errorf("%w: at least one line is required", ErrInvalidSalesOrder)
+	}
+	for i, line := range order.Lines {
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: line %d: quantity must be positive", ErrInvalidSalesOrder, i+1)
+		}
+		_, err := m.Store.GetItemByID(ctx, line.ItemID)
+		if errors.Is(err, service.ErrItemNotFound) {
+			return nil, fmt.Errorf("%w: line %d: item %q not found", ErrInvalidSalesOrder, i+1, line.ItemID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order.Status = models.SalesOrderPending
+	order.Picks = nil
+	order.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	order.AllocatedAt, order.PickedAt, order.ShippedAt, order.DeliveredAt = nil, nil, nil, nil
+	order.Version = 0
+
+	return m.Store.CreateSalesOrder(ctx, order)
+}
+
+// GetSalesOrders returns a page of sales orders, newest first, and the
+// number of orders matching the query.
+func (m *InventoryManager) GetSalesOrders(ctx context.Context, query models.SalesOrderQuery) ([]*models.SalesOrder, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.SalesOrderPending, models.SalesOrderAllocated, models.SalesOrderPicked,
+		models.SalesOrderShipped, models.SalesOrderDelivered, models.SalesOrderCancelled:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown sales order status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetSalesOrders(ctx, query)
+}
+
+func (m *InventoryManager) GetSalesOrderByID(ctx context.Context, id string) (*models.SalesOrder, error) {
+	return m.Store.GetSalesOrderByID(ctx, id)
+}
+
+// AllocateSalesOrder reserves the stock of every line of a pending order.
+// Either all lines are allocated or, when an item is short, none are.
+func (m *InventoryManager) AllocateSalesOrder(ctx context.Context, id string) (*models.SalesOrder, error) {
+	var order *models.SalesOrder
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = m.Store.GetSalesOrderByID(ctx, id); err != nil {
+			return err
+		}
+		if order.Status != models.SalesOrderPending {
+			return fmt.Errorf("%w: only a pending order can be allocated, this one is %s", ErrSalesOrderState, order.Status)
+		}
+
+		for _, line := range order.Lines {
+			if _, err := m.Store.AdjustStock(ctx, line.ItemID, 0, line.Quantity); err != nil {
+				return fmt.Errorf("item %s: %w", line.ItemID, err)
+			}
+		}
+
+		allocatedAt := time.Now().UTC().Truncate(time.Millisecond)
+		order.Status, order.AllocatedAt = models.SalesOrderAllocated, &allocatedAt
+		return m.Store.UpdateSalesOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// SalesOrderPickList returns where to pick the order's stock from. For an
+// allocated order it is a suggestion that empties the fullest locations
+// first; once the order is picked it is the confirmed pick list.
+func (m *InventoryManager) SalesOrderPickList(ctx context.Context, id string) ([]models.SalesOrderPick, error) {
+	order, err := m.Store.GetSalesOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch order.Status {
+	case models.SalesOrderAllocated:
+		return m.suggestPicks(ctx, order)
+	case models.SalesOrderPicked, models.SalesOrderShipped, models.SalesOrderDelivered:
+		return order.Picks, nil
+	}
+	return nil, fmt.Errorf("%w: a %s order has no pick list", ErrSalesOrderState, order.Status)
+}
+
+// PickSalesOrder confirms the pick list of an allocated order: picks, or
+// the suggested pick list when picks is empty. The picks of each line must
+// add up to its quantity and fit in the stock where they are taken from.
+func (m *InventoryManager) PickSalesOrder(ctx context.Context, id string, picks []models.SalesOrderPick) (*models.SalesOrder, error) {
+	var order *models.SalesOrder
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = m.Store.GetSalesOrderByID(ctx, id); err != nil {
+			return err
+		}
+		if order.Status != models.SalesOrderAllocated {
+			return fmt.Errorf("%w: only an allocated order can be picked, this one is %s", ErrSalesOrderState, order.Status)
+		}
+
+		if len(picks) == 0 {
+			if picks, err = m.suggestPicks(ctx, order); err != nil {
+				return err
+			}
+		} else if err := m.validatePicks(ctx, order, picks); err != nil {
+			return err
+		}
+
+		pickedAt := time.Now().UTC().Truncate(time.Millisecond)
+		order.Status, order.Picks, order.PickedAt = models.SalesOrderPicked, picks, &pickedAt
+		return m.Store.UpdateSalesOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ShipSalesOrder takes the picked stock out of inventory: each pick releases
+// its allocation and records a shipment in the ledger, all in one
+// transaction. serialNumbers lists the units shipped of the order's
+// serialized items, which are shared out among their picks in order.
+func (m *InventoryManager) ShipSalesOrder(ctx context.Context, id string, serialNumbers []string, user string) (*models.SalesOrder, error) {
+	var order *models.SalesOrder
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = m.Store.GetSalesOrderByID(ctx, id); err != nil {
+			return err
+		}
+		if order.Status != models.SalesOrderPicked {
+			return fmt.Errorf("%w: only a picked order can be shipped, this one is %s", ErrSalesOrderState, order.Status)
+		}
+
+		serialsByItem, err := m.serialsByItem(ctx, serialNumbers)
+		if err != nil {
+			return err
+		}
+
+		for _, pick := range order.Picks {
+			if _, err := m.Store.AdjustStock(ctx, pick.ItemID, 0, -pick.Quantity); err != nil {
+				return err
+			}
+			serials := serialsByItem[pick.ItemID]
+			n := min(pick.Quantity, len(serials))
+			serialsByItem[pick.ItemID] = serials[n:]
+
+			_, _, err := m.RecordMovement(ctx, pick.ItemID, &models.StockMovement{
+				Delta:         -pick.Quantity,
+				Reason:        models.MovementShipment,
+				Reference:     salesOrderReference(order),
+				User:          user,
+				LocationID:    pick.LocationID,
+				SerialNumbers: serials[:n],
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, serials := range serialsByItem {
+			if len(serials) > 0 {
+				return fmt.Errorf("%w: serial number %s is not for a unit of the order", ErrInvalidSalesOrder, serials[0])
+			}
+		}
+
+		shippedAt := time.Now().UTC().Truncate(time.Millisecond)
+		order.Status, order.ShippedAt = models.SalesOrderShipped, &shippedAt
+		return m.Store.UpdateSalesOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// serialsByItem groups serial numbers by the item they belong to, keeping
+// their order.
+func (m *InventoryManager) serialsByItem(ctx context.Context, serialNumbers []string) (map[string][]string, error) {
+	byItem := make(map[string][]string)
+	if len(serialNumbers) == 0 {
+		return byItem, nil
+	}
+	if err := checkSerialNumbers(serialNumbers); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSalesOrder, err)
+	}
+
+	serials, _, err := m.Store.GetSerials(ctx, models.SerialQuery{SerialNumbers: serialNumbers})
+	if err != nil {
+		return nil, err
+	}
+	itemIDs := make(map[string]string, len(serials))
+	for _, serial := range serials {
+		itemIDs[serial.SerialNumber] = serial.ItemID
+	}
+
+	for _, serialNumber := range serialNumbers {
+		itemID, ok := itemIDs[serialNumber]
+		if !ok {
+			return nil, fmt.Errorf("%w: serial number %s not found", ErrInvalidSalesOrder, serialNumber)
+		}
+		byItem[itemID] = append(byItem[itemID], serialNumber)
+	}
+	return byItem, nil
+}
+
+// DeliverSalesOrder records that a shipped order reached the customer.
+func (m *InventoryManager) DeliverSalesOrder(ctx context.Context, id string) (*models.SalesOrder, error) {
+	order, err := m.Store.GetSalesOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.SalesOrderShipped {
+		return nil, fmt.Errorf("%w: only a shipped order can be delivered, this one is %s", ErrSalesOrderState, order.Status)
+	}
+
+	deliveredAt := time.Now().UTC().Truncate(time.Millisecond)
+	order.Status, order.DeliveredAt = models.SalesOrderDelivered, &deliveredAt
+	if err := m.Store.UpdateSalesOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CancelSalesOrder abandons an order that has not shipped yet, releasing
+// its allocation if it has one.
+func (m *InventoryManager) CancelSalesOrder(ctx context.Context, id string) (*models.SalesOrder, error) {
+	var order *models.SalesOrder
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if order, err = m.Store.GetSalesOrderByID(ctx, id); err != nil {
+			return err
+		}
+
+		switch order.Status {
+		case models.SalesOrderPending:
+		case models.SalesOrderAllocated, models.SalesOrderPicked:
+			for _, line := range order.Lines {
+				_, err := m.Store.AdjustStock(ctx, line.ItemID, 0, -line.Quantity)
+				// A deleted item has no allocation left to release.
+				if err != nil && !errors.Is(err, service.ErrItemNotFound) {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("%w: cannot cancel a %s order", ErrSalesOrderState, order.Status)
+		}
+
+		order.Status, order.Picks = models.SalesOrderCancelled, nil
+		return m.Store.UpdateSalesOrder(ctx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// suggestPicks spreads each line over the locations holding its item, the
+// fullest first, and takes whatever remains from the item's unlocated stock.
+func (m *InventoryManager) suggestPicks(ctx context.Context, order *models.SalesOrder) ([]models.SalesOrderPick, error) {
+	available, err := m.pickableStock(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var picks []models.SalesOrderPick
+	for _, line := range order.Lines {
+		levels := available[line.ItemID]
+		sort.SliceStable(levels, func(i, j int) bool {
+			// The unlocated stock comes last.
+			if (levels[i].LocationID == "") != (levels[j].LocationID == "") {
+				return levels[j].LocationID == ""
+			}
+			if levels[i].OnHand != levels[j].OnHand {
+				return levels[i].OnHand > levels[j].OnHand
+			}
+			return levels[i].LocationID < levels[j].LocationID
+		})
+
+		remaining := line.Quantity
+		for _, level := range levels {
+			if remaining == 0 {
+				break
+			}
+			taken := min(remaining, level.OnHand)
+			if taken <= 0 {
+				continue
+			}
+			level.OnHand -= taken
+			remaining -= taken
+			picks = append(picks, newSalesOrderPick(line, level.LocationID, taken))
+		}
+		if remaining > 0 {
+			return nil, fmt.Errorf("%w: item %s is %d units short", service.ErrInsufficientStock, line.ItemID, remaining)
+		}
+	}
+	return picks, nil
+}
+
+// validatePicks checks that picks cover every line of order exactly and
+// that each location holds what is picked from it.
+func (m *InventoryManager) validatePicks(ctx context.Context, order *models.SalesOrder, picks []models.SalesOrderPick) error {
+	lines := make(map[string]models.SalesOrderLine, len(order.Lines))
+	picked := make(map[string]int, len(order.Lines))
+	for _, line := range order.Lines {
+		lines[line.ID] = line
+	}
+
+	available, err := m.pickableStock(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	for i := range picks {
+		pick := &picks[i]
+		line, ok := lines[pick.LineID]
+		if !ok {
+			return fmt.Errorf("%w: pick %d: line %q not found", ErrInvalidSalesOrder, i+1, pick.LineID)
+		}
+		if pick.Quantity <= 0 {
+			return fmt.Errorf("%w: pick %d: quantity must be positive", ErrInvalidSalesOrder, i+1)
+		}
+		pick.ItemID = line.ItemID
+		picked[line.ID] += pick.Quantity
+
+		locationID := ""
+		if pick.LocationID != nil {
+			locationID = *pick.LocationID
+		}
+		level := findLevel(available[line.ItemID], locationID)
+		if level == nil || level.OnHand < pick.Quantity {
+			return fmt.Errorf("%w: pick %d: not enough stock where it is picked from", service.ErrInsufficientStock, i+1)
+		}
+		level.OnHand -= pick.Quantity
+	}
+
+	for _, line := range order.Lines {
+		if picked[line.ID] != line.Quantity {
+			return fmt.Errorf("%w: line %s: picks add up to %d, not %d", ErrInvalidSalesOrder, line.ID, picked[line.ID], line.Quantity)
+		}
+	}
+	return nil
+}
+
+// pickableStock returns, per item of order, the stock at each location
+// other than quarantine locations and, with an empty location ID, the stock
+// not kept at any location. Stock that other orders have picked but not
+// shipped yet is left out.
+func (m *InventoryManager) pickableStock(ctx context.Context, order *models.SalesOrder) (map[string][]*models.StockLevel, error) {
+	locations, err := m.Store.GetLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quarantine := make(map[string]bool)
+	for _, location := range locations {
+		quarantine[location.ID] = location.Quarantine
+	}
+	picked, err := m.pickedElsewhere(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string][]*models.StockLevel)
+	for _, line := range order.Lines {
+		if _, ok := available[line.ItemID]; ok {
+			continue
+		}
+
+		item, err := m.Store.GetItemByID(ctx, line.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{ItemIDs: []string{line.ItemID}})
+		if err != nil {
+			return nil, err
+		}
+
+		unlocated := item.OnHand - picked[line.ItemID][""]
+		for _, level := range levels {
+			unlocated -= level.OnHand
+			if !quarantine[level.LocationID] {
+				level.OnHand -= picked[line.ItemID][level.LocationID]
+				available[line.ItemID] = append(available[line.ItemID], level)
+			}
+		}
+		available[line.ItemID] = append(available[line.ItemID], &models.StockLevel{ItemID: line.ItemID, OnHand: unlocated})
+	}
+	return available, nil
+}
+
+// pickedElsewhere adds up, per item and location, the picks of the picked
+// orders other than order, which are still waiting to be shipped. Picks of
+// unlocated stock are under an empty location ID.
+func (m *InventoryManager) pickedElsewhere(ctx context.Context, order *models.SalesOrder) (map[string]map[string]int, error) {
+	query := models.SalesOrderQuery{Status: models.SalesOrderPicked, Limit: MaxPageSize}
+
+	picked := make(map[string]map[string]int)
+	for {
+		orders, totalCount, err := m.Store.GetSalesOrders(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range orders {
+			if other.ID == order.ID {
+				continue
+			}
+			for _, pick := range other.Picks {
+				locationID := ""
+				if pick.LocationID != nil {
+					locationID = *pick.LocationID
+				}
+				if picked[pick.ItemID] == nil {
+					picked[pick.ItemID] = make(map[string]int)
+				}
+				picked[pick.ItemID][locationID] += pick.Quantity
+			}
+		}
+
+		query.Offset += len(orders)
+		if len(orders) == 0 || int64(query.Offset) >= totalCount {
+			return picked, nil
+		}
+	}
+}
+
+// openSalesOrderFor returns a sales order that has not shipped the item yet,
+// or nil if there is none.
+func (m *InventoryManager) openSalesOrderFor(ctx context.Context, itemID string) (*models.SalesOrder, error) {
+	for _, status := range []string{models.SalesOrderPending, models.SalesOrderAllocated, models.SalesOrderPicked} {
+		query := models.SalesOrderQuery{Status: status, Limit: MaxPageSize}
+		for {
+			orders, totalCount, err := m.Store.GetSalesOrders(ctx, query)
+			if err != nil {
+				return nil, err
+			}
+			for _, order := range orders {
+				for _, line := range order.Lines {
+					if line.ItemID == itemID {
+						return order, nil
+					}
+				}
+			}
+
+			query.Offset += len(orders)
+			if len(orders) == 0 || int64(query.Offset) >= totalCount {
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+func findLevel(levels []*models.StockLevel, locationID string) *models.StockLevel {
+	for _, level := range levels {
+		if level.LocationID == locationID {
+			return level
+		}
+	}
+	return nil
+}
+
+func newSalesOrderPick(line models.SalesOrderLine, locationID string, quantity int) models.SalesOrderPick {
+	pick := models.SalesOrderPick{LineID: line.ID, ItemID: line.ItemID, Quantity: quantity}
+	if locationID != "" {
+		pick.LocationID = &locationID
+	}
+	return pick
+}
+
+// salesOrderReference is the ledger reference of a sales order's shipments.
+func salesOrderReference(order *models.SalesOrder) string {
+	return "sales order " + order.ID
+}
@@ -0,0 +1,174 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"testing"
+)
+
+// shipTestOrder creates a sales order for quantity units of item and takes
+// it through allocation, picking and shipment.
+func shipTestOrder(t *testing.T, m *InventoryManager, item *models.Inventory, quantity int, serialNumbers ...string) *models.SalesOrder {
+	t.Helper()
+	ctx := context.Background()
+
+	order, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{{ItemID: item.ID, Quantity: quantity}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AllocateSalesOrder(ctx, order.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.PickSalesOrder(ctx, order.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if order, err = m.ShipSalesOrder(ctx, order.ID, serialNumbers, "test"); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestSalesOrderStateMachine(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 5)
+	ctx := context.Background()
+	stock := func() (int, int) {
+		t.Helper()
+		item, err := m.GetItemByID(ctx, item.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return item.OnHand, item.Reserved
+	}
+
+	order, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{{ItemID: item.ID, Quantity: 3}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ShipSalesOrder(ctx, order.ID, nil, "test"); !errors.Is(err, ErrSalesOrderState) {
+		t.Errorf("shipping a pending order: %v, want ErrSalesOrderState", err)
+	}
+	if _, err := m.AllocateSalesOrder(ctx, order.ID); err != nil {
+		t.Fatal(err)
+	}
+	if onHand, reserved := stock(); onHand != 5 || reserved != 3 {
+		t.Errorf("after allocation: on hand %d, reserved %d, want 5 and 3", onHand, reserved)
+	}
+
+	// An order that cannot be allocated in full reserves nothing.
+	short, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{
+		{ItemID: item.ID, Quantity: 1},
+		{ItemID: item.ID, Quantity: 2},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AllocateSalesOrder(ctx, short.ID); !errors.Is(err, service.ErrInsufficientStock) {
+		t.Errorf("allocating more than is available: %v, want ErrInsufficientStock", err)
+	}
+	if _, reserved := stock(); reserved != 3 {
+		t.Errorf("after a failed allocation: reserved %d, want 3", reserved)
+	}
+
+	if _, err := m.PickSalesOrder(ctx, order.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if order, err = m.ShipSalesOrder(ctx, order.ID, nil, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if onHand, reserved := stock(); onHand != 2 || reserved != 0 {
+		t.Errorf("after shipping: on hand %d, reserved %d, want 2 and 0", onHand, reserved)
+	}
+	if _, err := m.CancelSalesOrder(ctx, order.ID); !errors.Is(err, ErrSalesOrderState) {
+		t.Errorf("cancelling a shipped order: %v, want ErrSalesOrderState", err)
+	}
+	if order, err = m.DeliverSalesOrder(ctx, order.ID); err != nil || order.Status != models.SalesOrderDelivered {
+		t.Fatalf("delivering: %v", err)
+	}
+	if _, err := m.DeliverSalesOrder(ctx, order.ID); !errors.Is(err, ErrSalesOrderState) {
+		t.Errorf("delivering twice: %v, want ErrSalesOrderState", err)
+	}
+}
+
+func TestCancelSalesOrderReleasesAllocation(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 2)
+	ctx := context.Background()
+
+	order, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{{ItemID: item.ID, Quantity: 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.AllocateSalesOrder(ctx, order.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.PickSalesOrder(ctx, order.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if order, err = m.CancelSalesOrder(ctx, order.ID); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != models.SalesOrderCancelled || order.Picks != nil {
+		t.Errorf("cancelled order is %s with picks %v", order.Status, order.Picks)
+	}
+	if item, err = m.GetItemByID(ctx, item.ID); err != nil || item.Reserved != 0 {
+		t.Errorf("reserved after cancelling: %d, %v, want 0", item.Reserved, err)
+	}
+	if _, err := m.AllocateSalesOrder(ctx, order.ID); !errors.Is(err, ErrSalesOrderState) {
+		t.Errorf("allocating a cancelled order: %v, want ErrSalesOrderState", err)
+	}
+}
+
+func TestOverlappingOrdersPickDifferentStock(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 0)
+	ctx := context.Background()
+	for _, name := range []string{"A", "B"} {
+		location := createTestLocation(t, m, name)
+		_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: 3, Reason: models.MovementReceipt, LocationID: &location.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var orders []*models.SalesOrder
+	for range 2 {
+		order, err := m.CreateSalesOrder(ctx, &models.SalesOrder{Customer: "Acme", Lines: []models.SalesOrderLine{{ItemID: item.ID, Quantity: 3}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order, err = m.AllocateSalesOrder(ctx, order.ID); err != nil {
+			t.Fatal(err)
+		}
+		orders = append(orders, order)
+	}
+	first, err := m.PickSalesOrder(ctx, orders[0].ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Picks) != 1 {
+		t.Fatalf("first order picked %v, want all of one location", first.Picks)
+	}
+
+	taken := first.Picks[0].LocationID
+	line := orders[1].Lines[0].ID
+	_, err = m.PickSalesOrder(ctx, orders[1].ID, []models.SalesOrderPick{{LineID: line, LocationID: taken, Quantity: 3}})
+	if !errors.Is(err, service.ErrInsufficientStock) {
+		t.Errorf("picking stock another order has picked: %v, want ErrInsufficientStock", err)
+	}
+	second, err := m.PickSalesOrder(ctx, orders[1].ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Picks) != 1 || *second.Picks[0].LocationID == *taken {
+		t.Errorf("second order picked %v, want all of the other location", second.Picks)
+	}
+
+	for _, order := range orders {
+		if _, err := m.ShipSalesOrder(ctx, order.ID, nil, "test"); err != nil {
+			t.Errorf("shipping order %s: %v", order.ID, err)
+		}
+	}
+}
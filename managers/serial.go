@@ -0,0 +1,259 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	"time"
+)
+
+var (
+	ErrInvalidSerial = errors.New("invalid serial number")
+	ErrSerialState   = errors.New("serial number cannot change state")
+)
+
+// GetSerials returns a page of a serialized item's units, by serial number.
+func (m *InventoryManager) GetSerials(ctx context.Context, itemID string, query models.SerialQuery) ([]*models.Serial, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.SerialInStock, models.SerialReserved, models.SerialShipped, models.SerialReturned, models.SerialScrapped:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown serial status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	if _, err := m.Store.GetItemByID(ctx, itemID); err != nil {
+		return nil, 0, err
+	}
+
+	query.ItemID = itemID
+	return m.Store.GetSerials(ctx, query)
+}
+
+func (m *InventoryManager) GetSerial(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	return m.Store.GetSerialByNumber(ctx, serialNumber)
+}
+
+// RegisterSerials records the serial numbers of units already on hand, such
+// as stock taken in before the item was serialized. There can be no more
+// units in stock with a serial number than the item has on hand.
+func (m *InventoryManager) RegisterSerials(ctx context.Context, itemID string, serialNumbers []string) ([]*models.Serial, error) {
+	if err := checkSerialNumbers(serialNumbers); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSerial, err)
+	}
+
+	var serials []*models.Serial
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		item, err := m.Store.GetItemByID(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		if !item.Serialized {
+			return fmt.Errorf("%w: item is not serialized", ErrInvalidSerial)
+		}
+
+		onHand, err := m.serialsOnHand(ctx, itemID)
+		if err != nil {
+			return err
+		}
+		if onHand+len(serialNumbers) > item.OnHand {
+			return fmt.Errorf("%w: only %d units on hand have no serial number", ErrInvalidSerial, item.OnHand-onHand)
+		}
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		for _, serialNumber := range serialNumbers {
+			serial, err := m.Store.CreateSerial(ctx, &models.Serial{
+				ItemID:       itemID,
+				SerialNumber: serialNumber,
+				Status:       models.SerialInStock,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			})
+			if err != nil {
+				return err
+			}
+			serials = append(serials, serial)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return serials, nil
+}
+
+func (m *InventoryManager) serialsOnHand(ctx context.Context, itemID string) (int, error) {
+	onHand := 0
+	for _, status := range []string{models.SerialInStock, models.SerialReserved, models.SerialReturned} {
+		_, count, err := m.Store.GetSerials(ctx, models.SerialQuery{ItemID: itemID, Status: status, Limit: 1})
+		if err != nil {
+			return 0, err
+		}
+		onHand += int(count)
+	}
+	return onHand, nil
+}
+
+// ReserveSerial sets a unit in stock aside, reserving one unit of its item.
+func (m *InventoryManager) ReserveSerial(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	return m.changeSerialReservation(ctx, serialNumber, true)
+}
+
+// ReleaseSerial returns a reserved unit to the available stock.
+func (m *InventoryManager) ReleaseSerial(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	return m.changeSerialReservation(ctx, serialNumber, false)
+}
+
+func (m *InventoryManager) changeSerialReservation(ctx context.Context, serialNumber string, reserve bool) (*models.Serial, error) {
+	var serial *models.Serial
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if serial, err = m.Store.GetSerialByNumber(ctx, serialNumber); err != nil {
+			return err
+		}
+
+		from, to, reservedDelta := serial.Status, models.SerialInStock, -1
+		if reserve {
+			if serial.Status != models.SerialInStock {
+				return fmt.Errorf("%w: only a unit in stock can be reserved, this one is %s", ErrSerialState, serial.Status)
+			}
+			to, reservedDelta = models.SerialReserved, 1
+		} else if serial.Status != models.SerialReserved {
+			return fmt.Errorf("%w: only a reserved unit can be released, this one is %s", ErrSerialState, serial.Status)
+		}
+
+		if _, err := m.Store.AdjustStock(ctx, serial.ItemID, 0, reservedDelta); err != nil {
+			return err
+		}
+		serial.Status, serial.UpdatedAt = to, time.Now().UTC().Truncate(time.Millisecond)
+		return m.Store.UpdateSerialStatus(ctx, serial.ID, from, to, serial.UpdatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return serial, nil
+}
+
+// applySerials updates the serials a movement of a serialized item names,
+// one per unit of its delta. Receipts and other additions register new
+// serial numbers or bring back ones that left, returns take back shipped
+// units, shipments ship units in stock and other removals scrap them. A
+// shipment may ship a reserved unit, releasing its reservation; anything
+// else has to release it first. Returned units are in quarantine and leave
+// it only through their return. Transfers move stock between locations and
+// leave serials alone.
+func (m *InventoryManager) applySerials(ctx context.Context, itemID string, movement *models.StockMovement) error {
+	item, err := m.Store.GetItemByID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if movement.Reason == models.MovementTransfer || !item.Serialized {
+		if len(movement.SerialNumbers) > 0 {
+			return fmt.Errorf("%w: only receipts, issues and adjustments of serialized items name serial numbers", ErrInvalidMovement)
+		}
+		return nil
+	}
+
+	quantity := movement.Delta
+	if quantity < 0 {
+		quantity = -quantity
+	}
+	if len(movement.SerialNumbers) != quantity {
+		return fmt.Errorf("%w: the item is serialized, name a serial number for each of the %d units", ErrInvalidMovement, quantity)
+	}
+	if err := checkSerialNumbers(movement.SerialNumbers); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMovement, err)
+	}
+
+	serials, _, err := m.Store.GetSerials(ctx, models.SerialQuery{SerialNumbers: movement.SerialNumbers})
+	if err != nil {
+		return err
+	}
+	known := make(map[string]*models.Serial, len(serials))
+	for _, serial := range serials {
+		known[serial.SerialNumber] = serial
+	}
+
+	for _, serialNumber := range movement.SerialNumbers {
+		serial, ok := known[serialNumber]
+		if ok && serial.ItemID != itemID {
+			return fmt.Errorf("%w: serial number %s belongs to another item", ErrInvalidMovement, serialNumber)
+		}
+
+		var to string
+		switch {
+		case movement.Delta > 0 && movement.Reason == models.MovementReturn:
+			if !ok || serial.Status != models.SerialShipped {
+				return fmt.Errorf("%w: serial number %s was not shipped", ErrInvalidMovement, serialNumber)
+			}
+			to = models.SerialReturned
+		case movement.Delta > 0:
+			if !ok {
+				_, err := m.Store.CreateSerial(ctx, &models.Serial{
+					ItemID:       itemID,
+					SerialNumber: serialNumber,
+					Status:       models.SerialInStock,
+					CreatedAt:    movement.CreatedAt,
+					UpdatedAt:    movement.CreatedAt,
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if serial.OnHand() {
+				return fmt.Errorf("%w: serial number %s is already in stock", ErrInvalidMovement, serialNumber)
+			}
+			to = models.SerialInStock
+		default:
+			if ok && serial.Status == models.SerialReturned {
+				return fmt.Errorf("%w: serial number %s is in quarantine, release it through its return", ErrInvalidMovement, serialNumber)
+			}
+			if !ok || (serial.Status != models.SerialInStock && serial.Status != models.SerialReserved) {
+				return fmt.Errorf("%w: serial number %s is not in stock", ErrInvalidMovement, serialNumber)
+			}
+			to = models.SerialScrapped
+			if movement.Reason == models.MovementShipment {
+				to = models.SerialShipped
+			}
+			if serial.Status == models.SerialReserved {
+				if to != models.SerialShipped {
+					return fmt.Errorf("%w: serial number %s is reserved, release it first", ErrInvalidMovement, serialNumber)
+				}
+				if _, err := m.Store.AdjustStock(ctx, itemID, 0, -1); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := m.Store.UpdateSerialStatus(ctx, serial.ID, serial.Status, to, movement.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSerialNumbers rejects empty and repeated serial numbers.
+func checkSerialNumbers(serialNumbers []string) error {
+	seen := make(map[string]bool, len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		if serialNumber == "" {
+			return errors.New("serial numbers must not be empty")
+		}
+		if seen[serialNumber] {
+			return fmt.Errorf("serial number %s is listed twice", serialNumber)
+		}
+		seen[serialNumber] = true
+	}
+	return nil
+}
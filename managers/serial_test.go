@@ -0,0 +1,93 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"testing"
+)
+
+func TestReturnedSerialIsQuarantined(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{Serialized: true}, 0)
+	ctx := context.Background()
+
+	move := func(delta int, reason string, serialNumbers ...string) error {
+		_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: delta, Reason: reason, SerialNumbers: serialNumbers})
+		return err
+	}
+	if err := move(2, models.MovementReceipt, "SN-1", "SN-2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := move(-1, models.MovementShipment, "SN-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := move(1, models.MovementReturn, "SN-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.ReserveSerial(ctx, "SN-1"); !errors.Is(err, ErrSerialState) {
+		t.Errorf("reserving a returned unit: %v, want ErrSerialState", err)
+	}
+	if err := move(-1, models.MovementShipment, "SN-1"); !errors.Is(err, ErrInvalidMovement) {
+		t.Errorf("shipping a returned unit: %v, want ErrInvalidMovement", err)
+	}
+	if err := move(-1, models.MovementAdjustment, "SN-1"); !errors.Is(err, ErrInvalidMovement) {
+		t.Errorf("scrapping a returned unit: %v, want ErrInvalidMovement", err)
+	}
+
+	if _, err := m.ReserveSerial(ctx, "SN-2"); err != nil {
+		t.Fatalf("reserving a unit in stock: %v", err)
+	}
+	if err := move(-1, models.MovementShipment, "SN-2"); err != nil {
+		t.Fatalf("shipping a reserved unit: %v", err)
+	}
+	if item, err := m.GetItemByID(ctx, item.ID); err != nil {
+		t.Fatal(err)
+	} else if item.OnHand != 1 || item.Reserved != 0 {
+		t.Errorf("on hand %d, reserved %d, want 1 and 0", item.OnHand, item.Reserved)
+	}
+}
+
+func TestDeleteItemWithSerials(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, &models.Inventory{Serialized: true}, 0)
+	ctx := context.Background()
+
+	for _, delta := range []int{1, -1} {
+		reason := models.MovementReceipt
+		if delta < 0 {
+			reason = models.MovementShipment
+		}
+		_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: delta, Reason: reason, SerialNumbers: []string{"SN-1"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.DeleteItem(ctx, item.ID); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("deleting an item with shipped serial numbers: %v, want ErrItemInUse", err)
+	}
+}
+
+func TestSerialTrackingChangesOnlyWithoutStock(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 2)
+	ctx := context.Background()
+
+	update := *item
+	update.Serialized = true
+	if _, err := m.UpdateItem(ctx, item.ID, &update); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("turning serial numbers on with stock: %v, want ErrItemInUse", err)
+	}
+	patch := []byte(`[{"op": "replace", "path": "/serialized", "value": true}]`)
+	if _, err := m.PatchItem(ctx, item.ID, JSONPatchContentType, patch); !errors.Is(err, ErrItemInUse) {
+		t.Errorf("patching serial numbers on with stock: %v, want ErrItemInUse", err)
+	}
+
+	if _, err := m.AdjustStock(ctx, item.ID, -2, "count", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if updated, err := m.UpdateItem(ctx, item.ID, &update); err != nil || !updated.Serialized {
+		t.Errorf("turning serial numbers on without stock: %v", err)
+	}
+}
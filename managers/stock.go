@@ -0,0 +1,154 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var (
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	ErrInvalidMovement = errors.New("invalid stock movement")
+)
+
+// RecordMovement appends movement to the item's ledger and applies its delta
+// to the on-hand quantity in one transaction. Receipts and returns must add
+// stock and shipments must remove it. A movement with a location also
+// changes the stock there; one without can only take stock that is not kept
+// at any location. Movements of lot-tracked and serialized items also
+// change their lots and serials, see applyLots and applySerials.
+func (m *InventoryManager) RecordMovement(ctx context.Context, itemID string, movement *models.StockMovement) (*models.StockMovement, *models.Inventory, error) {
+	if err := validateMovement(movement); err != nil {
+		return nil, nil, err
+	}
+	if movement.LocationID != nil {
+		_, err := m.Store.GetLocationByID(ctx, *movement.LocationID)
+		if errors.Is(err, service.ErrLocationNotFound) {
+			return nil, nil, fmt.Errorf("%w: location not found", ErrInvalidMovement)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	movement.ItemID = itemID
+	movement.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	var item *models.Inventory
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := m.applySerials(ctx, itemID, movement); err != nil {
+			return err
+		}
+		var err error
+		if item, err = m.Store.AdjustStock(ctx, itemID, movement.Delta, 0); err != nil {
+			return err
+		}
+		if movement.LocationID != nil {
+			_, err = m.Store.AdjustLocationStock(ctx, itemID, *movement.LocationID, movement.Delta)
+		} else if movement.Delta < 0 {
+			err = m.checkUnlocatedStock(ctx, item)
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.applyLots(ctx, item, movement); err != nil {
+			return err
+		}
+		movement, err = m.Store.CreateMovement(ctx, movement)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return movement, item, nil
+}
+
+// checkUnlocatedStock fails with service.ErrInsufficientStock if the item's
+// stock levels add up to more than its on-hand quantity.
+func (m *InventoryManager) checkUnlocatedStock(ctx context.Context, item *models.Inventory) error {
+	levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{ItemIDs: []string{item.ID}})
+	if err != nil {
+		return err
+	}
+
+	located := 0
+	for _, level := range levels {
+		located += level.OnHand
+	}
+	if located > item.OnHand {
+		return fmt.Errorf("%w: the stock is kept at locations, name the location to take it from", service.ErrInsufficientStock)
+	}
+	return nil
+}
+
+func validateMovement(movement *models.StockMovement) error {
+	if movement.Delta == 0 {
+		return fmt.Errorf("%w: delta must not be zero", ErrInvalidMovement)
+	}
+
+	switch movement.Reason {
+	case models.MovementReceipt, models.MovementReturn:
+		if movement.Delta < 0 {
+			return fmt.Errorf("%w: a %s must have a positive delta", ErrInvalidMovement, movement.Reason)
+		}
+	case models.MovementShipment:
+		if movement.Delta > 0 {
+			return fmt.Errorf("%w: a shipment must have a negative delta", ErrInvalidMovement)
+		}
+	case models.MovementAdjustment, models.MovementTransfer, models.MovementAssembly, models.MovementDisassembly:
+	default:
+		return fmt.Errorf("%w: unknown reason %q", ErrInvalidMovement, movement.Reason)
+	}
+	return nil
+}
+
+// ListMovements returns a page of the item's ledger, oldest first, with the
+// total number of movements and the on-hand quantity the whole ledger adds
+// up to.
+func (m *InventoryManager) ListMovements(ctx context.Context, itemID string, query models.MovementQuery) ([]*models.StockMovement, int64, int, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+	if _, err := m.Store.GetItemByID(ctx, itemID); err != nil {
+		return nil, 0, 0, err
+	}
+
+	movements, totalCount, err := m.Store.ListMovements(ctx, itemID, query)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	ledgerOnHand, err := m.Store.SumMovements(ctx, itemID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return movements, totalCount, ledgerOnHand, nil
+}
+
+// AdjustStock records an adjustment of delta, which may be negative, to the
+// on-hand quantity. On-hand stock can never drop below the reserved
+// quantity.
+func (m *InventoryManager) AdjustStock(ctx context.Context, id string, delta int, reference, user string) (*models.Inventory, error) {
+	if delta == 0 {
+		return nil, fmt.Errorf("%w: must not be zero", ErrInvalidQuantity)
+	}
+
+	_, item, err := m.RecordMovement(ctx, id, &models.StockMovement{
+		Delta:     delta,
+		Reason:    models.MovementAdjustment,
+		Reference: reference,
+		User:      user,
+	})
+	return item, err
+}
@@ -0,0 +1,34 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	service "main/services"
+	"testing"
+)
+
+func TestListMovements(t *testing.T) {
+	m := newTestManager(t)
+	ctx := context.Background()
+
+	item := createTestItem(t, m, nil, 5)
+	if _, err := m.AdjustStock(ctx, item.ID, -2, "count", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	movements, total, ledgerOnHand, err := m.ListMovements(ctx, item.ID, models.MovementQuery{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movements) != 1 || total != 2 || ledgerOnHand != 3 {
+		t.Errorf("listed %d of %d movements adding up to %d, want 1 of 2 adding up to 3", len(movements), total, ledgerOnHand)
+	}
+
+	if _, _, _, err := m.ListMovements(ctx, "missing", models.MovementQuery{}); !errors.Is(err, service.ErrItemNotFound) {
+		t.Errorf("listing the movements of a missing item: %v, want ErrItemNotFound", err)
+	}
+	if _, _, _, err := m.ListMovements(ctx, item.ID, models.MovementQuery{Offset: -1}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("listing from a negative offset: %v, want ErrInvalidQuery", err)
+	}
+}
@@ -0,0 +1,207 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/models"
+	service "main/services"
+	"time"
+)
+
+var (
+	ErrInvalidTransfer = errors.New("invalid transfer")
+	ErrTransferState   = errors.New("transfer is not in the right state")
+)
+
+// CreateTransfer drafts a transfer. Drafts do not touch any stock until they
+// are shipped.
+func (m *InventoryManager) CreateTransfer(ctx context.Context, transfer *models.Transfer) (*models.Transfer, error) {
+	if transfer.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidTransfer)
+	}
+	if transfer.FromLocationID == transfer.ToLocationID {
+		return nil, fmt.Errorf("%w: source and destination must differ", ErrInvalidTransfer)
+	}
+
+	_, err := m.Store.GetItemByID(ctx, transfer.ItemID)
+	if errors.Is(err, service.ErrItemNotFound) {
+		return nil, fmt.Errorf("%w: item %q not found", ErrInvalidTransfer, transfer.ItemID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, locationID := range []string{transfer.FromLocationID, transfer.ToLocationID} {
+		_, err := m.Store.GetLocationByID(ctx, locationID)
+		if errors.Is(err, service.ErrLocationNotFound) {
+			return nil, fmt.Errorf("%w: location %q not found", ErrInvalidTransfer, locationID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transfer.Status = models.TransferDraft
+	transfer.Received = 0
+	transfer.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	transfer.ShippedAt, transfer.ReceivedAt = nil, nil
+
+	return m.Store.CreateTransfer(ctx, transfer)
+}
+
+// GetTransfers returns a page of transfers, oldest first, and the number of
+// transfers matching the query.
+func (m *InventoryManager) GetTransfers(ctx context.Context, query models.TransferQuery) ([]*models.Transfer, int64, error) {
+	if query.Limit < 0 || query.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	switch query.Status {
+	case "", models.TransferDraft, models.TransferShipped, models.TransferReceived, models.TransferCancelled:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown transfer status %q", ErrInvalidQuery, query.Status)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	if query.Limit > MaxPageSize {
+		query.Limit = MaxPageSize
+	}
+
+	return m.Store.GetTransfers(ctx, query)
+}
+
+func (m *InventoryManager) GetTransferByID(ctx context.Context, id string) (*models.Transfer, error) {
+	return m.Store.GetTransferByID(ctx, id)
+}
+
+// ShipTransfer takes the whole quantity out of the source location and puts
+// it in transit. The ledger entry and the status change are written in one
+// transaction.
+func (m *InventoryManager) ShipTransfer(ctx context.Context, id, user string) (*models.Transfer, error) {
+	var transfer *models.Transfer
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if transfer, err = m.Store.GetTransferByID(ctx, id); err != nil {
+			return err
+		}
+		if transfer.Status != models.TransferDraft {
+			return fmt.Errorf("%w: only a draft can be shipped, this one is %s", ErrTransferState, transfer.Status)
+		}
+
+		_, _, err = m.RecordMovement(ctx, transfer.ItemID, &models.StockMovement{
+			Delta:      -transfer.Quantity,
+			Reason:     models.MovementTransfer,
+			Reference:  transferReference(transfer),
+			User:       user,
+			LocationID: &transfer.FromLocationID,
+		})
+		if err != nil {
+			return err
+		}
+
+		shippedAt := time.Now().UTC().Truncate(time.Millisecond)
+		transfer.Status, transfer.ShippedAt = models.TransferShipped, &shippedAt
+		return m.Store.UpdateTransfer(ctx, transfer, models.TransferDraft, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// ReceiveTransfer puts quantity units of a shipped transfer into the
+// destination location, or everything still in transit when quantity is
+// zero. The transfer is received once all of it has arrived.
+func (m *InventoryManager) ReceiveTransfer(ctx context.Context, id string, quantity int, user string) (*models.Transfer, error) {
+	if quantity < 0 {
+		return nil, fmt.Errorf("%w: quantity must not be negative", ErrInvalidTransfer)
+	}
+
+	var transfer *models.Transfer
+	err := m.Store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if transfer, err = m.Store.GetTransferByID(ctx, id); err != nil {
+			return err
+		}
+		if transfer.Status != models.TransferShipped {
+			return fmt.Errorf("%w: only a shipped transfer can be received, this one is %s", ErrTransferState, transfer.Status)
+		}
+
+		receiving := quantity
+		if receiving == 0 {
+			receiving = transfer.InTransit()
+		}
+		if receiving > transfer.InTransit() {
+			return fmt.Errorf("%w: only %d units are in transit", ErrInvalidTransfer, transfer.InTransit())
+		}
+
+		_, _, err = m.RecordMovement(ctx, transfer.ItemID, &models.StockMovement{
+			Delta:      receiving,
+			Reason:     models.MovementTransfer,
+			Reference:  transferReference(transfer),
+			User:       user,
+			LocationID: &transfer.ToLocationID,
+		})
+		if err != nil {
+			return err
+		}
+
+		received := transfer.Received
+		transfer.Received += receiving
+		if transfer.Received == transfer.Quantity {
+			receivedAt := time.Now().UTC().Truncate(time.Millisecond)
+			transfer.Status, transfer.ReceivedAt = models.TransferReceived, &receivedAt
+		}
+		return m.Store.UpdateTransfer(ctx, transfer, models.TransferShipped, received)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// CancelTransfer abandons a draft.
+func (m *InventoryManager) CancelTransfer(ctx context.Context, id string) (*models.Transfer, error) {
+	transfer, err := m.Store.GetTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != models.TransferDraft {
+		return nil, fmt.Errorf("%w: only a draft can be cancelled, this one is %s", ErrTransferState, transfer.Status)
+	}
+
+	transfer.Status = models.TransferCancelled
+	if err := m.Store.UpdateTransfer(ctx, transfer, models.TransferDraft, 0); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// InTransit returns how many units of the item have been shipped by
+// transfers but not yet received.
+func (m *InventoryManager) InTransit(ctx context.Context, itemID string) (int, error) {
+	query := models.TransferQuery{ItemID: itemID, Status: models.TransferShipped, Limit: MaxPageSize}
+
+	inTransit := 0
+	for {
+		transfers, totalCount, err := m.Store.GetTransfers(ctx, query)
+		if err != nil {
+			return 0, err
+		}
+		for _, transfer := range transfers {
+			inTransit += transfer.InTransit()
+		}
+
+		query.Offset += len(transfers)
+		if len(transfers) == 0 || int64(query.Offset) >= totalCount {
+			return inTransit, nil
+		}
+	}
+}
+
+// transferReference is the ledger reference of a transfer's movements.
+func transferReference(transfer *models.Transfer) string {
+	return "transfer " + transfer.ID
+}
@@ -0,0 +1,81 @@
+package managers
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"testing"
+)
+
+func TestTransferStateMachine(t *testing.T) {
+	m := newTestManager(t)
+	item := createTestItem(t, m, nil, 0)
+	from, to := createTestLocation(t, m, "North"), createTestLocation(t, m, "South")
+	ctx := context.Background()
+
+	_, _, err := m.RecordMovement(ctx, item.ID, &models.StockMovement{Delta: 5, Reason: models.MovementReceipt, LocationID: &from.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTransfer := func(quantity int) *models.Transfer {
+		t.Helper()
+		transfer, err := m.CreateTransfer(ctx, &models.Transfer{ItemID: item.ID, FromLocationID: from.ID, ToLocationID: to.ID, Quantity: quantity})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return transfer
+	}
+
+	cancelled := newTransfer(1)
+	if _, err := m.CancelTransfer(ctx, cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ShipTransfer(ctx, cancelled.ID, "test"); !errors.Is(err, ErrTransferState) {
+		t.Errorf("shipping a cancelled transfer: %v, want ErrTransferState", err)
+	}
+
+	transfer := newTransfer(3)
+	if _, err := m.ReceiveTransfer(ctx, transfer.ID, 0, "test"); !errors.Is(err, ErrTransferState) {
+		t.Errorf("receiving a draft: %v, want ErrTransferState", err)
+	}
+	if _, err := m.ShipTransfer(ctx, transfer.ID, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.CancelTransfer(ctx, transfer.ID); !errors.Is(err, ErrTransferState) {
+		t.Errorf("cancelling a shipped transfer: %v, want ErrTransferState", err)
+	}
+	if inTransit, err := m.InTransit(ctx, item.ID); err != nil || inTransit != 3 {
+		t.Errorf("in transit after shipping: %d, %v, want 3", inTransit, err)
+	}
+
+	if transfer, err = m.ReceiveTransfer(ctx, transfer.ID, 1, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.Status != models.TransferShipped || transfer.InTransit() != 2 {
+		t.Errorf("after a partial receipt: %s with %d in transit, want shipped with 2", transfer.Status, transfer.InTransit())
+	}
+	if _, err := m.ReceiveTransfer(ctx, transfer.ID, 3, "test"); !errors.Is(err, ErrInvalidTransfer) {
+		t.Errorf("receiving more than is in transit: %v, want ErrInvalidTransfer", err)
+	}
+	if transfer, err = m.ReceiveTransfer(ctx, transfer.ID, 0, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if transfer.Status != models.TransferReceived || transfer.ReceivedAt == nil {
+		t.Errorf("after receiving the rest: %s, want received", transfer.Status)
+	}
+	if _, err := m.ReceiveTransfer(ctx, transfer.ID, 0, "test"); !errors.Is(err, ErrTransferState) {
+		t.Errorf("receiving a received transfer: %v, want ErrTransferState", err)
+	}
+
+	levels, err := m.Store.GetStockLevels(ctx, models.StockLevelQuery{ItemIDs: []string{item.ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	onHand := map[string]int{}
+	for _, level := range levels {
+		onHand[level.LocationID] = level.OnHand
+	}
+	if onHand[from.ID] != 2 || onHand[to.ID] != 3 {
+		t.Errorf("stock at source %d and destination %d, want 2 and 3", onHand[from.ID], onHand[to.ID])
+	}
+}
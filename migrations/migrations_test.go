@@ -0,0 +1,115 @@
+package migrations
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestLoad(t *testing.T) {
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		migrations, err := load(dialect)
+		if err != nil {
+			t.Fatalf("%s: %v", dialect, err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("%s: no migrations", dialect)
+		}
+		for i, migration := range migrations {
+			if migration.Version != int64(i+1) {
+				t.Errorf("%s: migration %d_%s is number %d", dialect, migration.Version, migration.Name, i+1)
+			}
+		}
+	}
+
+	if _, err := load("mysql"); err == nil {
+		t.Error("loading migrations for an unknown dialect succeeded")
+	}
+}
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "inventory.db")
+	db, err := gorm.Open(sqlite.Open(path+"?_pragma=foreign_keys(1)"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func tables(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	var names []string
+	err := db.Raw(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name <> 'schema_migrations'
+		ORDER BY name`).Scan(&names).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	m, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := m.Up(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != len(m.Migrations) {
+		t.Errorf("applied %d migrations to an empty database, want all %d", len(done), len(m.Migrations))
+	}
+	if len(tables(t, db)) == 0 {
+		t.Error("migrations created no tables")
+	}
+
+	// Running the migrations again changes nothing.
+	done, err = m.Up(ctx)
+	if err != nil {
+		t.Fatalf("migrating a second time: %v", err)
+	}
+	if len(done) != 0 {
+		t.Errorf("applied %d migrations a second time, want none", len(done))
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.AppliedAt == nil {
+			t.Errorf("migration %d_%s is not recorded as applied", status.Version, status.Name)
+		}
+	}
+
+	// Every migration rolls back, newest first, and applies again.
+	done, err = m.Down(ctx, len(m.Migrations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != len(m.Migrations) || done[0].Version != m.Migrations[len(m.Migrations)-1].Version {
+		t.Errorf("rolled back %d migrations, want all %d newest first", len(done), len(m.Migrations))
+	}
+	if names := tables(t, db); len(names) != 0 {
+		t.Errorf("tables %v remain after rolling back every migration", names)
+	}
+	if done, err = m.Up(ctx); err != nil || len(done) != len(m.Migrations) {
+		t.Errorf("migrating after a rollback applied %d migrations: %v", len(done), err)
+	}
+
+	if _, err := m.Down(ctx, 0); err == nil {
+		t.Error("rolling back no migrations succeeded")
+	}
+}
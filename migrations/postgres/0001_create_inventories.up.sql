@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "inventories" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"product_name" varchar(255),
+	"price" bigint,
+	"currency" varchar(10),
+	"discount" bigint,
+	"vendor" varchar(255)
+);
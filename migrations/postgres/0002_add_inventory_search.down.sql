@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "inventories_vendor_trgm_idx";
+DROP INDEX IF EXISTS "inventories_product_name_trgm_idx";
+DROP INDEX IF EXISTS "inventories_search_vector_idx";
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "inventories" ADD COLUMN IF NOT EXISTS "search_vector" tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce("product_name", '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce("vendor", '')), 'B')
+	) STORED;
+
+CREATE INDEX IF NOT EXISTS "inventories_search_vector_idx" ON "inventories" USING GIN ("search_vector");
+CREATE INDEX IF NOT EXISTS "inventories_product_name_trgm_idx" ON "inventories" USING GIN ("product_name" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "inventories_vendor_trgm_idx" ON "inventories" USING GIN ("vendor" gin_trgm_ops);
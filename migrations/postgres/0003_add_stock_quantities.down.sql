@@ -0,0 +1,2 @@
+ALTER TABLE "inventories" DROP CONSTRAINT IF EXISTS "inventories_stock_check";
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "reserved", DROP COLUMN IF EXISTS "on_hand";
@@ -0,0 +1,6 @@
+ALTER TABLE "inventories"
+	ADD COLUMN IF NOT EXISTS "on_hand" bigint NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS "reserved" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "inventories" ADD CONSTRAINT "inventories_stock_check"
+	CHECK ("on_hand" >= 0 AND "reserved" >= 0 AND "reserved" <= "on_hand");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "stock_movements";
+DROP FUNCTION IF EXISTS "stock_movements_append_only"();
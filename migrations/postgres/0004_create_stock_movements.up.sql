@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS "stock_movements" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL,
+	"delta" bigint NOT NULL CHECK ("delta" <> 0),
+	"reason" varchar(32) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"user_name" varchar(255) NOT NULL DEFAULT '',
+	"created_at" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movements_item_id_created_at_idx" ON "stock_movements" ("item_id", "created_at", "id");
+
+CREATE OR REPLACE FUNCTION "stock_movements_append_only"() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "stock_movements_append_only"
+	BEFORE UPDATE OR DELETE ON "stock_movements"
+	FOR EACH ROW EXECUTE FUNCTION "stock_movements_append_only"();
+
+-- Record existing stock as an opening balance so the ledger replays to it.
+INSERT INTO "stock_movements" ("item_id", "delta", "reason", "reference")
+	SELECT "id", "on_hand", 'adjustment', 'opening balance' FROM "inventories" WHERE "on_hand" <> 0;
@@ -0,0 +1,3 @@
+ALTER TABLE "stock_movements" DROP COLUMN IF EXISTS "location_id";
+DROP TABLE IF EXISTS "stock_levels";
+DROP TABLE IF EXISTS "locations";
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "locations" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"name" varchar(255) NOT NULL,
+	"type" varchar(32) NOT NULL,
+	"parent_id" uuid REFERENCES "locations" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "locations_parent_id_idx" ON "locations" ("parent_id");
+
+CREATE TABLE IF NOT EXISTS "stock_levels" (
+	"item_id" uuid NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"location_id" uuid NOT NULL REFERENCES "locations" ("id"),
+	"on_hand" bigint NOT NULL DEFAULT 0 CHECK ("on_hand" >= 0),
+	PRIMARY KEY ("item_id", "location_id")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_levels_location_id_idx" ON "stock_levels" ("location_id");
+
+ALTER TABLE "stock_movements" ADD COLUMN IF NOT EXISTS "location_id" uuid;
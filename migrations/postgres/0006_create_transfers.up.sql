@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "transfers" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL,
+	"from_location_id" uuid NOT NULL,
+	"to_location_id" uuid NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	"received" bigint NOT NULL DEFAULT 0 CHECK ("received" >= 0 AND "received" <= "quantity"),
+	"status" varchar(16) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"user_name" varchar(255) NOT NULL DEFAULT '',
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"shipped_at" timestamptz,
+	"received_at" timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS "transfers_item_id_idx" ON "transfers" ("item_id");
+CREATE INDEX IF NOT EXISTS "transfers_status_idx" ON "transfers" ("status");
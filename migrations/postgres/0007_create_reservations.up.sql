@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "reservations" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	"status" varchar(16) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"expires_at" timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "reservations_item_id_idx" ON "reservations" ("item_id");
+CREATE INDEX IF NOT EXISTS "reservations_status_expires_at_idx" ON "reservations" ("status", "expires_at");
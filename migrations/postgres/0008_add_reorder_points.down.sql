@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "low_stock_alerts";
+ALTER TABLE "inventories"
+	DROP COLUMN IF EXISTS "reorder_quantity",
+	DROP COLUMN IF EXISTS "reorder_point";
@@ -0,0 +1,18 @@
+ALTER TABLE "inventories"
+	ADD COLUMN IF NOT EXISTS "reorder_point" bigint NOT NULL DEFAULT 0 CHECK ("reorder_point" >= 0),
+	ADD COLUMN IF NOT EXISTS "reorder_quantity" bigint NOT NULL DEFAULT 0 CHECK ("reorder_quantity" >= 0);
+
+CREATE TABLE IF NOT EXISTS "low_stock_alerts" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL,
+	"available" bigint NOT NULL,
+	"reorder_point" bigint NOT NULL,
+	"reorder_quantity" bigint NOT NULL,
+	"status" varchar(16) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"resolved_at" timestamptz
+);
+
+-- An item has at most one open alert.
+CREATE UNIQUE INDEX IF NOT EXISTS "low_stock_alerts_open_item_id_idx" ON "low_stock_alerts" ("item_id") WHERE "status" = 'open';
+CREATE INDEX IF NOT EXISTS "low_stock_alerts_status_created_at_idx" ON "low_stock_alerts" ("status", "created_at");
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS "purchase_orders" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"vendor" varchar(255) NOT NULL,
+	"currency" varchar(10) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"closed_at" timestamptz,
+	"version" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "purchase_orders_vendor_idx" ON "purchase_orders" ("vendor");
+CREATE INDEX IF NOT EXISTS "purchase_orders_status_idx" ON "purchase_orders" ("status");
+
+CREATE TABLE IF NOT EXISTS "purchase_order_lines" (
+	"id" uuid PRIMARY KEY,
+	"purchase_order_id" uuid NOT NULL REFERENCES "purchase_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" uuid NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	"unit_cost" bigint NOT NULL CHECK ("unit_cost" >= 0),
+	"expected_date" date NOT NULL,
+	"received" bigint NOT NULL DEFAULT 0 CHECK ("received" >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS "purchase_order_lines_purchase_order_id_idx" ON "purchase_order_lines" ("purchase_order_id", "position");
+CREATE INDEX IF NOT EXISTS "purchase_order_lines_item_id_idx" ON "purchase_order_lines" ("item_id");
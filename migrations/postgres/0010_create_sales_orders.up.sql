@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS "sales_orders" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"customer" varchar(255) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"allocated_at" timestamptz,
+	"picked_at" timestamptz,
+	"shipped_at" timestamptz,
+	"delivered_at" timestamptz,
+	"version" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "sales_orders_customer_idx" ON "sales_orders" ("customer");
+CREATE INDEX IF NOT EXISTS "sales_orders_status_idx" ON "sales_orders" ("status");
+
+CREATE TABLE IF NOT EXISTS "sales_order_lines" (
+	"id" uuid PRIMARY KEY,
+	"sales_order_id" uuid NOT NULL REFERENCES "sales_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" uuid NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0)
+);
+
+CREATE INDEX IF NOT EXISTS "sales_order_lines_sales_order_id_idx" ON "sales_order_lines" ("sales_order_id", "position");
+CREATE INDEX IF NOT EXISTS "sales_order_lines_item_id_idx" ON "sales_order_lines" ("item_id");
+
+CREATE TABLE IF NOT EXISTS "sales_order_picks" (
+	"sales_order_id" uuid NOT NULL REFERENCES "sales_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"line_id" uuid NOT NULL,
+	"item_id" uuid NOT NULL,
+	"location_id" uuid,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	PRIMARY KEY ("sales_order_id", "position")
+);
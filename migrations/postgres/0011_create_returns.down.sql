@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "return_entries";
+DROP TABLE IF EXISTS "return_lines";
+DROP TABLE IF EXISTS "returns";
+ALTER TABLE "locations" DROP COLUMN IF EXISTS "quarantine";
@@ -0,0 +1,42 @@
+ALTER TABLE "locations" ADD COLUMN IF NOT EXISTS "quarantine" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "returns" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"sales_order_id" uuid NOT NULL,
+	"reason" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"received_at" timestamptz,
+	"closed_at" timestamptz,
+	"version" bigint NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "returns_sales_order_id_idx" ON "returns" ("sales_order_id");
+CREATE INDEX IF NOT EXISTS "returns_status_idx" ON "returns" ("status");
+
+CREATE TABLE IF NOT EXISTS "return_lines" (
+	"id" uuid PRIMARY KEY,
+	"return_id" uuid NOT NULL REFERENCES "returns" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" uuid NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	"received" bigint NOT NULL DEFAULT 0 CHECK ("received" >= 0),
+	"restocked" bigint NOT NULL DEFAULT 0 CHECK ("restocked" >= 0),
+	"scrapped" bigint NOT NULL DEFAULT 0 CHECK ("scrapped" >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS "return_lines_return_id_idx" ON "return_lines" ("return_id", "position");
+
+CREATE TABLE IF NOT EXISTS "return_entries" (
+	"return_id" uuid NOT NULL REFERENCES "returns" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"line_id" uuid NOT NULL,
+	"item_id" uuid NOT NULL,
+	"condition" varchar(32) NOT NULL DEFAULT '',
+	"disposition" varchar(32) NOT NULL,
+	"from_location_id" uuid,
+	"location_id" uuid,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	"created_at" timestamptz NOT NULL,
+	PRIMARY KEY ("return_id", "position")
+);
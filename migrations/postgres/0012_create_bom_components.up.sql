@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS "bom_components" (
+	"parent_id" uuid NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"component_id" uuid NOT NULL REFERENCES "inventories" ("id"),
+	"position" integer NOT NULL,
+	"quantity" bigint NOT NULL CHECK ("quantity" > 0),
+	PRIMARY KEY ("parent_id", "component_id"),
+	CHECK ("parent_id" <> "component_id")
+);
+
+CREATE INDEX IF NOT EXISTS "bom_components_component_id_idx" ON "bom_components" ("component_id");
@@ -0,0 +1,28 @@
+ALTER TABLE "inventories" ADD COLUMN IF NOT EXISTS "lot_tracked" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "lots" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"lot_number" varchar(64) NOT NULL,
+	"manufactured_at" date,
+	"expires_at" date,
+	"on_hand" bigint NOT NULL DEFAULT 0 CHECK ("on_hand" >= 0),
+	"quarantined" bigint NOT NULL DEFAULT 0 CHECK ("quarantined" >= 0 AND "quarantined" <= "on_hand"),
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	UNIQUE ("item_id", "lot_number")
+);
+
+CREATE INDEX IF NOT EXISTS "lots_expires_at_idx" ON "lots" ("expires_at") WHERE "on_hand" > 0;
+
+-- Movement lots outlive the lots they refer to, like the ledger itself.
+CREATE TABLE IF NOT EXISTS "stock_movement_lots" (
+	"movement_id" uuid NOT NULL REFERENCES "stock_movements" ("id"),
+	"lot_id" uuid NOT NULL,
+	"position" integer NOT NULL,
+	"delta" bigint NOT NULL CHECK ("delta" <> 0),
+	PRIMARY KEY ("movement_id", "lot_id")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movement_lots_lot_id_idx" ON "stock_movement_lots" ("lot_id");
+
+ALTER TABLE "return_entries" ADD COLUMN IF NOT EXISTS "lot_id" uuid;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "stock_movement_serials";
+DROP TABLE IF EXISTS "serials";
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "serialized";
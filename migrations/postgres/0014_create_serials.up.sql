@@ -0,0 +1,22 @@
+ALTER TABLE "inventories" ADD COLUMN IF NOT EXISTS "serialized" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "serials" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"item_id" uuid NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"serial_number" varchar(128) NOT NULL UNIQUE,
+	"status" varchar(16) NOT NULL,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"updated_at" timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "serials_item_id_status_idx" ON "serials" ("item_id", "status");
+
+-- Movement serials outlive the serials they refer to, like the ledger itself.
+CREATE TABLE IF NOT EXISTS "stock_movement_serials" (
+	"movement_id" uuid NOT NULL REFERENCES "stock_movements" ("id"),
+	"serial_number" varchar(128) NOT NULL,
+	"position" integer NOT NULL,
+	PRIMARY KEY ("movement_id", "serial_number")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movement_serials_serial_number_idx" ON "stock_movement_serials" ("serial_number");
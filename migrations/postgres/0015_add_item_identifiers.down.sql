@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "inventory_barcodes";
+DROP INDEX IF EXISTS "inventories_sku_key";
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "sku";
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS "categories" (
+	"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+	"name" varchar(255) NOT NULL,
+	"parent_id" uuid REFERENCES "categories" ("id"),
+	"position" integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "categories_parent_id_position_idx" ON "categories" ("parent_id", "position");
+
+ALTER TABLE "inventories" ADD COLUMN IF NOT EXISTS "category_id" uuid REFERENCES "categories" ("id");
+
+CREATE INDEX IF NOT EXISTS "inventories_category_id_idx" ON "inventories" ("category_id");
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "inventories" (
+	"id" text PRIMARY KEY,
+	"product_name" varchar(255),
+	"price" integer,
+	"currency" varchar(10),
+	"discount" integer,
+	"vendor" varchar(255)
+);
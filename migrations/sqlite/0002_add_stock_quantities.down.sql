@@ -0,0 +1,2 @@
+ALTER TABLE "inventories" DROP COLUMN "reserved";
+ALTER TABLE "inventories" DROP COLUMN "on_hand";
@@ -0,0 +1,2 @@
+ALTER TABLE "inventories" ADD COLUMN "on_hand" integer NOT NULL DEFAULT 0 CHECK ("on_hand" >= 0);
+ALTER TABLE "inventories" ADD COLUMN "reserved" integer NOT NULL DEFAULT 0 CHECK ("reserved" >= 0);
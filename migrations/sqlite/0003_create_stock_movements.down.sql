@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "stock_movements_no_delete";
+DROP TRIGGER IF EXISTS "stock_movements_no_update";
+DROP TABLE IF EXISTS "stock_movements";
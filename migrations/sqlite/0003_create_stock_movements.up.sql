@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS "stock_movements" (
+	"id" text PRIMARY KEY,
+	"item_id" text NOT NULL,
+	"delta" integer NOT NULL CHECK ("delta" <> 0),
+	"reason" varchar(32) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"user_name" varchar(255) NOT NULL DEFAULT '',
+	"created_at" datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movements_item_id_created_at_idx" ON "stock_movements" ("item_id", "created_at", "id");
+
+CREATE TRIGGER IF NOT EXISTS "stock_movements_no_update" BEFORE UPDATE ON "stock_movements"
+BEGIN
+	SELECT RAISE(ABORT, 'stock_movements is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS "stock_movements_no_delete" BEFORE DELETE ON "stock_movements"
+BEGIN
+	SELECT RAISE(ABORT, 'stock_movements is append-only');
+END;
+
+-- Record existing stock as an opening balance so the ledger replays to it.
+INSERT INTO "stock_movements" ("id", "item_id", "delta", "reason", "reference", "created_at")
+	SELECT lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-a' ||
+			substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+		"id", "on_hand", 'adjustment', 'opening balance', CURRENT_TIMESTAMP
+	FROM "inventories" WHERE "on_hand" <> 0;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "locations" (
+	"id" text PRIMARY KEY,
+	"name" varchar(255) NOT NULL,
+	"type" varchar(32) NOT NULL,
+	"parent_id" text REFERENCES "locations" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS "locations_parent_id_idx" ON "locations" ("parent_id");
+
+CREATE TABLE IF NOT EXISTS "stock_levels" (
+	"item_id" text NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"location_id" text NOT NULL REFERENCES "locations" ("id"),
+	"on_hand" integer NOT NULL DEFAULT 0 CHECK ("on_hand" >= 0),
+	PRIMARY KEY ("item_id", "location_id")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_levels_location_id_idx" ON "stock_levels" ("location_id");
+
+ALTER TABLE "stock_movements" ADD COLUMN "location_id" text;
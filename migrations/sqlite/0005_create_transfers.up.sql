@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "transfers" (
+	"id" text PRIMARY KEY,
+	"item_id" text NOT NULL,
+	"from_location_id" text NOT NULL,
+	"to_location_id" text NOT NULL,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0),
+	"received" integer NOT NULL DEFAULT 0 CHECK ("received" >= 0 AND "received" <= "quantity"),
+	"status" varchar(16) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"user_name" varchar(255) NOT NULL DEFAULT '',
+	"created_at" datetime NOT NULL,
+	"shipped_at" datetime,
+	"received_at" datetime
+);
+
+CREATE INDEX IF NOT EXISTS "transfers_item_id_idx" ON "transfers" ("item_id");
+CREATE INDEX IF NOT EXISTS "transfers_status_idx" ON "transfers" ("status");
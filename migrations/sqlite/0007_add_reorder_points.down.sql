@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "low_stock_alerts";
+ALTER TABLE "inventories" DROP COLUMN "reorder_quantity";
+ALTER TABLE "inventories" DROP COLUMN "reorder_point";
@@ -0,0 +1,17 @@
+ALTER TABLE "inventories" ADD COLUMN "reorder_point" integer NOT NULL DEFAULT 0 CHECK ("reorder_point" >= 0);
+ALTER TABLE "inventories" ADD COLUMN "reorder_quantity" integer NOT NULL DEFAULT 0 CHECK ("reorder_quantity" >= 0);
+
+CREATE TABLE IF NOT EXISTS "low_stock_alerts" (
+	"id" text PRIMARY KEY,
+	"item_id" text NOT NULL,
+	"available" integer NOT NULL,
+	"reorder_point" integer NOT NULL,
+	"reorder_quantity" integer NOT NULL,
+	"status" varchar(16) NOT NULL,
+	"created_at" datetime NOT NULL,
+	"resolved_at" datetime
+);
+
+-- An item has at most one open alert.
+CREATE UNIQUE INDEX IF NOT EXISTS "low_stock_alerts_open_item_id_idx" ON "low_stock_alerts" ("item_id") WHERE "status" = 'open';
+CREATE INDEX IF NOT EXISTS "low_stock_alerts_status_created_at_idx" ON "low_stock_alerts" ("status", "created_at");
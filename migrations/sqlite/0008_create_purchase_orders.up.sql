@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS "purchase_orders" (
+	"id" text PRIMARY KEY,
+	"vendor" varchar(255) NOT NULL,
+	"currency" varchar(10) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" datetime NOT NULL,
+	"closed_at" datetime,
+	"version" integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "purchase_orders_vendor_idx" ON "purchase_orders" ("vendor");
+CREATE INDEX IF NOT EXISTS "purchase_orders_status_idx" ON "purchase_orders" ("status");
+
+CREATE TABLE IF NOT EXISTS "purchase_order_lines" (
+	"id" text PRIMARY KEY,
+	"purchase_order_id" text NOT NULL REFERENCES "purchase_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" text NOT NULL,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0),
+	"unit_cost" integer NOT NULL CHECK ("unit_cost" >= 0),
+	"expected_date" datetime NOT NULL,
+	"received" integer NOT NULL DEFAULT 0 CHECK ("received" >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS "purchase_order_lines_purchase_order_id_idx" ON "purchase_order_lines" ("purchase_order_id", "position");
+CREATE INDEX IF NOT EXISTS "purchase_order_lines_item_id_idx" ON "purchase_order_lines" ("item_id");
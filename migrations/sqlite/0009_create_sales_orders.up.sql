@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS "sales_orders" (
+	"id" text PRIMARY KEY,
+	"customer" varchar(255) NOT NULL,
+	"reference" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" datetime NOT NULL,
+	"allocated_at" datetime,
+	"picked_at" datetime,
+	"shipped_at" datetime,
+	"delivered_at" datetime,
+	"version" integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "sales_orders_customer_idx" ON "sales_orders" ("customer");
+CREATE INDEX IF NOT EXISTS "sales_orders_status_idx" ON "sales_orders" ("status");
+
+CREATE TABLE IF NOT EXISTS "sales_order_lines" (
+	"id" text PRIMARY KEY,
+	"sales_order_id" text NOT NULL REFERENCES "sales_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" text NOT NULL,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0)
+);
+
+CREATE INDEX IF NOT EXISTS "sales_order_lines_sales_order_id_idx" ON "sales_order_lines" ("sales_order_id", "position");
+CREATE INDEX IF NOT EXISTS "sales_order_lines_item_id_idx" ON "sales_order_lines" ("item_id");
+
+CREATE TABLE IF NOT EXISTS "sales_order_picks" (
+	"sales_order_id" text NOT NULL REFERENCES "sales_orders" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"line_id" text NOT NULL,
+	"item_id" text NOT NULL,
+	"location_id" text,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0),
+	PRIMARY KEY ("sales_order_id", "position")
+);
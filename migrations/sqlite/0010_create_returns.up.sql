@@ -0,0 +1,42 @@
+ALTER TABLE "locations" ADD COLUMN "quarantine" boolean NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "returns" (
+	"id" text PRIMARY KEY,
+	"sales_order_id" text NOT NULL,
+	"reason" varchar(255) NOT NULL DEFAULT '',
+	"status" varchar(32) NOT NULL,
+	"created_at" datetime NOT NULL,
+	"received_at" datetime,
+	"closed_at" datetime,
+	"version" integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS "returns_sales_order_id_idx" ON "returns" ("sales_order_id");
+CREATE INDEX IF NOT EXISTS "returns_status_idx" ON "returns" ("status");
+
+CREATE TABLE IF NOT EXISTS "return_lines" (
+	"id" text PRIMARY KEY,
+	"return_id" text NOT NULL REFERENCES "returns" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"item_id" text NOT NULL,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0),
+	"received" integer NOT NULL DEFAULT 0 CHECK ("received" >= 0),
+	"restocked" integer NOT NULL DEFAULT 0 CHECK ("restocked" >= 0),
+	"scrapped" integer NOT NULL DEFAULT 0 CHECK ("scrapped" >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS "return_lines_return_id_idx" ON "return_lines" ("return_id", "position");
+
+CREATE TABLE IF NOT EXISTS "return_entries" (
+	"return_id" text NOT NULL REFERENCES "returns" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL,
+	"line_id" text NOT NULL,
+	"item_id" text NOT NULL,
+	"condition" varchar(32) NOT NULL DEFAULT '',
+	"disposition" varchar(32) NOT NULL,
+	"from_location_id" text,
+	"location_id" text,
+	"quantity" integer NOT NULL CHECK ("quantity" > 0),
+	"created_at" datetime NOT NULL,
+	PRIMARY KEY ("return_id", "position")
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "bom_components";
@@ -0,0 +1,4 @@
+ALTER TABLE "return_entries" DROP COLUMN "lot_id";
+DROP TABLE IF EXISTS "stock_movement_lots";
+DROP TABLE IF EXISTS "lots";
+ALTER TABLE "inventories" DROP COLUMN "lot_tracked";
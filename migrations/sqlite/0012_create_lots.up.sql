@@ -0,0 +1,28 @@
+ALTER TABLE "inventories" ADD COLUMN "lot_tracked" boolean NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "lots" (
+	"id" text PRIMARY KEY,
+	"item_id" text NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"lot_number" varchar(64) NOT NULL,
+	"manufactured_at" datetime,
+	"expires_at" datetime,
+	"on_hand" integer NOT NULL DEFAULT 0 CHECK ("on_hand" >= 0),
+	"quarantined" integer NOT NULL DEFAULT 0 CHECK ("quarantined" >= 0 AND "quarantined" <= "on_hand"),
+	"created_at" datetime NOT NULL,
+	UNIQUE ("item_id", "lot_number")
+);
+
+CREATE INDEX IF NOT EXISTS "lots_expires_at_idx" ON "lots" ("expires_at") WHERE "on_hand" > 0;
+
+-- Movement lots outlive the lots they refer to, like the ledger itself.
+CREATE TABLE IF NOT EXISTS "stock_movement_lots" (
+	"movement_id" text NOT NULL REFERENCES "stock_movements" ("id"),
+	"lot_id" text NOT NULL,
+	"position" integer NOT NULL,
+	"delta" integer NOT NULL CHECK ("delta" <> 0),
+	PRIMARY KEY ("movement_id", "lot_id")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movement_lots_lot_id_idx" ON "stock_movement_lots" ("lot_id");
+
+ALTER TABLE "return_entries" ADD COLUMN "lot_id" text;
@@ -0,0 +1,22 @@
+ALTER TABLE "inventories" ADD COLUMN "serialized" boolean NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "serials" (
+	"id" text PRIMARY KEY,
+	"item_id" text NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"serial_number" varchar(128) NOT NULL UNIQUE,
+	"status" varchar(16) NOT NULL,
+	"created_at" datetime NOT NULL,
+	"updated_at" datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "serials_item_id_status_idx" ON "serials" ("item_id", "status");
+
+-- Movement serials outlive the serials they refer to, like the ledger itself.
+CREATE TABLE IF NOT EXISTS "stock_movement_serials" (
+	"movement_id" text NOT NULL REFERENCES "stock_movements" ("id"),
+	"serial_number" varchar(128) NOT NULL,
+	"position" integer NOT NULL,
+	PRIMARY KEY ("movement_id", "serial_number")
+);
+
+CREATE INDEX IF NOT EXISTS "stock_movement_serials_serial_number_idx" ON "stock_movement_serials" ("serial_number");
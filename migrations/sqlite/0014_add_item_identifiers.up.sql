@@ -0,0 +1,13 @@
+ALTER TABLE "inventories" ADD COLUMN "sku" varchar(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "inventories_sku_key" ON "inventories" ("sku");
+
+-- Barcodes are kept as 14-digit GTINs, so the EAN-13, UPC-A and GTIN-14
+-- forms of one code collide.
+CREATE TABLE IF NOT EXISTS "inventory_barcodes" (
+	"gtin" char(14) PRIMARY KEY,
+	"item_id" text NOT NULL REFERENCES "inventories" ("id") ON DELETE CASCADE,
+	"position" integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "inventory_barcodes_item_id_idx" ON "inventory_barcodes" ("item_id");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "inventories_category_id_idx";
+ALTER TABLE "inventories" DROP COLUMN "category_id";
+DROP TABLE IF EXISTS "categories";
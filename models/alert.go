@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	AlertOpen     = "open"
+	AlertResolved = "resolved"
+)
+
+// LowStockAlert records that an item's available quantity dropped below its
+// reorder point. It stays open until the item is restocked above the point,
+// and an item has at most one open alert at a time.
+type LowStockAlert struct {
+	ID              string     `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID          string     `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Available       int        `gorm:"column:available" bson:"available" json:"available"`
+	ReorderPoint    int        `gorm:"column:reorder_point" bson:"reorder_point" json:"reorder_point"`
+	ReorderQuantity int        `gorm:"column:reorder_quantity" bson:"reorder_quantity" json:"reorder_quantity"`
+	Status          string     `gorm:"size:16;column:status" bson:"status" json:"status"`
+	CreatedAt       time.Time  `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	ResolvedAt      *time.Time `gorm:"column:resolved_at" bson:"resolved_at" json:"resolved_at"`
+}
+
+// AlertQuery selects a page of alerts, newest first. Empty fields match
+// every alert.
+type AlertQuery struct {
+	ItemID string
+	Status string
+	Limit  int
+	Offset int
+}
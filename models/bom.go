@@ -0,0 +1,16 @@
+package models
+
+// BOMComponent is one line of a kit's bill of materials: one unit of the
+// kit ParentID is assembled from Quantity units of the item ComponentID.
+type BOMComponent struct {
+	ParentID    string `gorm:"column:parent_id" bson:"parent_id" json:"parent_id"`
+	ComponentID string `gorm:"column:component_id" bson:"component_id" json:"component_id"`
+	Quantity    int    `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+}
+
+// BOMQuery selects the bill of materials lines of the given kits that use
+// the given components. An empty list matches everything.
+type BOMQuery struct {
+	ParentIDs    []string
+	ComponentIDs []string
+}
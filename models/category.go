@@ -0,0 +1,11 @@
+package models
+
+// Category groups items for browsing, such as Headphones inside Audio inside
+// Electronics. Top-level categories have no parent. Position orders a
+// category among its siblings, lowest first.
+type Category struct {
+	ID       string  `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	Name     string  `gorm:"size:255;column:name" bson:"name" json:"name"`
+	ParentID *string `gorm:"column:parent_id" bson:"parent_id" json:"parent_id"`
+	Position int     `gorm:"column:position" bson:"position" json:"position"`
+}
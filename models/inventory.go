@@ -13,6 +13,36 @@ type Inventory struct {
 	Currency string `gorm:"size:10;column:currency" bson:"currency" json:"currency"`
 	Discount int    `gorm:"column:discount" bson:"discount" json:"discount"`
 	Vendor   string `gorm:"size:255;column:vendor" bson:"vendor" json:"vendor"`
+	OnHand   int    `gorm:"column:on_hand" bson:"on_hand" json:"on_hand"`
+	Reserved int    `gorm:"column:reserved" bson:"reserved" json:"reserved"`
+	// ReorderPoint is the available quantity below which the item needs
+	// reordering, ReorderQuantity units at a time. Zero disables alerts.
+	ReorderPoint    int `gorm:"column:reorder_point" bson:"reorder_point" json:"reorder_point"`
+	ReorderQuantity int `gorm:"column:reorder_quantity" bson:"reorder_quantity" json:"reorder_quantity"`
+	// LotTracked items take stock in by lot and give it out first expired,
+	// first out.
+	LotTracked bool `gorm:"column:lot_tracked" bson:"lot_tracked" json:"lot_tracked"`
+	// Serialized items track every unit by its serial number.
+	Serialized bool `gorm:"column:serialized" bson:"serialized" json:"serialized"`
+	// SKU is the human-assigned stock keeping unit, unique among items.
+	// Items without one leave it empty.
+	SKU string `gorm:"column:sku" bson:"sku,omitempty" json:"sku"`
+	// Barcodes are the item's GTINs in their 14-digit form, each unique
+	// among items.
+	Barcodes []string `gorm:"-" bson:"barcodes,omitempty" json:"barcodes"`
+	// CategoryID is the category the item is listed under, if any.
+	CategoryID *string `gorm:"column:category_id" bson:"category_id,omitempty" json:"category_id"`
+}
+
+// Available is the quantity on hand that is not reserved.
+func (i *Inventory) Available() int {
+	return i.OnHand - i.Reserved
+}
+
+// BelowReorderPoint reports whether the item has a reorder point and its
+// available quantity has dropped below it.
+func (i *Inventory) BelowReorderPoint() bool {
+	return i.ReorderPoint > 0 && i.Available() < i.ReorderPoint
 }
 
 func (i *Inventory) SetMongoDB() {
@@ -26,3 +56,74 @@ func (i *Inventory) GenerateUUID() {
 		i.ID = uuid.New().String()
 	}
 }
+
+// InventoryPatch lists the fields a partial update changes; nil fields are
+// left as they are.
+type InventoryPatch struct {
+	Name     *string
+	Price    *int
+	Currency *string
+	Discount *int
+	Vendor   *string
+
+	ReorderPoint    *int
+	ReorderQuantity *int
+
+	LotTracked *bool
+	Serialized *bool
+
+	SKU      *string
+	Barcodes *[]string
+
+	// CategoryID moves the item to a category, or out of its category when
+	// it points to an empty string.
+	CategoryID *string
+}
+
+func (p InventoryPatch) IsEmpty() bool {
+	return p == InventoryPatch{}
+}
+
+// Apply copies the set fields of p onto item.
+func (p InventoryPatch) Apply(item *Inventory) {
+	if p.Name != nil {
+		item.Name = *p.Name
+	}
+	if p.Price != nil {
+		item.Price = *p.Price
+	}
+	if p.Currency != nil {
+		item.Currency = *p.Currency
+	}
+	if p.Discount != nil {
+		item.Discount = *p.Discount
+	}
+	if p.Vendor != nil {
+		item.Vendor = *p.Vendor
+	}
+	if p.ReorderPoint != nil {
+		item.ReorderPoint = *p.ReorderPoint
+	}
+	if p.ReorderQuantity != nil {
+		item.ReorderQuantity = *p.ReorderQuantity
+	}
+	if p.LotTracked != nil {
+		item.LotTracked = *p.LotTracked
+	}
+	if p.Serialized != nil {
+		item.Serialized = *p.Serialized
+	}
+	if p.SKU != nil {
+		item.SKU = *p.SKU
+	}
+	if p.Barcodes != nil {
+		item.Barcodes = *p.Barcodes
+	}
+	if p.CategoryID != nil {
+		item.CategoryID = nil
+		if *p.CategoryID != "" {
+			categoryID := *p.CategoryID
+			item.CategoryID = &categoryID
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+const (
+	LocationWarehouse = "warehouse"
+	LocationZone      = "zone"
+	LocationBin       = "bin"
+)
+
+// LocationParentTypes maps each location type to the type its parent must
+// have. Warehouses are the roots of the hierarchy and have no parent.
+var LocationParentTypes = map[string]string{
+	LocationWarehouse: "",
+	LocationZone:      LocationWarehouse,
+	LocationBin:       LocationZone,
+}
+
+// Location is a warehouse, a zone within a warehouse or a bin within a zone.
+// Stock in a quarantine location is held back from sale.
+type Location struct {
+	ID         string  `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	Name       string  `gorm:"size:255;column:name" bson:"name" json:"name"`
+	Type       string  `gorm:"size:32;column:type" bson:"type" json:"type"`
+	ParentID   *string `gorm:"column:parent_id" bson:"parent_id" json:"parent_id"`
+	Quarantine bool    `gorm:"column:quarantine" bson:"quarantine" json:"quarantine"`
+}
+
+// StockLevel is the on-hand quantity of an item at one location. The levels
+// of an item add up to at most its on-hand quantity; the rest has not been
+// put away at any location.
+type StockLevel struct {
+	ItemID     string `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	LocationID string `gorm:"column:location_id" bson:"location_id" json:"location_id"`
+	OnHand     int    `gorm:"column:on_hand" bson:"on_hand" json:"on_hand"`
+}
+
+// StockLevelQuery selects the non-zero stock levels of the given items at
+// the given locations. An empty list matches everything.
+type StockLevelQuery struct {
+	ItemIDs     []string
+	LocationIDs []string
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Lot is a batch of a lot-tracked item. The on-hand quantities of an item's
+// lots add up to at most its on-hand quantity; the rest was taken in before
+// the item was lot-tracked.
+type Lot struct {
+	ID        string `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID    string `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	LotNumber string `gorm:"size:64;column:lot_number" bson:"lot_number" json:"lot_number"`
+	// ManufacturedAt and ExpiresAt are calendar dates; a lot can be used up
+	// to and including its expiry date.
+	ManufacturedAt *time.Time `gorm:"column:manufactured_at" bson:"manufactured_at" json:"manufactured_at"`
+	ExpiresAt      *time.Time `gorm:"column:expires_at" bson:"expires_at" json:"expires_at"`
+	OnHand         int        `gorm:"column:on_hand" bson:"on_hand" json:"on_hand"`
+	// Quarantined of the on-hand units came back in a return and are held
+	// in quarantine. Only their return releases them.
+	Quarantined int       `gorm:"column:quarantined" bson:"quarantined" json:"quarantined"`
+	CreatedAt   time.Time `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+}
+
+// Available is the quantity on hand that is not held in quarantine.
+func (l *Lot) Available() int {
+	return l.OnHand - l.Quarantined
+}
+
+// ExpiredOn reports whether the lot is past its expiry date on the calendar
+// day of t.
+func (l *Lot) ExpiredOn(t time.Time) bool {
+	return l.ExpiresAt != nil && l.ExpiresAt.Before(t.UTC().Truncate(24*time.Hour))
+}
+
+// LotQuery selects lots, first expiring first and lots without an expiry
+// date last. Zero fields match everything.
+type LotQuery struct {
+	ItemIDs   []string
+	LotNumber string
+	// ExpiresBefore keeps only lots with an expiry date before it.
+	ExpiresBefore *time.Time
+	// InStock keeps only lots with stock on hand.
+	InStock bool
+	// Limit zero returns every matching lot.
+	Limit  int
+	Offset int
+}
+
+// MovementLot is the part of a stock movement that changed one lot.
+type MovementLot struct {
+	LotID string `gorm:"column:lot_id" bson:"lot_id" json:"lot_id"`
+	Delta int    `gorm:"column:delta" bson:"delta" json:"delta"`
+}
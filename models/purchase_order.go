@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	PurchaseOrderOpen              = "open"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderClosed            = "closed"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrder orders stock from a vendor. Receiving against it adds stock
+// through the ledger; it closes once every line has been received in full,
+// or when closed by hand after an under-receipt.
+type PurchaseOrder struct {
+	ID        string              `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	Vendor    string              `gorm:"size:255;column:vendor" bson:"vendor" json:"vendor"`
+	Currency  string              `gorm:"size:10;column:currency" bson:"currency" json:"currency"`
+	Reference string              `gorm:"size:255;column:reference" bson:"reference" json:"reference"`
+	Status    string              `gorm:"size:32;column:status" bson:"status" json:"status"`
+	Lines     []PurchaseOrderLine `gorm:"-" bson:"lines" json:"lines"`
+	CreatedAt time.Time           `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	ClosedAt  *time.Time          `gorm:"column:closed_at" bson:"closed_at" json:"closed_at"`
+	// Version increases with every change, so concurrent updates can be
+	// detected.
+	Version int `gorm:"column:version" bson:"version" json:"version"`
+}
+
+// PurchaseOrderLine orders Quantity units of an item at UnitCost each, in
+// the currency of the order. Received may end up above Quantity.
+type PurchaseOrderLine struct {
+	ID           string    `gorm:"column:id" bson:"id" json:"id"`
+	ItemID       string    `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Quantity     int       `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+	UnitCost     int       `gorm:"column:unit_cost" bson:"unit_cost" json:"unit_cost"`
+	ExpectedDate time.Time `gorm:"column:expected_date" bson:"expected_date" json:"expected_date"`
+	Received     int       `gorm:"column:received" bson:"received" json:"received"`
+}
+
+// FullyReceived reports whether every line has received at least the
+// quantity ordered.
+func (o *PurchaseOrder) FullyReceived() bool {
+	for _, line := range o.Lines {
+		if line.Received < line.Quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// PurchaseOrderQuery selects a page of purchase orders, newest first. Empty
+// fields match every order.
+type PurchaseOrderQuery struct {
+	Vendor string
+	Status string
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ItemQuery selects a page of inventory items. Cursor, when set, is the
+// NextCursor of a previous page and continues after its last item; it cannot
+// be combined with Offset.
+type ItemQuery struct {
+	Limit  int
+	Offset int
+	Cursor string
+	Filter ItemFilter
+	Sort   []SortField
+}
+
+// ItemFilter narrows an item listing. Zero values and nil pointers leave the
+// corresponding condition out.
+type ItemFilter struct {
+	Vendor       string
+	Currency     string
+	PriceMin     *int
+	PriceMax     *int
+	DiscountGT   *int
+	NameContains string
+	// BelowReorderPoint keeps only the items Inventory.BelowReorderPoint
+	// reports.
+	BelowReorderPoint bool
+	// CategoryIDs keeps only the items in one of the categories. The
+	// manager widens it to every category beneath them.
+	CategoryIDs []string
+}
+
+// SortField orders items by one field; Field is the JSON, BSON and column
+// name of the field.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// SortableItemFields lists the fields items can be sorted by, mapped to
+// whether the field is numeric.
+var SortableItemFields = map[string]bool{
+	"product_name": false,
+	"price":        true,
+	"currency":     false,
+	"discount":     true,
+	"vendor":       false,
+}
+
+// ParseSort parses a comma separated sort expression such as
+// "price,-product_name", where a leading "-" sorts descending.
+func ParseSort(expr string) ([]SortField, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	var fields []SortField
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		field := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+
+		if _, ok := SortableItemFields[field.Field]; !ok {
+			return nil, fmt.Errorf("cannot sort by %q", field.Field)
+		}
+		if seen[field.Field] {
+			return nil, fmt.Errorf("duplicate sort field %q", field.Field)
+		}
+		seen[field.Field] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// SortString formats sort fields back into the ParseSort syntax.
+func SortString(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field
+		if field.Desc {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+type ItemPage struct {
+	Items      []*Inventory
+	Limit      int
+	TotalCount int64
+	NextCursor string
+}
+
+// SearchResult is an item matched by a product search, with its relevance
+// score; higher scores rank first.
+type SearchResult struct {
+	Item  *Inventory
+	Score float64
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	ReservationActive    = "active"
+	ReservationConfirmed = "confirmed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// Reservation holds Quantity units of an item for a pending order. Active
+// reservations expire at ExpiresAt unless confirmed first; confirmed ones
+// hold their stock until released.
+type Reservation struct {
+	ID        string    `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID    string    `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Quantity  int       `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+	Status    string    `gorm:"size:16;column:status" bson:"status" json:"status"`
+	Reference string    `gorm:"size:255;column:reference" bson:"reference" json:"reference"`
+	CreatedAt time.Time `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	ExpiresAt time.Time `gorm:"column:expires_at" bson:"expires_at" json:"expires_at"`
+}
+
+// ReservationQuery selects a page of reservations, oldest first. Empty
+// fields match every reservation; ExpiresBefore, when set, matches those
+// expiring at or before it.
+type ReservationQuery struct {
+	ItemID        string
+	Status        string
+	ExpiresBefore *time.Time
+	Limit         int
+	Offset        int
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+const (
+	ReturnAuthorized = "authorized"
+	ReturnReceived   = "received"
+	ReturnClosed     = "closed"
+	ReturnCancelled  = "cancelled"
+)
+
+// Condition codes of returned units.
+const (
+	ConditionNew       = "new"
+	ConditionOpened    = "opened"
+	ConditionDamaged   = "damaged"
+	ConditionDefective = "defective"
+)
+
+const (
+	DispositionRestock    = "restock"
+	DispositionQuarantine = "quarantine"
+	DispositionScrap      = "scrap"
+)
+
+// ConditionDispositions maps each condition code to where units in that
+// condition go when they are received: back to sellable stock or into
+// quarantine.
+var ConditionDispositions = map[string]string{
+	ConditionNew:       DispositionRestock,
+	ConditionOpened:    DispositionRestock,
+	ConditionDamaged:   DispositionQuarantine,
+	ConditionDefective: DispositionQuarantine,
+}
+
+// Return authorizes a customer to send back stock shipped by a sales order.
+// It is received once the units arrive and closed once none of them is left
+// in quarantine.
+type Return struct {
+	ID           string        `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	SalesOrderID string        `gorm:"column:sales_order_id" bson:"sales_order_id" json:"sales_order_id"`
+	Reason       string        `gorm:"size:255;column:reason" bson:"reason" json:"reason"`
+	Status       string        `gorm:"size:32;column:status" bson:"status" json:"status"`
+	Lines        []ReturnLine  `gorm:"-" bson:"lines" json:"lines"`
+	Entries      []ReturnEntry `gorm:"-" bson:"entries" json:"entries"`
+	CreatedAt    time.Time     `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	ReceivedAt   *time.Time    `gorm:"column:received_at" bson:"received_at" json:"received_at"`
+	ClosedAt     *time.Time    `gorm:"column:closed_at" bson:"closed_at" json:"closed_at"`
+	// Version increases with every change, so concurrent updates can be
+	// detected.
+	Version int `gorm:"column:version" bson:"version" json:"version"`
+}
+
+// ReturnLine authorizes the return of Quantity units of an item. Of the
+// units received, those neither restocked nor scrapped are in quarantine.
+type ReturnLine struct {
+	ID        string `gorm:"column:id" bson:"id" json:"id"`
+	ItemID    string `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Quantity  int    `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+	Received  int    `gorm:"column:received" bson:"received" json:"received"`
+	Restocked int    `gorm:"column:restocked" bson:"restocked" json:"restocked"`
+	Scrapped  int    `gorm:"column:scrapped" bson:"scrapped" json:"scrapped"`
+}
+
+func (l ReturnLine) Quarantined() int {
+	return l.Received - l.Restocked - l.Scrapped
+}
+
+// ReturnEntry records what happened to some units of a return line. A
+// receipt has the condition the units arrived in and no FromLocationID; a
+// unit leaving quarantine comes from FromLocationID. LocationID is where the
+// units went, nil for stock not kept at any location or scrapped stock.
+type ReturnEntry struct {
+	LineID         string  `gorm:"column:line_id" bson:"line_id" json:"line_id"`
+	ItemID         string  `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Condition      string  `gorm:"column:condition" bson:"condition" json:"condition"`
+	Disposition    string  `gorm:"column:disposition" bson:"disposition" json:"disposition"`
+	FromLocationID *string `gorm:"column:from_location_id" bson:"from_location_id" json:"from_location_id"`
+	LocationID     *string `gorm:"column:location_id" bson:"location_id" json:"location_id"`
+	// LotID is the lot of a lot-tracked item the units belong to.
+	LotID     *string   `gorm:"column:lot_id" bson:"lot_id,omitempty" json:"lot_id,omitempty"`
+	Quantity  int       `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+	CreatedAt time.Time `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+}
+
+// ReturnQuery selects a page of returns, newest first. Empty fields match
+// every return.
+type ReturnQuery struct {
+	SalesOrderID string
+	Status       string
+	Limit        int
+	Offset       int
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+const (
+	SalesOrderPending   = "pending"
+	SalesOrderAllocated = "allocated"
+	SalesOrderPicked    = "picked"
+	SalesOrderShipped   = "shipped"
+	SalesOrderDelivered = "delivered"
+	SalesOrderCancelled = "cancelled"
+)
+
+// SalesOrder sells stock to a customer. It moves from pending through
+// allocated, when its stock is reserved, and picked, when the pick list is
+// confirmed, to shipped, when the stock leaves, and finally delivered.
+type SalesOrder struct {
+	ID          string           `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	Customer    string           `gorm:"size:255;column:customer" bson:"customer" json:"customer"`
+	Reference   string           `gorm:"size:255;column:reference" bson:"reference" json:"reference"`
+	Status      string           `gorm:"size:32;column:status" bson:"status" json:"status"`
+	Lines       []SalesOrderLine `gorm:"-" bson:"lines" json:"lines"`
+	Picks       []SalesOrderPick `gorm:"-" bson:"picks" json:"picks"`
+	CreatedAt   time.Time        `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	AllocatedAt *time.Time       `gorm:"column:allocated_at" bson:"allocated_at" json:"allocated_at"`
+	PickedAt    *time.Time       `gorm:"column:picked_at" bson:"picked_at" json:"picked_at"`
+	ShippedAt   *time.Time       `gorm:"column:shipped_at" bson:"shipped_at" json:"shipped_at"`
+	DeliveredAt *time.Time       `gorm:"column:delivered_at" bson:"delivered_at" json:"delivered_at"`
+	// Version increases with every change, so concurrent updates can be
+	// detected.
+	Version int `gorm:"column:version" bson:"version" json:"version"`
+}
+
+type SalesOrderLine struct {
+	ID       string `gorm:"column:id" bson:"id" json:"id"`
+	ItemID   string `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Quantity int    `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+}
+
+// SalesOrderPick takes Quantity units of a line's item from a location, or
+// from the stock not kept at any location when LocationID is nil.
+type SalesOrderPick struct {
+	LineID     string  `gorm:"column:line_id" bson:"line_id" json:"line_id"`
+	ItemID     string  `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	LocationID *string `gorm:"column:location_id" bson:"location_id" json:"location_id"`
+	Quantity   int     `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+}
+
+// SalesOrderQuery selects a page of sales orders, newest first. Empty fields
+// match every order.
+type SalesOrderQuery struct {
+	Customer string
+	Status   string
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+const (
+	SerialInStock  = "in_stock"
+	SerialReserved = "reserved"
+	SerialShipped  = "shipped"
+	SerialReturned = "returned"
+	SerialScrapped = "scrapped"
+)
+
+// Serial is one individually tracked unit of a serialized item. Serial
+// numbers are unique across all items.
+type Serial struct {
+	ID           string    `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID       string    `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	SerialNumber string    `gorm:"size:128;column:serial_number" bson:"serial_number" json:"serial_number"`
+	Status       string    `gorm:"size:16;column:status" bson:"status" json:"status"`
+	CreatedAt    time.Time `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" bson:"updated_at" json:"updated_at"`
+}
+
+// OnHand reports whether the unit is part of its item's on-hand stock.
+func (s *Serial) OnHand() bool {
+	return s.Status == SerialInStock || s.Status == SerialReserved || s.Status == SerialReturned
+}
+
+// SerialQuery selects a page of serials by serial number. Empty fields
+// match every serial; a zero Limit returns every match.
+type SerialQuery struct {
+	ItemID        string
+	SerialNumbers []string
+	Status        string
+	Limit         int
+	Offset        int
+}
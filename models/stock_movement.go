@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+const (
+	MovementReceipt    = "receipt"
+	MovementShipment   = "shipment"
+	MovementAdjustment = "adjustment"
+	MovementTransfer   = "transfer"
+	MovementReturn     = "return"
+	// MovementAssembly consumes a kit's components and produces the kit;
+	// MovementDisassembly does the reverse.
+	MovementAssembly    = "assembly"
+	MovementDisassembly = "disassembly"
+)
+
+// MovementReasons lists the valid StockMovement reasons.
+var MovementReasons = []string{MovementReceipt, MovementShipment, MovementAdjustment, MovementTransfer, MovementReturn,
+	MovementAssembly, MovementDisassembly}
+
+// StockMovement is one immutable entry of the stock ledger. Replaying an
+// item's movements in order yields its on-hand quantity.
+type StockMovement struct {
+	ID        string `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID    string `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	Delta     int    `gorm:"column:delta" bson:"delta" json:"delta"`
+	Reason    string `gorm:"size:32;column:reason" bson:"reason" json:"reason"`
+	Reference string `gorm:"size:255;column:reference" bson:"reference" json:"reference"`
+	User      string `gorm:"size:255;column:user_name" bson:"user" json:"user"`
+	// LocationID is the location whose stock the movement changed, if any.
+	LocationID *string   `gorm:"column:location_id" bson:"location_id,omitempty" json:"location_id,omitempty"`
+	CreatedAt  time.Time `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	// Lots breaks the delta of a lot-tracked item down by lot. Stock taken
+	// in before the item was lot-tracked belongs to no lot.
+	Lots []MovementLot `gorm:"-" bson:"lots,omitempty" json:"lots,omitempty"`
+	// SerialNumbers lists the units of a serialized item the movement took
+	// in or out, one per unit of the delta.
+	SerialNumbers []string `gorm:"-" bson:"serial_numbers,omitempty" json:"serial_numbers,omitempty"`
+}
+
+// MovementQuery selects a page of an item's movements, oldest first.
+type MovementQuery struct {
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	TransferDraft     = "draft"
+	TransferShipped   = "shipped"
+	TransferReceived  = "received"
+	TransferCancelled = "cancelled"
+)
+
+// Transfer moves Quantity units of an item from one location to another.
+// Shipping takes the stock out of the source location and receiving puts it
+// into the destination, possibly over several partial receipts; in between
+// the stock is in transit and kept at neither.
+type Transfer struct {
+	ID             string     `gorm:"column:id;type:uuid;default:gen_random_uuid();primaryKey" bson:"_id" json:"id"`
+	ItemID         string     `gorm:"column:item_id" bson:"item_id" json:"item_id"`
+	FromLocationID string     `gorm:"column:from_location_id" bson:"from_location_id" json:"from_location_id"`
+	ToLocationID   string     `gorm:"column:to_location_id" bson:"to_location_id" json:"to_location_id"`
+	Quantity       int        `gorm:"column:quantity" bson:"quantity" json:"quantity"`
+	Received       int        `gorm:"column:received" bson:"received" json:"received"`
+	Status         string     `gorm:"size:16;column:status" bson:"status" json:"status"`
+	Reference      string     `gorm:"size:255;column:reference" bson:"reference" json:"reference"`
+	User           string     `gorm:"size:255;column:user_name" bson:"user" json:"user"`
+	CreatedAt      time.Time  `gorm:"column:created_at" bson:"created_at" json:"created_at"`
+	ShippedAt      *time.Time `gorm:"column:shipped_at" bson:"shipped_at" json:"shipped_at"`
+	ReceivedAt     *time.Time `gorm:"column:received_at" bson:"received_at" json:"received_at"`
+}
+
+// InTransit returns how many units have been shipped but not yet received.
+func (t *Transfer) InTransit() int {
+	if t.Status != TransferShipped {
+		return 0
+	}
+	return t.Quantity - t.Received
+}
+
+// TransferQuery selects a page of transfers, oldest first. Empty fields
+// match every transfer; LocationID matches transfers from or to it.
+type TransferQuery struct {
+	ItemID     string
+	LocationID string
+	Status     string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	manager "main/managers"
+	"main/models"
+	"main/responses"
+	"net/http"
+)
+
+// LogNotifier writes low-stock alerts to the log.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyLowStock(ctx context.Context, alert *models.LowStockAlert, item *models.Inventory) error {
+	log.Printf("Low stock: %q (%s) has %d available, below its reorder point of %d; reorder %d",
+		item.Name, item.ID, alert.Available, alert.ReorderPoint, alert.ReorderQuantity)
+	return nil
+}
+
+// WebhookNotifier posts low-stock alerts as JSON to URL.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+type lowStockEvent struct {
+	Event string                          `json:"event"`
+	Alert responses.LowStockAlertResponse `json:"alert"`
+	Item  responses.InventoryResponse     `json:"item"`
+}
+
+func (n *WebhookNotifier) NotifyLowStock(ctx context.Context, alert *models.LowStockAlert, item *models.Inventory) error {
+	body, err := json.Marshal(lowStockEvent{
+		Event: "low_stock",
+		Alert: responses.NewLowStockAlertResponse(alert),
+		Item:  responses.NewInventoryResponse(item),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// Multi notifies every notifier in turn and returns the first error.
+type Multi []manager.AlertNotifier
+
+func (m Multi) NotifyLowStock(ctx context.Context, alert *models.LowStockAlert, item *models.Inventory) error {
+	var firstErr error
+	for _, notifier := range m {
+		if err := notifier.NotifyLowStock(ctx, alert, item); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,20 @@
+package requests
+
+// BOMRequest replaces the bill of materials of a kit. An empty list of
+// components turns it back into a plain item.
+type BOMRequest struct {
+	Components []BOMComponentRequest `json:"components" validate:"dive"`
+}
+
+type BOMComponentRequest struct {
+	ItemID   string `json:"item_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// KitRequest assembles or disassembles Quantity units of a kit, at
+// LocationID if given.
+type KitRequest struct {
+	Quantity   int     `json:"quantity" validate:"required,gt=0"`
+	LocationID *string `json:"location_id"`
+	User       string  `json:"user"`
+}
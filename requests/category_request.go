@@ -0,0 +1,15 @@
+package requests
+
+// CategoryRequest creates a category, or renames and moves one. A null
+// parent_id puts the category at the top level.
+type CategoryRequest struct {
+	Name     string  `json:"name" validate:"required,max=255"`
+	ParentID *string `json:"parent_id"`
+}
+
+// CategoryOrderRequest orders the children of parent_id, or the top-level
+// categories when it is null.
+type CategoryOrderRequest struct {
+	ParentID    *string  `json:"parent_id"`
+	CategoryIDs []string `json:"category_ids" validate:"required,min=1,dive,required"`
+}
@@ -3,9 +3,16 @@ package requests
 //gorm:"column:id;type:uuid;default:gen_random_uuid()"
 
 type InventoryRequest struct {
-	Name        string   `json:"product_name" validate:"required" binding:"required"`
-	Price       int      `json:"price" validate:"required" binding:"required"`
-	Currency    string   `json:"currency" validate:"required" binding:"required"`
-	Discount    int      `json:"discount" validate:"required" binding:"required"`
-	Vendor      string   `json:"vendor" validate:"required" binding:"required"`
+	Name            string   `json:"product_name" validate:"required" binding:"required"`
+	Price           int      `json:"price" validate:"required" binding:"required"`
+	Currency        string   `json:"currency" validate:"required" binding:"required"`
+	Discount        int      `json:"discount" validate:"required" binding:"required"`
+	Vendor          string   `json:"vendor" validate:"required" binding:"required"`
+	ReorderPoint    int      `json:"reorder_point" validate:"gte=0"`
+	ReorderQuantity int      `json:"reorder_quantity" validate:"gte=0"`
+	LotTracked      bool     `json:"lot_tracked"`
+	Serialized      bool     `json:"serialized"`
+	SKU             string   `json:"sku" validate:"max=64"`
+	Barcodes        []string `json:"barcodes" validate:"dive,required"`
+	CategoryID      *string  `json:"category_id"`
 }
@@ -0,0 +1,15 @@
+package requests
+
+// LabelSheetRequest prints labels for items on sheets of paper, A4 unless
+// asked otherwise, with Code 128 barcodes unless asked otherwise.
+type LabelSheetRequest struct {
+	Items   []LabelItemRequest `json:"items" validate:"required,min=1,dive"`
+	Paper   string             `json:"paper" validate:"omitempty,oneof=a4 letter"`
+	Barcode string             `json:"barcode" validate:"omitempty,oneof=code128 qr"`
+	Encode  string             `json:"encode" validate:"omitempty,oneof=sku id"`
+}
+
+type LabelItemRequest struct {
+	ItemID string `json:"item_id" validate:"required"`
+	Copies int    `json:"copies" validate:"required,gt=0"`
+}
@@ -0,0 +1,9 @@
+package requests
+
+type LocationRequest struct {
+	Name     string  `json:"name" validate:"required"`
+	Type     string  `json:"type" validate:"required,oneof=warehouse zone bin"`
+	ParentID *string `json:"parent_id"`
+	// Quarantine holds the stock kept at the location back from sale.
+	Quarantine bool `json:"quarantine"`
+}
@@ -0,0 +1,9 @@
+package requests
+
+// LotRequest adds a lot to a lot-tracked item. The dates are calendar dates
+// such as 2024-05-31.
+type LotRequest struct {
+	LotNumber      string `json:"lot_number" validate:"required,max=64"`
+	ManufacturedAt string `json:"manufactured_at" validate:"omitempty,datetime=2006-01-02"`
+	ExpiresAt      string `json:"expires_at" validate:"omitempty,datetime=2006-01-02"`
+}
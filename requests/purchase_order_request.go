@@ -0,0 +1,34 @@
+package requests
+
+type PurchaseOrderRequest struct {
+	Vendor    string                     `json:"vendor" validate:"required"`
+	Currency  string                     `json:"currency" validate:"omitempty,len=3"`
+	Reference string                     `json:"reference"`
+	Lines     []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// PurchaseOrderLineRequest orders an item. ExpectedDate is a calendar date
+// such as 2024-05-31.
+type PurchaseOrderLineRequest struct {
+	ItemID       string `json:"item_id" validate:"required"`
+	Quantity     int    `json:"quantity" validate:"required,gt=0"`
+	UnitCost     int    `json:"unit_cost" validate:"gte=0"`
+	ExpectedDate string `json:"expected_date" validate:"required,datetime=2006-01-02"`
+}
+
+type PurchaseOrderReceiveRequest struct {
+	Lines []PurchaseOrderReceiptRequest `json:"lines" validate:"required,min=1,dive"`
+	User  string                        `json:"user"`
+}
+
+// PurchaseOrderReceiptRequest receives against the line with LineID, or
+// against the only line for ItemID.
+type PurchaseOrderReceiptRequest struct {
+	LineID     string  `json:"line_id" validate:"required_without=ItemID"`
+	ItemID     string  `json:"item_id"`
+	Quantity   int     `json:"quantity" validate:"required,gt=0"`
+	LocationID *string `json:"location_id"`
+	LotID      *string `json:"lot_id"`
+	// SerialNumbers lists the units received of a serialized item.
+	SerialNumbers []string `json:"serial_numbers"`
+}
@@ -0,0 +1,9 @@
+package requests
+
+// ReservationRequest holds Quantity units for TTLSeconds, or for the
+// configured default when TTLSeconds is left out.
+type ReservationRequest struct {
+	Quantity   int    `json:"quantity" validate:"required,gt=0"`
+	TTLSeconds int    `json:"ttl_seconds" validate:"gte=0"`
+	Reference  string `json:"reference"`
+}
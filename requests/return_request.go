@@ -0,0 +1,45 @@
+package requests
+
+type ReturnRequest struct {
+	SalesOrderID string              `json:"sales_order_id" validate:"required"`
+	Reason       string              `json:"reason"`
+	Lines        []ReturnLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type ReturnLineRequest struct {
+	ItemID   string `json:"item_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type ReturnReceiveRequest struct {
+	Receipts []ReturnReceiptRequest `json:"receipts" validate:"required,min=1,dive"`
+	User     string                 `json:"user"`
+}
+
+// ReturnReceiptRequest receives units of a line in one condition. New and
+// opened units are restocked, damaged and defective ones quarantined.
+type ReturnReceiptRequest struct {
+	LineID     string  `json:"line_id" validate:"required"`
+	Condition  string  `json:"condition" validate:"required,oneof=new opened damaged defective"`
+	Quantity   int     `json:"quantity" validate:"required,gt=0"`
+	LocationID *string `json:"location_id"`
+	LotID      *string `json:"lot_id"`
+	// SerialNumbers lists the units received of a serialized item.
+	SerialNumbers []string `json:"serial_numbers"`
+}
+
+type ReturnReleaseRequest struct {
+	Releases []ReturnReleaseLineRequest `json:"releases" validate:"required,min=1,dive"`
+	User     string                     `json:"user"`
+}
+
+type ReturnReleaseLineRequest struct {
+	LineID         string  `json:"line_id" validate:"required"`
+	Disposition    string  `json:"disposition" validate:"required,oneof=restock scrap"`
+	Quantity       int     `json:"quantity" validate:"required,gt=0"`
+	FromLocationID string  `json:"from_location_id" validate:"required"`
+	LocationID     *string `json:"location_id"`
+	LotID          *string `json:"lot_id"`
+	// SerialNumbers lists the units released of a serialized item.
+	SerialNumbers []string `json:"serial_numbers"`
+}
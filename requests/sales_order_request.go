@@ -0,0 +1,31 @@
+package requests
+
+type SalesOrderRequest struct {
+	Customer  string                  `json:"customer" validate:"required"`
+	Reference string                  `json:"reference"`
+	Lines     []SalesOrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+type SalesOrderLineRequest struct {
+	ItemID   string `json:"item_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// SalesOrderPickRequest confirms a pick list. Leaving Picks out accepts the
+// suggested one.
+type SalesOrderPickRequest struct {
+	Picks []SalesOrderPickLineRequest `json:"picks" validate:"dive"`
+}
+
+type SalesOrderPickLineRequest struct {
+	LineID     string  `json:"line_id" validate:"required"`
+	LocationID *string `json:"location_id"`
+	Quantity   int     `json:"quantity" validate:"required,gt=0"`
+}
+
+// SalesOrderShipRequest ships a picked order. SerialNumbers lists the units
+// shipped of its serialized items.
+type SalesOrderShipRequest struct {
+	SerialNumbers []string `json:"serial_numbers"`
+	User          string   `json:"user"`
+}
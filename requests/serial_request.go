@@ -0,0 +1,7 @@
+package requests
+
+// SerialRegistrationRequest records the serial numbers of units already on
+// hand.
+type SerialRegistrationRequest struct {
+	SerialNumbers []string `json:"serial_numbers" validate:"required,min=1,dive,required,max=128"`
+}
@@ -0,0 +1,23 @@
+package requests
+
+// StockAdjustmentRequest changes the on-hand quantity by Quantity, which may
+// be negative, recording an adjustment in the stock ledger.
+type StockAdjustmentRequest struct {
+	Quantity  int    `json:"quantity" validate:"required"`
+	Reference string `json:"reference"`
+	User      string `json:"user"`
+}
+
+type StockMovementRequest struct {
+	Delta      int     `json:"delta" validate:"required"`
+	Reason     string  `json:"reason" validate:"required,oneof=receipt shipment adjustment transfer return"`
+	Reference  string  `json:"reference"`
+	User       string  `json:"user"`
+	LocationID *string `json:"location_id"`
+	// LotID names the lot of a lot-tracked item the delta applies to.
+	// Without it, stock taken out comes from the lots first expiring.
+	LotID *string `json:"lot_id"`
+	// SerialNumbers lists the units of a serialized item, one per unit of
+	// the delta.
+	SerialNumbers []string `json:"serial_numbers"`
+}
@@ -0,0 +1,21 @@
+package requests
+
+type TransferRequest struct {
+	ItemID         string `json:"item_id" validate:"required"`
+	FromLocationID string `json:"from_location_id" validate:"required"`
+	ToLocationID   string `json:"to_location_id" validate:"required"`
+	Quantity       int    `json:"quantity" validate:"required,gt=0"`
+	Reference      string `json:"reference"`
+	User           string `json:"user"`
+}
+
+type TransferShipRequest struct {
+	User string `json:"user"`
+}
+
+// TransferReceiveRequest receives Quantity units of a transfer, or all that
+// is still in transit when Quantity is left out.
+type TransferReceiveRequest struct {
+	Quantity int    `json:"quantity" validate:"gte=0"`
+	User     string `json:"user"`
+}
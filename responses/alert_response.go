@@ -0,0 +1,30 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type LowStockAlertResponse struct {
+	ID              string     `json:"id"`
+	ItemID          string     `json:"item_id"`
+	Available       int        `json:"available"`
+	ReorderPoint    int        `json:"reorder_point"`
+	ReorderQuantity int        `json:"reorder_quantity"`
+	Status          string     `json:"status"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ResolvedAt      *time.Time `json:"resolved_at"`
+}
+
+func NewLowStockAlertResponse(alert *models.LowStockAlert) LowStockAlertResponse {
+	return LowStockAlertResponse{
+		ID:              alert.ID,
+		ItemID:          alert.ItemID,
+		Available:       alert.Available,
+		ReorderPoint:    alert.ReorderPoint,
+		ReorderQuantity: alert.ReorderQuantity,
+		Status:          alert.Status,
+		CreatedAt:       alert.CreatedAt,
+		ResolvedAt:      alert.ResolvedAt,
+	}
+}
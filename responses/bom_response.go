@@ -0,0 +1,31 @@
+package responses
+
+import "main/models"
+
+type BOMResponse struct {
+	ItemID            string                 `json:"item_id"`
+	Components        []BOMComponentResponse `json:"components"`
+	BuildableQuantity *int                   `json:"buildable_quantity"`
+}
+
+type BOMComponentResponse struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// NewBOMResponse maps a kit's bill of materials to its API representation.
+// buildable is nil for an item without components.
+func NewBOMResponse(itemID string, components []*models.BOMComponent, buildable *int) BOMResponse {
+	response := BOMResponse{
+		ItemID:            itemID,
+		Components:        make([]BOMComponentResponse, 0, len(components)),
+		BuildableQuantity: buildable,
+	}
+	for _, component := range components {
+		response.Components = append(response.Components, BOMComponentResponse{
+			ItemID:   component.ComponentID,
+			Quantity: component.Quantity,
+		})
+	}
+	return response
+}
@@ -0,0 +1,58 @@
+package responses
+
+import "main/models"
+
+type CategoryResponse struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	ParentID *string `json:"parent_id"`
+	Position int     `json:"position"`
+	// Children holds the subcategories, in order, when the category is
+	// returned as part of a tree.
+	Children []CategoryResponse `json:"children,omitempty"`
+}
+
+func NewCategoryResponse(category *models.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+		Position: category.Position,
+	}
+}
+
+// NewCategoryTree nests categories, which must be in order, under their
+// parents and returns the children of parentID, or the top-level
+// categories when it is nil.
+func NewCategoryTree(categories []*models.Category, parentID *string) []CategoryResponse {
+	children := make(map[string][]*models.Category)
+	var roots []*models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var build func(level []*models.Category) []CategoryResponse
+	build = func(level []*models.Category) []CategoryResponse {
+		tree := make([]CategoryResponse, 0, len(level))
+		for _, category := range level {
+			if seen[category.ID] {
+				continue
+			}
+			seen[category.ID] = true
+			node := NewCategoryResponse(category)
+			node.Children = build(children[category.ID])
+			tree = append(tree, node)
+		}
+		return tree
+	}
+
+	if parentID == nil {
+		return build(roots)
+	}
+	return build(children[*parentID])
+}
@@ -1,11 +1,62 @@
 package responses
 
+import "main/models"
 
 type InventoryResponse struct {
-	ID          string   `json:"id" bson:"_id"`
-	Name        string   `json:"product_name"`
-	Price       int      `json:"price"`
-	Currency    string   `json:"currency"`
-	Discount    int      `json:"discount"`
-	Vendor      string   `json:"vendor"`
+	ID              string   `json:"id" bson:"_id"`
+	Name            string   `json:"product_name"`
+	Price           int      `json:"price"`
+	Currency        string   `json:"currency"`
+	Discount        int      `json:"discount"`
+	Vendor          string   `json:"vendor"`
+	OnHand          int      `json:"on_hand"`
+	Reserved        int      `json:"reserved"`
+	Available       int      `json:"available"`
+	ReorderPoint    int      `json:"reorder_point"`
+	ReorderQuantity int      `json:"reorder_quantity"`
+	LotTracked      bool     `json:"lot_tracked"`
+	Serialized      bool     `json:"serialized"`
+	SKU             string   `json:"sku,omitempty"`
+	Barcodes        []string `json:"barcodes"`
+	CategoryID      *string  `json:"category_id"`
+	// Locations breaks OnHand down by location when the caller asks for it.
+	Locations []StockLevelResponse `json:"locations,omitempty"`
+	// BuildableQuantity is how many units of a kit its available components
+	// make. Items without a bill of materials leave it out.
+	BuildableQuantity *int `json:"buildable_quantity,omitempty"`
+}
+
+// NewInventoryResponse maps an item to its API representation.
+func NewInventoryResponse(item *models.Inventory) InventoryResponse {
+	return InventoryResponse{
+		ID:        item.ID,
+		Name:      item.Name,
+		Price:     item.Price,
+		Currency:  item.Currency,
+		Discount:  item.Discount,
+		Vendor:    item.Vendor,
+		OnHand:    item.OnHand,
+		Reserved:  item.Reserved,
+		Available: item.Available(),
+
+		ReorderPoint:    item.ReorderPoint,
+		ReorderQuantity: item.ReorderQuantity,
+		LotTracked:      item.LotTracked,
+		Serialized:      item.Serialized,
+		SKU:             item.SKU,
+		Barcodes:        append([]string{}, item.Barcodes...),
+		CategoryID:      item.CategoryID,
+	}
+}
+
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+type SearchResultResponse struct {
+	InventoryResponse
+	Score float64 `json:"score"`
 }
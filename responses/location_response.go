@@ -0,0 +1,39 @@
+package responses
+
+import "main/models"
+
+type LocationResponse struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Type       string  `json:"type"`
+	ParentID   *string `json:"parent_id"`
+	Quarantine bool    `json:"quarantine"`
+}
+
+func NewLocationResponse(location *models.Location) LocationResponse {
+	return LocationResponse{
+		ID:         location.ID,
+		Name:       location.Name,
+		Type:       location.Type,
+		ParentID:   location.ParentID,
+		Quarantine: location.Quarantine,
+	}
+}
+
+type StockLevelResponse struct {
+	ItemID     string `json:"item_id"`
+	LocationID string `json:"location_id"`
+	OnHand     int    `json:"on_hand"`
+}
+
+func NewStockLevelResponses(levels []*models.StockLevel) []StockLevelResponse {
+	levelResponses := make([]StockLevelResponse, 0, len(levels))
+	for _, level := range levels {
+		levelResponses = append(levelResponses, StockLevelResponse{
+			ItemID:     level.ItemID,
+			LocationID: level.LocationID,
+			OnHand:     level.OnHand,
+		})
+	}
+	return levelResponses
+}
@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type LotResponse struct {
+	ID             string    `json:"id"`
+	ItemID         string    `json:"item_id"`
+	LotNumber      string    `json:"lot_number"`
+	ManufacturedAt *string   `json:"manufactured_at"`
+	ExpiresAt      *string   `json:"expires_at"`
+	Expired        bool      `json:"expired"`
+	OnHand         int       `json:"on_hand"`
+	Quarantined    int       `json:"quarantined"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func NewLotResponse(lot *models.Lot) LotResponse {
+	return LotResponse{
+		ID:             lot.ID,
+		ItemID:         lot.ItemID,
+		LotNumber:      lot.LotNumber,
+		ManufacturedAt: formatDate(lot.ManufacturedAt),
+		ExpiresAt:      formatDate(lot.ExpiresAt),
+		Expired:        lot.ExpiredOn(time.Now()),
+		OnHand:         lot.OnHand,
+		Quarantined:    lot.Quarantined,
+		CreatedAt:      lot.CreatedAt,
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	date := t.UTC().Format("2006-01-02")
+	return &date
+}
@@ -0,0 +1,54 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type PurchaseOrderResponse struct {
+	ID        string                      `json:"id"`
+	Vendor    string                      `json:"vendor"`
+	Currency  string                      `json:"currency"`
+	Reference string                      `json:"reference"`
+	Status    string                      `json:"status"`
+	Lines     []PurchaseOrderLineResponse `json:"lines"`
+	Total     int                         `json:"total"`
+	CreatedAt time.Time                   `json:"created_at"`
+	ClosedAt  *time.Time                  `json:"closed_at"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ID           string `json:"id"`
+	ItemID       string `json:"item_id"`
+	Quantity     int    `json:"quantity"`
+	UnitCost     int    `json:"unit_cost"`
+	ExpectedDate string `json:"expected_date"`
+	Received     int    `json:"received"`
+	Outstanding  int    `json:"outstanding"`
+}
+
+func NewPurchaseOrderResponse(order *models.PurchaseOrder) PurchaseOrderResponse {
+	response := PurchaseOrderResponse{
+		ID:        order.ID,
+		Vendor:    order.Vendor,
+		Currency:  order.Currency,
+		Reference: order.Reference,
+		Status:    order.Status,
+		Lines:     make([]PurchaseOrderLineResponse, 0, len(order.Lines)),
+		CreatedAt: order.CreatedAt,
+		ClosedAt:  order.ClosedAt,
+	}
+	for _, line := range order.Lines {
+		response.Lines = append(response.Lines, PurchaseOrderLineResponse{
+			ID:           line.ID,
+			ItemID:       line.ItemID,
+			Quantity:     line.Quantity,
+			UnitCost:     line.UnitCost,
+			ExpectedDate: line.ExpectedDate.UTC().Format("2006-01-02"),
+			Received:     line.Received,
+			Outstanding:  max(line.Quantity-line.Received, 0),
+		})
+		response.Total += line.Quantity * line.UnitCost
+	}
+	return response
+}
@@ -0,0 +1,28 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type ReservationResponse struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	Quantity  int       `json:"quantity"`
+	Status    string    `json:"status"`
+	Reference string    `json:"reference"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewReservationResponse(reservation *models.Reservation) ReservationResponse {
+	return ReservationResponse{
+		ID:        reservation.ID,
+		ItemID:    reservation.ItemID,
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		Reference: reservation.Reference,
+		CreatedAt: reservation.CreatedAt,
+		ExpiresAt: reservation.ExpiresAt,
+	}
+}
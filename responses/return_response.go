@@ -0,0 +1,79 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type ReturnResponse struct {
+	ID           string                `json:"id"`
+	SalesOrderID string                `json:"sales_order_id"`
+	Reason       string                `json:"reason"`
+	Status       string                `json:"status"`
+	Lines        []ReturnLineResponse  `json:"lines"`
+	Entries      []ReturnEntryResponse `json:"entries"`
+	CreatedAt    time.Time             `json:"created_at"`
+	ReceivedAt   *time.Time            `json:"received_at"`
+	ClosedAt     *time.Time            `json:"closed_at"`
+}
+
+type ReturnLineResponse struct {
+	ID          string `json:"id"`
+	ItemID      string `json:"item_id"`
+	Quantity    int    `json:"quantity"`
+	Received    int    `json:"received"`
+	Restocked   int    `json:"restocked"`
+	Quarantined int    `json:"quarantined"`
+	Scrapped    int    `json:"scrapped"`
+}
+
+type ReturnEntryResponse struct {
+	LineID         string    `json:"line_id"`
+	ItemID         string    `json:"item_id"`
+	Condition      string    `json:"condition,omitempty"`
+	Disposition    string    `json:"disposition"`
+	FromLocationID *string   `json:"from_location_id"`
+	LocationID     *string   `json:"location_id"`
+	LotID          *string   `json:"lot_id,omitempty"`
+	Quantity       int       `json:"quantity"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func NewReturnResponse(ret *models.Return) ReturnResponse {
+	response := ReturnResponse{
+		ID:           ret.ID,
+		SalesOrderID: ret.SalesOrderID,
+		Reason:       ret.Reason,
+		Status:       ret.Status,
+		Lines:        make([]ReturnLineResponse, 0, len(ret.Lines)),
+		Entries:      make([]ReturnEntryResponse, 0, len(ret.Entries)),
+		CreatedAt:    ret.CreatedAt,
+		ReceivedAt:   ret.ReceivedAt,
+		ClosedAt:     ret.ClosedAt,
+	}
+	for _, line := range ret.Lines {
+		response.Lines = append(response.Lines, ReturnLineResponse{
+			ID:          line.ID,
+			ItemID:      line.ItemID,
+			Quantity:    line.Quantity,
+			Received:    line.Received,
+			Restocked:   line.Restocked,
+			Quarantined: line.Quarantined(),
+			Scrapped:    line.Scrapped,
+		})
+	}
+	for _, entry := range ret.Entries {
+		response.Entries = append(response.Entries, ReturnEntryResponse{
+			LineID:         entry.LineID,
+			ItemID:         entry.ItemID,
+			Condition:      entry.Condition,
+			Disposition:    entry.Disposition,
+			FromLocationID: entry.FromLocationID,
+			LocationID:     entry.LocationID,
+			LotID:          entry.LotID,
+			Quantity:       entry.Quantity,
+			CreatedAt:      entry.CreatedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,70 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type SalesOrderResponse struct {
+	ID          string                   `json:"id"`
+	Customer    string                   `json:"customer"`
+	Reference   string                   `json:"reference"`
+	Status      string                   `json:"status"`
+	Lines       []SalesOrderLineResponse `json:"lines"`
+	Picks       []SalesOrderPickResponse `json:"picks"`
+	CreatedAt   time.Time                `json:"created_at"`
+	AllocatedAt *time.Time               `json:"allocated_at"`
+	PickedAt    *time.Time               `json:"picked_at"`
+	ShippedAt   *time.Time               `json:"shipped_at"`
+	DeliveredAt *time.Time               `json:"delivered_at"`
+}
+
+type SalesOrderLineResponse struct {
+	ID       string `json:"id"`
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+type SalesOrderPickResponse struct {
+	LineID     string  `json:"line_id"`
+	ItemID     string  `json:"item_id"`
+	LocationID *string `json:"location_id"`
+	Quantity   int     `json:"quantity"`
+}
+
+func NewSalesOrderResponse(order *models.SalesOrder) SalesOrderResponse {
+	response := SalesOrderResponse{
+		ID:          order.ID,
+		Customer:    order.Customer,
+		Reference:   order.Reference,
+		Status:      order.Status,
+		Lines:       make([]SalesOrderLineResponse, 0, len(order.Lines)),
+		Picks:       NewSalesOrderPickResponses(order.Picks),
+		CreatedAt:   order.CreatedAt,
+		AllocatedAt: order.AllocatedAt,
+		PickedAt:    order.PickedAt,
+		ShippedAt:   order.ShippedAt,
+		DeliveredAt: order.DeliveredAt,
+	}
+	for _, line := range order.Lines {
+		response.Lines = append(response.Lines, SalesOrderLineResponse{
+			ID:       line.ID,
+			ItemID:   line.ItemID,
+			Quantity: line.Quantity,
+		})
+	}
+	return response
+}
+
+func NewSalesOrderPickResponses(picks []models.SalesOrderPick) []SalesOrderPickResponse {
+	pickResponses := make([]SalesOrderPickResponse, 0, len(picks))
+	for _, pick := range picks {
+		pickResponses = append(pickResponses, SalesOrderPickResponse{
+			LineID:     pick.LineID,
+			ItemID:     pick.ItemID,
+			LocationID: pick.LocationID,
+			Quantity:   pick.Quantity,
+		})
+	}
+	return pickResponses
+}
@@ -0,0 +1,26 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type SerialResponse struct {
+	ID           string    `json:"id"`
+	ItemID       string    `json:"item_id"`
+	SerialNumber string    `json:"serial_number"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewSerialResponse(serial *models.Serial) SerialResponse {
+	return SerialResponse{
+		ID:           serial.ID,
+		ItemID:       serial.ItemID,
+		SerialNumber: serial.SerialNumber,
+		Status:       serial.Status,
+		CreatedAt:    serial.CreatedAt,
+		UpdatedAt:    serial.UpdatedAt,
+	}
+}
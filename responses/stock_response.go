@@ -0,0 +1,44 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type StockMovementResponse struct {
+	ID         string    `json:"id"`
+	ItemID     string    `json:"item_id"`
+	Delta      int       `json:"delta"`
+	Reason     string    `json:"reason"`
+	Reference  string    `json:"reference"`
+	User       string    `json:"user"`
+	LocationID *string   `json:"location_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	// Lots breaks the delta of a lot-tracked item down by lot.
+	Lots          []MovementLotResponse `json:"lots,omitempty"`
+	SerialNumbers []string              `json:"serial_numbers,omitempty"`
+}
+
+type MovementLotResponse struct {
+	LotID string `json:"lot_id"`
+	Delta int    `json:"delta"`
+}
+
+func NewStockMovementResponse(movement *models.StockMovement) StockMovementResponse {
+	response := StockMovementResponse{
+		ID:         movement.ID,
+		ItemID:     movement.ItemID,
+		Delta:      movement.Delta,
+		Reason:     movement.Reason,
+		Reference:  movement.Reference,
+		User:       movement.User,
+		LocationID: movement.LocationID,
+		CreatedAt:  movement.CreatedAt,
+
+		SerialNumbers: movement.SerialNumbers,
+	}
+	for _, lot := range movement.Lots {
+		response.Lots = append(response.Lots, MovementLotResponse{LotID: lot.LotID, Delta: lot.Delta})
+	}
+	return response
+}
@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"main/models"
+	"time"
+)
+
+type TransferResponse struct {
+	ID             string     `json:"id"`
+	ItemID         string     `json:"item_id"`
+	FromLocationID string     `json:"from_location_id"`
+	ToLocationID   string     `json:"to_location_id"`
+	Quantity       int        `json:"quantity"`
+	Received       int        `json:"received"`
+	InTransit      int        `json:"in_transit"`
+	Status         string     `json:"status"`
+	Reference      string     `json:"reference"`
+	User           string     `json:"user"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ShippedAt      *time.Time `json:"shipped_at"`
+	ReceivedAt     *time.Time `json:"received_at"`
+}
+
+func NewTransferResponse(transfer *models.Transfer) TransferResponse {
+	return TransferResponse{
+		ID:             transfer.ID,
+		ItemID:         transfer.ItemID,
+		FromLocationID: transfer.FromLocationID,
+		ToLocationID:   transfer.ToLocationID,
+		Quantity:       transfer.Quantity,
+		Received:       transfer.Received,
+		InTransit:      transfer.InTransit(),
+		Status:         transfer.Status,
+		Reference:      transfer.Reference,
+		User:           transfer.User,
+		CreatedAt:      transfer.CreatedAt,
+		ShippedAt:      transfer.ShippedAt,
+		ReceivedAt:     transfer.ReceivedAt,
+	}
+}
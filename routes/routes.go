@@ -2,17 +2,90 @@ package routes
 
 import (
 	"main/controllers"
-	manager "main/managers"
 
 	"github.com/labstack/echo/v4"
 )
 
 func RegisterInventoryRoutes(e *echo.Echo, inventoryController *controllers.InventoryController) {
-	inventoryManager := &manager.InventoryManager{}
-	inventoryController.InventoryManager = inventoryManager
 	e.POST("/inventory", inventoryController.CreateItemHandler)
 	e.GET("/inventory", inventoryController.GetItemsHandler)
+	e.GET("/inventory/search", inventoryController.SearchItemsHandler)
+	e.GET("/inventory/expiring", inventoryController.GetExpiringLotsHandler)
+	e.POST("/inventory/labels", inventoryController.CreateLabelSheetHandler)
+	e.GET("/inventory/by-sku/:sku", inventoryController.GetItemBySKUHandler)
+	e.GET("/inventory/by-barcode/:code", inventoryController.GetItemByBarcodeHandler)
 	e.GET("/inventory/:id", inventoryController.GetItemByIDHandler)
 	e.PUT("/inventory/:id", inventoryController.UpdateItemHandler)
+	e.PATCH("/inventory/:id", inventoryController.PatchItemHandler)
 	e.DELETE("/inventory/:id", inventoryController.DeleteItemHandler)
-}
\ No newline at end of file
+
+	e.POST("/inventory/:id/stock/adjust", inventoryController.AdjustStockHandler)
+	e.POST("/inventory/:id/movements", inventoryController.CreateMovementHandler)
+	e.GET("/inventory/:id/movements", inventoryController.GetMovementsHandler)
+	e.GET("/inventory/:id/locations", inventoryController.GetItemLocationsHandler)
+	e.POST("/inventory/:id/reservations", inventoryController.CreateReservationHandler)
+	e.GET("/inventory/:id/reservations", inventoryController.GetItemReservationsHandler)
+	e.GET("/inventory/:id/bom", inventoryController.GetBOMHandler)
+	e.PUT("/inventory/:id/bom", inventoryController.SetBOMHandler)
+	e.POST("/inventory/:id/assemble", inventoryController.AssembleKitHandler)
+	e.POST("/inventory/:id/disassemble", inventoryController.DisassembleKitHandler)
+	e.POST("/inventory/:id/lots", inventoryController.CreateLotHandler)
+	e.GET("/inventory/:id/lots", inventoryController.GetLotsHandler)
+	e.POST("/inventory/:id/serials", inventoryController.RegisterSerialsHandler)
+	e.GET("/inventory/:id/serials", inventoryController.GetSerialsHandler)
+	e.GET("/inventory/:id/label", inventoryController.GetItemLabelHandler)
+
+	e.GET("/reservations/:id", inventoryController.GetReservationByIDHandler)
+	e.POST("/reservations/:id/confirm", inventoryController.ConfirmReservationHandler)
+	e.POST("/reservations/:id/release", inventoryController.ReleaseReservationHandler)
+
+	e.GET("/serials/:serial", inventoryController.GetSerialHandler)
+	e.POST("/serials/:serial/reserve", inventoryController.ReserveSerialHandler)
+	e.POST("/serials/:serial/release", inventoryController.ReleaseSerialHandler)
+
+	e.GET("/alerts/low-stock", inventoryController.GetLowStockAlertsHandler)
+
+	e.POST("/locations", inventoryController.CreateLocationHandler)
+	e.GET("/locations", inventoryController.GetLocationsHandler)
+	e.GET("/locations/:id", inventoryController.GetLocationByIDHandler)
+	e.DELETE("/locations/:id", inventoryController.DeleteLocationHandler)
+	e.GET("/locations/:id/stock", inventoryController.GetLocationStockHandler)
+
+	e.POST("/categories", inventoryController.CreateCategoryHandler)
+	e.GET("/categories", inventoryController.GetCategoriesHandler)
+	e.POST("/categories/reorder", inventoryController.ReorderCategoriesHandler)
+	e.GET("/categories/:id", inventoryController.GetCategoryByIDHandler)
+	e.PUT("/categories/:id", inventoryController.UpdateCategoryHandler)
+	e.DELETE("/categories/:id", inventoryController.DeleteCategoryHandler)
+
+	e.POST("/transfers", inventoryController.CreateTransferHandler)
+	e.GET("/transfers", inventoryController.GetTransfersHandler)
+	e.GET("/transfers/:id", inventoryController.GetTransferByIDHandler)
+	e.POST("/transfers/:id/ship", inventoryController.ShipTransferHandler)
+	e.POST("/transfers/:id/receive", inventoryController.ReceiveTransferHandler)
+	e.POST("/transfers/:id/cancel", inventoryController.CancelTransferHandler)
+
+	e.POST("/purchase-orders", inventoryController.CreatePurchaseOrderHandler)
+	e.GET("/purchase-orders", inventoryController.GetPurchaseOrdersHandler)
+	e.GET("/purchase-orders/:id", inventoryController.GetPurchaseOrderByIDHandler)
+	e.POST("/purchase-orders/:id/receive", inventoryController.ReceivePurchaseOrderHandler)
+	e.POST("/purchase-orders/:id/close", inventoryController.ClosePurchaseOrderHandler)
+	e.POST("/purchase-orders/:id/cancel", inventoryController.CancelPurchaseOrderHandler)
+
+	e.POST("/sales-orders", inventoryController.CreateSalesOrderHandler)
+	e.GET("/sales-orders", inventoryController.GetSalesOrdersHandler)
+	e.GET("/sales-orders/:id", inventoryController.GetSalesOrderByIDHandler)
+	e.POST("/sales-orders/:id/allocate", inventoryController.AllocateSalesOrderHandler)
+	e.GET("/sales-orders/:id/pick-list", inventoryController.GetSalesOrderPickListHandler)
+	e.POST("/sales-orders/:id/pick", inventoryController.PickSalesOrderHandler)
+	e.POST("/sales-orders/:id/ship", inventoryController.ShipSalesOrderHandler)
+	e.POST("/sales-orders/:id/deliver", inventoryController.DeliverSalesOrderHandler)
+	e.POST("/sales-orders/:id/cancel", inventoryController.CancelSalesOrderHandler)
+
+	e.POST("/returns", inventoryController.CreateReturnHandler)
+	e.GET("/returns", inventoryController.GetReturnsHandler)
+	e.GET("/returns/:id", inventoryController.GetReturnByIDHandler)
+	e.POST("/returns/:id/receive", inventoryController.ReceiveReturnHandler)
+	e.POST("/returns/:id/release", inventoryController.ReleaseReturnHandler)
+	e.POST("/returns/:id/cancel", inventoryController.CancelReturnHandler)
+}
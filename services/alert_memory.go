@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateAlert(ctx context.Context, alert *models.LowStockAlert) (*models.LowStockAlert, error) {
+	defer s.lock(ctx)()
+
+	alert.ID = uuid.New().String()
+	stored := *alert
+	s.data.alerts[alert.ID] = &stored
+
+	return alert, nil
+}
+
+func (s *MemoryStore) GetAlerts(ctx context.Context, query models.AlertQuery) ([]*models.LowStockAlert, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.LowStockAlert
+	for _, stored := range s.data.alerts {
+		if query.ItemID != "" && stored.ItemID != query.ItemID || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		alert := *stored
+		matched = append(matched, &alert)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) ResolveAlert(ctx context.Context, id string, resolvedAt time.Time) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.alerts[id]
+	if !ok || stored.Status != models.AlertOpen {
+		return ErrAlertNotFound
+	}
+
+	stored.Status, stored.ResolvedAt = models.AlertResolved, &resolvedAt
+	return nil
+}
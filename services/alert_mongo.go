@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"log"
+	"main/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) alerts() *mongo.Collection {
+	return s.Collection.Database().Collection("low_stock_alerts")
+}
+
+func (s *MongoStore) CreateAlert(ctx context.Context, alert *models.LowStockAlert) (*models.LowStockAlert, error) {
+	alert.ID = primitive.NewObjectID().Hex()
+	if _, err := s.alerts().InsertOne(ctx, alert); err != nil {
+		log.Printf("Error inserting low-stock alert: %v", err)
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (s *MongoStore) GetAlerts(ctx context.Context, query models.AlertQuery) ([]*models.LowStockAlert, int64, error) {
+	var alerts []*models.LowStockAlert
+
+	filter := bson.M{}
+	if query.ItemID != "" {
+		filter["item_id"] = query.ItemID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.alerts().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.alerts().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &alerts); err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, totalCount, nil
+}
+
+func (s *MongoStore) ResolveAlert(ctx context.Context, id string, resolvedAt time.Time) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrAlertNotFound
+	}
+
+	filter := bson.M{"_id": id, "status": models.AlertOpen}
+	update := bson.M{"$set": bson.M{"status": models.AlertResolved, "resolved_at": resolvedAt}}
+	result, err := s.alerts().UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error resolving low-stock alert: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrAlertNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const alertColumns = "id, item_id, available, reorder_point, reorder_quantity, status, created_at, resolved_at"
+
+func (s *PostgresStore) CreateAlert(ctx context.Context, alert *models.LowStockAlert) (*models.LowStockAlert, error) {
+	alert.ID = uuid.New().String()
+
+	query := `INSERT INTO low_stock_alerts (` + alertColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	err := s.conn(ctx).Exec(query, alert.ID, alert.ItemID, alert.Available, alert.ReorderPoint, alert.ReorderQuantity,
+		alert.Status, alert.CreatedAt, alert.ResolvedAt).Error
+	if err != nil {
+		log.Printf("Error inserting low-stock alert: %v", err)
+		return nil, fmt.Errorf("error inserting low-stock alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (s *PostgresStore) GetAlerts(ctx context.Context, query models.AlertQuery) ([]*models.LowStockAlert, int64, error) {
+	var alerts []*models.LowStockAlert
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.ItemID != "" {
+		if _, err := uuid.Parse(query.ItemID); err != nil {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "item_id = ?")
+		args = append(args, query.ItemID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM low_stock_alerts` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting low-stock alerts: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + alertColumns + ` FROM low_stock_alerts` + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&alerts).Error; err != nil {
+		log.Printf("Error fetching low-stock alerts: %v", err)
+		return nil, 0, err
+	}
+
+	return alerts, totalCount, nil
+}
+
+func (s *PostgresStore) ResolveAlert(ctx context.Context, id string, resolvedAt time.Time) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrAlertNotFound
+	}
+
+	query := `UPDATE low_stock_alerts SET status = ?, resolved_at = ? WHERE id = ? AND status = ?`
+	result := s.conn(ctx).Exec(query, models.AlertResolved, resolvedAt, id, models.AlertOpen)
+	if result.Error != nil {
+		log.Printf("Error resolving low-stock alert: %v", result.Error)
+		return fmt.Errorf("error resolving low-stock alert: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlertNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+)
+
+func (s *MemoryStore) SetBOM(ctx context.Context, parentID string, components []*models.BOMComponent) error {
+	defer s.lock(ctx)()
+
+	if len(components) == 0 {
+		delete(s.data.boms, parentID)
+		return nil
+	}
+
+	stored := make([]models.BOMComponent, 0, len(components))
+	for _, component := range components {
+		stored = append(stored, *component)
+	}
+	s.data.boms[parentID] = stored
+	return nil
+}
+
+func (s *MemoryStore) GetBOMComponents(ctx context.Context, query models.BOMQuery) ([]*models.BOMComponent, error) {
+	defer s.rlock(ctx)()
+
+	parentIDs, componentIDs := stringSet(query.ParentIDs), stringSet(query.ComponentIDs)
+
+	parents := make([]string, 0, len(s.data.boms))
+	for parentID := range s.data.boms {
+		if parentIDs == nil || parentIDs[parentID] {
+			parents = append(parents, parentID)
+		}
+	}
+	sort.Strings(parents)
+
+	var components []*models.BOMComponent
+	for _, parentID := range parents {
+		for _, stored := range s.data.boms[parentID] {
+			if componentIDs != nil && !componentIDs[stored.ComponentID] {
+				continue
+			}
+			component := stored
+			components = append(components, &component)
+		}
+	}
+
+	return components, nil
+}
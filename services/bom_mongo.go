@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) bomComponents() *mongo.Collection {
+	return s.Collection.Database().Collection("bom_components")
+}
+
+// bomDocument is how a BOMComponent is stored, with its position in the
+// kit's bill of materials.
+type bomDocument struct {
+	models.BOMComponent `bson:",inline"`
+	Position            int `bson:"position"`
+}
+
+func (s *MongoStore) SetBOM(ctx context.Context, parentID string, components []*models.BOMComponent) error {
+	if _, err := s.bomComponents().DeleteMany(ctx, bson.M{"parent_id": parentID}); err != nil {
+		log.Printf("Error deleting bill of materials: %v", err)
+		return err
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(components))
+	for i, component := range components {
+		documents = append(documents, bomDocument{BOMComponent: *component, Position: i})
+	}
+	if _, err := s.bomComponents().InsertMany(ctx, documents); err != nil {
+		log.Printf("Error inserting bill of materials: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *MongoStore) GetBOMComponents(ctx context.Context, query models.BOMQuery) ([]*models.BOMComponent, error) {
+	var components []*models.BOMComponent
+
+	filter := bson.M{}
+	if len(query.ParentIDs) > 0 {
+		filter["parent_id"] = bson.M{"$in": query.ParentIDs}
+	}
+	if len(query.ComponentIDs) > 0 {
+		filter["component_id"] = bson.M{"$in": query.ComponentIDs}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "parent_id", Value: 1}, {Key: "position", Value: 1}}).
+		SetProjection(bson.M{"_id": 0, "position": 0})
+	cursor, err := s.bomComponents().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &components); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+)
+
+func (s *PostgresStore) SetBOM(ctx context.Context, parentID string, components []*models.BOMComponent) error {
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.conn(ctx).Exec(`DELETE FROM bom_components WHERE parent_id = ?`, parentID).Error; err != nil {
+			return err
+		}
+
+		for i, component := range components {
+			query := `INSERT INTO bom_components (parent_id, component_id, position, quantity) VALUES (?, ?, ?, ?)`
+			if err := s.conn(ctx).Exec(query, parentID, component.ComponentID, i, component.Quantity).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error setting bill of materials: %v", err)
+		return fmt.Errorf("error setting bill of materials: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) GetBOMComponents(ctx context.Context, query models.BOMQuery) ([]*models.BOMComponent, error) {
+	var components []*models.BOMComponent
+
+	var conditions []string
+	var args []interface{}
+	filters := []struct {
+		column string
+		ids    []string
+	}{{"parent_id", query.ParentIDs}, {"component_id", query.ComponentIDs}}
+	for _, filter := range filters {
+		if len(filter.ids) == 0 {
+			continue
+		}
+		valid := validUUIDs(filter.ids)
+		if len(valid) == 0 {
+			return nil, nil
+		}
+		conditions = append(conditions, filter.column+" IN ?")
+		args = append(args, valid)
+	}
+
+	selectQuery := `SELECT parent_id, component_id, quantity FROM bom_components` + whereClause(conditions) +
+		` ORDER BY parent_id, position`
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&components).Error; err != nil {
+		log.Printf("Error fetching bill of materials: %v", err)
+		return nil, err
+	}
+
+	return components, nil
+}
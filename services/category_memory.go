@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	defer s.lock(ctx)()
+
+	category.ID = uuid.New().String()
+	stored := *category
+	s.data.categories[category.ID] = &stored
+
+	return category, nil
+}
+
+func (s *MemoryStore) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	defer s.rlock(ctx)()
+
+	categories := make([]*models.Category, 0, len(s.data.categories))
+	for _, stored := range s.data.categories {
+		category := *stored
+		categories = append(categories, &category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	return categories, nil
+}
+
+func (s *MemoryStore) GetCategoryByID(ctx context.Context, id string) (*models.Category, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.categories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+
+	category := *stored
+	return &category, nil
+}
+
+func (s *MemoryStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.categories[category.ID]; !ok {
+		return ErrCategoryNotFound
+	}
+
+	stored := *category
+	s.data.categories[category.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) DeleteCategory(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.categories[id]; !ok {
+		return ErrCategoryNotFound
+	}
+
+	delete(s.data.categories, id)
+	return nil
+}
+
+// LockCategories does nothing: a transaction holds the store's lock
+// throughout.
+func (s *MemoryStore) LockCategories(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) categories() *mongo.Collection {
+	return s.Collection.Database().Collection("categories")
+}
+
+// categoryLocks holds the document every change to the category tree
+// writes, see LockCategories.
+func (s *MongoStore) categoryLocks() *mongo.Collection {
+	return s.Collection.Database().Collection("category_locks")
+}
+
+func (s *MongoStore) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category.ID = primitive.NewObjectID().Hex()
+	if _, err := s.categories().InsertOne(ctx, category); err != nil {
+		log.Printf("Error inserting category: %v", err)
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *MongoStore) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	var categories []*models.Category
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.categories().Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (s *MongoStore) GetCategoryByID(ctx context.Context, id string) (*models.Category, error) {
+	var category models.Category
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrCategoryNotFound
+	}
+
+	err := s.categories().FindOne(ctx, bson.M{"_id": id}).Decode(&category)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching category: %v", err)
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (s *MongoStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	if !primitive.IsValidObjectID(category.ID) {
+		return ErrCategoryNotFound
+	}
+
+	update := bson.M{"$set": bson.M{"name": category.Name, "parent_id": category.ParentID, "position": category.Position}}
+	result, err := s.categories().UpdateOne(ctx, bson.M{"_id": category.ID}, update)
+	if err != nil {
+		log.Printf("Error updating category: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (s *MongoStore) DeleteCategory(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrCategoryNotFound
+	}
+
+	result, err := s.categories().DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		log.Printf("Error deleting category: %v", err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+// LockCategories writes the tree's lock document. Transactions writing the
+// same document conflict, and all but the first are retried from the start
+// once it commits, so they see its changes.
+func (s *MongoStore) LockCategories(ctx context.Context) error {
+	_, err := s.categoryLocks().UpdateOne(ctx, bson.M{"_id": "tree"}, bson.M{"$inc": bson.M{"version": 1}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Printf("Error locking categories: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const categoryColumns = "id, name, parent_id, position"
+
+// categoryLockKey is the transaction-level advisory lock that serializes
+// changes to the category tree.
+const categoryLockKey int64 = 0x63617465676f7279
+
+func (s *PostgresStore) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category.ID = uuid.New().String()
+
+	query := `INSERT INTO categories (` + categoryColumns + `) VALUES (?, ?, ?, ?)`
+	err := s.conn(ctx).Exec(query, category.ID, category.Name, category.ParentID, category.Position).Error
+	if err != nil {
+		log.Printf("Error inserting category: %v", err)
+		return nil, fmt.Errorf("error inserting category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *PostgresStore) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	var categories []*models.Category
+
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY position, name, id`
+	if err := s.conn(ctx).Raw(query).Scan(&categories).Error; err != nil {
+		log.Printf("Error fetching categories: %v", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (s *PostgresStore) GetCategoryByID(ctx context.Context, id string) (*models.Category, error) {
+	var category models.Category
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&category)
+	if result.Error != nil {
+		log.Printf("Error fetching category: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrCategoryNotFound
+	}
+
+	return &category, nil
+}
+
+func (s *PostgresStore) UpdateCategory(ctx context.Context, category *models.Category) error {
+	if _, err := uuid.Parse(category.ID); err != nil {
+		return ErrCategoryNotFound
+	}
+
+	query := `UPDATE categories SET name = ?, parent_id = ?, position = ? WHERE id = ?`
+	result := s.conn(ctx).Exec(query, category.Name, category.ParentID, category.Position, category.ID)
+	if result.Error != nil {
+		log.Printf("Error updating category: %v", result.Error)
+		return fmt.Errorf("error updating category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) DeleteCategory(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrCategoryNotFound
+	}
+
+	result := s.conn(ctx).Exec(`DELETE FROM categories WHERE id = ?`, id)
+	if result.Error != nil {
+		log.Printf("Error deleting category: %v", result.Error)
+		return fmt.Errorf("error deleting category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) LockCategories(ctx context.Context) error {
+	if err := s.conn(ctx).Exec(`SELECT pg_advisory_xact_lock(?)`, categoryLockKey).Error; err != nil {
+		log.Printf("Error locking categories: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"main/models"
+)
+
+func (s *MemoryStore) GetItemBySKU(ctx context.Context, sku string) (*models.Inventory, error) {
+	defer s.rlock(ctx)()
+
+	for _, stored := range s.data.items {
+		if sku != "" && stored.SKU == sku {
+			item := *stored
+			return &item, nil
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
+func (s *MemoryStore) GetItemByBarcode(ctx context.Context, gtin string) (*models.Inventory, error) {
+	defer s.rlock(ctx)()
+
+	for _, stored := range s.data.items {
+		for _, barcode := range stored.Barcodes {
+			if barcode == gtin {
+				item := *stored
+				return &item, nil
+			}
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
+// checkItemIdentifiers fails if an item other than id already has the SKU
+// or one of the barcodes of item.
+func (d *memoryData) checkItemIdentifiers(id string, item *models.Inventory) error {
+	barcodes := make(map[string]bool, len(item.Barcodes))
+	for _, barcode := range item.Barcodes {
+		barcodes[barcode] = true
+	}
+
+	for _, stored := range d.items {
+		if stored.ID == id {
+			continue
+		}
+		if item.SKU != "" && stored.SKU == item.SKU {
+			return ErrSKUExists
+		}
+		for _, barcode := range stored.Barcodes {
+			if barcodes[barcode] {
+				return ErrBarcodeExists
+			}
+		}
+	}
+	return nil
+}
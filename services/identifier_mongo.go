@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Names of the unique indexes on item identifiers, which tell apart the
+// duplicate key errors they raise.
+const (
+	mongoSKUIndex     = "inventory_sku"
+	mongoBarcodeIndex = "inventory_barcodes"
+)
+
+func (s *MongoStore) GetItemBySKU(ctx context.Context, sku string) (*models.Inventory, error) {
+	return s.findItem(ctx, bson.M{"sku": sku})
+}
+
+// GetItemByBarcode matches gtin against the elements of the barcodes array.
+func (s *MongoStore) GetItemByBarcode(ctx context.Context, gtin string) (*models.Inventory, error) {
+	return s.findItem(ctx, bson.M{"barcodes": gtin})
+}
+
+func (s *MongoStore) findItem(ctx context.Context, filter bson.M) (*models.Inventory, error) {
+	var item models.Inventory
+
+	err := s.Collection.FindOne(ctx, filter).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrItemNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching inventory item: %v", err)
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// identifierError maps a duplicate key error on the SKU or barcode index
+// to ErrSKUExists or ErrBarcodeExists, returning other errors unchanged.
+func identifierError(err error) error {
+	if !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	switch {
+	case strings.Contains(err.Error(), mongoSKUIndex):
+		return ErrSKUExists
+	case strings.Contains(err.Error(), mongoBarcodeIndex):
+		return ErrBarcodeExists
+	}
+	return err
+}
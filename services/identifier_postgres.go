@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log"
+	"main/models"
+)
+
+func (s *PostgresStore) GetItemBySKU(ctx context.Context, sku string) (*models.Inventory, error) {
+	return s.getItemWhere(ctx, "sku = ?", sku)
+}
+
+func (s *PostgresStore) GetItemByBarcode(ctx context.Context, gtin string) (*models.Inventory, error) {
+	return s.getItemWhere(ctx, "id = (SELECT item_id FROM inventory_barcodes WHERE gtin = ?)", gtin)
+}
+
+func (s *PostgresStore) getItemWhere(ctx context.Context, condition string, arg interface{}) (*models.Inventory, error) {
+	var item models.Inventory
+
+	if s.DB == nil {
+		log.Println("Error: PostgreSQL database connection is not initialized.")
+		return nil, errPostgresNotInitialized
+	}
+
+	query := `SELECT ` + inventoryColumns + ` FROM inventories WHERE ` + condition
+	result := s.conn(ctx).Raw(query, arg).Scan(&item)
+	if result.Error != nil {
+		log.Printf("Error fetching inventory item from PostgreSQL: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrItemNotFound
+	}
+
+	if err := s.loadBarcodes(ctx, []*models.Inventory{&item}); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// checkItemIdentifiers fails if an item other than id already has the SKU
+// or one of the barcodes of item. An empty id stands for a new item. The
+// unique indexes back the check up against concurrent writers.
+func (s *PostgresStore) checkItemIdentifiers(ctx context.Context, id string, item *models.Inventory) error {
+	if item.SKU != "" {
+		conditions, args := []string{"sku = ?"}, []interface{}{item.SKU}
+		if id != "" {
+			conditions, args = append(conditions, "id <> ?"), append(args, id)
+		}
+		var count int64
+		query := `SELECT COUNT(*) FROM inventories` + whereClause(conditions)
+		if err := s.conn(ctx).Raw(query, args...).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrSKUExists
+		}
+	}
+
+	if len(item.Barcodes) > 0 {
+		conditions, args := []string{"gtin IN ?"}, []interface{}{item.Barcodes}
+		if id != "" {
+			conditions, args = append(conditions, "item_id <> ?"), append(args, id)
+		}
+		var count int64
+		query := `SELECT COUNT(*) FROM inventory_barcodes` + whereClause(conditions)
+		if err := s.conn(ctx).Raw(query, args...).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrBarcodeExists
+		}
+	}
+	return nil
+}
+
+// saveBarcodes replaces the barcodes of the item.
+func (s *PostgresStore) saveBarcodes(ctx context.Context, itemID string, barcodes []string) error {
+	if err := s.conn(ctx).Exec(`DELETE FROM inventory_barcodes WHERE item_id = ?`, itemID).Error; err != nil {
+		return err
+	}
+	for i, gtin := range barcodes {
+		query := `INSERT INTO inventory_barcodes (gtin, item_id, position) VALUES (?, ?, ?)`
+		if err := s.conn(ctx).Exec(query, gtin, itemID, i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBarcodes fills in the barcodes of items with a single query.
+func (s *PostgresStore) loadBarcodes(ctx context.Context, items []*models.Inventory) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Inventory, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	var barcodes []struct {
+		ItemID string `gorm:"column:item_id"`
+		GTIN   string `gorm:"column:gtin"`
+	}
+	query := `SELECT item_id, gtin FROM inventory_barcodes WHERE item_id IN ? ORDER BY item_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&barcodes).Error; err != nil {
+		log.Printf("Error fetching inventory barcodes: %v", err)
+		return err
+	}
+
+	for _, barcode := range barcodes {
+		item := byID[barcode.ItemID]
+		item.Barcodes = append(item.Barcodes, barcode.GTIN)
+	}
+	return nil
+}
+
+func resultItems(results []models.SearchResult) []*models.Inventory {
+	items := make([]*models.Inventory, len(results))
+	for i := range results {
+		items[i] = results[i].Item
+	}
+	return items
+}
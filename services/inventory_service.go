@@ -4,18 +4,235 @@ import (
 	"context"
 	"errors"
 	"log"
-	"main/config"
 	"main/models"
+	"regexp"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
+// MongoStore keeps inventory items in a MongoDB collection. Item IDs are
+// ObjectID hex strings stored as the document _id.
+type MongoStore struct {
+	Collection *mongo.Collection
+}
+
+func NewMongoStore(collection *mongo.Collection) *MongoStore {
+	return &MongoStore{Collection: collection}
+}
+
+// WithTransaction runs fn in a multi-document transaction, which requires
+// MongoDB to run as a replica set. Collection calls made with the context
+// passed to fn join the transaction; nested calls join the outer one.
+func (s *MongoStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := s.Collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return err
+}
+
+// EnsureIndexes creates the indexes the store relies on. It is safe to call
+// on every startup.
+func (s *MongoStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "product_name", Value: "text"}, {Key: "vendor", Value: "text"}},
+		Options: options.Index().
+			SetName("inventory_text_search").
+			SetWeights(bson.D{{Key: "product_name", Value: 2}, {Key: "vendor", Value: 1}}).
+			SetDefaultLanguage("none"),
+	})
+	if err != nil {
+		log.Printf("Error creating inventory indexes: %v", err)
+		return err
+	}
+
+	// Items without a SKU or barcodes stay out of the unique indexes.
+	_, err = s.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "sku", Value: 1}},
+			Options: options.Index().
+				SetName(mongoSKUIndex).
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"sku": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys: bson.D{{Key: "barcodes", Value: 1}},
+			Options: options.Index().
+				SetName(mongoBarcodeIndex).
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"barcodes": bson.M{"$type": "string"}}),
+		},
+	})
+	if err != nil {
+		log.Printf("Error creating inventory identifier indexes: %v", err)
+		return err
+	}
+
+	_, err = s.movements().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "item_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating stock movement indexes: %v", err)
+		return err
+	}
+
+	_, err = s.stockLevels().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "location_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "location_id", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating stock level indexes: %v", err)
+		return err
+	}
+
+	_, err = s.locations().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "parent_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating location indexes: %v", err)
+		return err
+	}
+
+	_, err = s.transfers().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "item_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating transfer indexes: %v", err)
+		return err
+	}
+
+	_, err = s.reservations().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "item_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating reservation indexes: %v", err)
+		return err
+	}
+
+	_, err = s.alerts().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// An item has at most one open alert.
+			Keys: bson.D{{Key: "item_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": models.AlertOpen}),
+		},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating low-stock alert indexes: %v", err)
+		return err
+	}
+
+	_, err = s.purchaseOrders().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "vendor", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "lines.item_id", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating purchase order indexes: %v", err)
+		return err
+	}
+
+	_, err = s.salesOrders().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "customer", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "lines.item_id", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating sales order indexes: %v", err)
+		return err
+	}
+
+	_, err = s.returns().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "sales_order_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating return indexes: %v", err)
+		return err
+	}
+
+	_, err = s.bomComponents().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}, {Key: "component_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "component_id", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating bill of materials indexes: %v", err)
+		return err
+	}
+
+	_, err = s.lots().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "lot_number", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating lot indexes: %v", err)
+		return err
+	}
+
+	_, err = s.serials().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "serial_number", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "item_id", Value: 1}, {Key: "status", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("Error creating serial indexes: %v", err)
+		return err
+	}
+
+	_, err = s.categories().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "position", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating category indexes: %v", err)
+		return err
+	}
+
+	_, err = s.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "category_id", Value: 1}},
+	})
+	if err != nil {
+		log.Printf("Error creating inventory category index: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *MongoStore) CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
 	item.SetMongoDB()
-	_, err := config.InventoryCollection.InsertOne(ctx, item)
+	item.OnHand, item.Reserved = 0, 0
+	_, err := s.Collection.InsertOne(ctx, item)
 	if err != nil {
+		if err := identifierError(err); err == ErrSKUExists || err == ErrBarcodeExists {
+			return nil, err
+		}
 		log.Printf("Error inserting inventory item: %v", err)
 		return nil, err
 	}
@@ -23,40 +240,134 @@ func CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory,
 	return item, nil
 }
 
-func GetItems(ctx context.Context) ([]*models.Inventory, int64, error) {
+func (s *MongoStore) GetItems(ctx context.Context, query models.ItemQuery) (*models.ItemPage, error) {
 	var items []*models.Inventory
-	var totalCount int64
 
-	cursor, err := config.InventoryCollection.Find(ctx, bson.D{})
+	cursor, err := decodeCursor(query.Cursor, query.Sort)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	if err := cursor.All(ctx, &items); err != nil {
-		return nil, 0, err
+	filter := itemFilterBSON(query.Filter)
+
+	totalCount, err := s.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != nil {
+		filter = append(filter, keysetBSON(cursor, query.Sort))
+	}
+
+	sort := bson.D{}
+	for _, field := range query.Sort {
+		direction := 1
+		if field.Desc {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: field.Field, Value: direction})
 	}
+	sort = append(sort, bson.E{Key: "_id", Value: 1})
 
-	totalCount, err = config.InventoryCollection.CountDocuments(ctx, bson.D{})
+	findOptions := options.Find().
+		SetSort(sort).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit + 1))
+
+	result, err := s.Collection.Find(ctx, filter, findOptions)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	defer result.Close(ctx)
+
+	if err := result.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return newPage(items, totalCount, query), nil
+}
+
+func itemFilterBSON(filter models.ItemFilter) bson.D {
+	conditions := bson.D{}
+
+	if filter.Vendor != "" {
+		conditions = append(conditions, bson.E{Key: "vendor", Value: filter.Vendor})
+	}
+	if filter.Currency != "" {
+		conditions = append(conditions, bson.E{Key: "currency", Value: filter.Currency})
+	}
+	if filter.PriceMin != nil || filter.PriceMax != nil {
+		price := bson.M{}
+		if filter.PriceMin != nil {
+			price["$gte"] = *filter.PriceMin
+		}
+		if filter.PriceMax != nil {
+			price["$lte"] = *filter.PriceMax
+		}
+		conditions = append(conditions, bson.E{Key: "price", Value: price})
+	}
+	if filter.DiscountGT != nil {
+		conditions = append(conditions, bson.E{Key: "discount", Value: bson.M{"$gt": *filter.DiscountGT}})
+	}
+	if filter.NameContains != "" {
+		conditions = append(conditions, bson.E{Key: "product_name", Value: primitive.Regex{
+			Pattern: regexp.QuoteMeta(filter.NameContains),
+			Options: "i",
+		}})
+	}
+	if len(filter.CategoryIDs) > 0 {
+		conditions = append(conditions, bson.E{Key: "category_id", Value: bson.M{"$in": filter.CategoryIDs}})
+	}
+	if filter.BelowReorderPoint {
+		reorderPoint := bson.M{"$ifNull": bson.A{"$reorder_point", 0}}
+		available := bson.M{"$subtract": bson.A{
+			bson.M{"$ifNull": bson.A{"$on_hand", 0}},
+			bson.M{"$ifNull": bson.A{"$reserved", 0}},
+		}}
+		conditions = append(conditions, bson.E{Key: "$expr", Value: bson.M{"$and": bson.A{
+			bson.M{"$gt": bson.A{reorderPoint, 0}},
+			bson.M{"$lt": bson.A{available, reorderPoint}},
+		}}})
+	}
+
+	return conditions
+}
+
+// keysetBSON is the BSON form of keysetSQL.
+func keysetBSON(cursor *pageCursor, sort []models.SortField) bson.E {
+	var terms bson.A
+
+	for i := 0; i <= len(sort); i++ {
+		term := bson.D{}
+		for j := 0; j < i; j++ {
+			term = append(term, bson.E{Key: sort[j].Field, Value: cursor.Values[j]})
+		}
+		if i < len(sort) {
+			op := "$gt"
+			if sort[i].Desc {
+				op = "$lt"
+			}
+			term = append(term, bson.E{Key: sort[i].Field, Value: bson.M{op: cursor.Values[i]}})
+		} else {
+			term = append(term, bson.E{Key: "_id", Value: bson.M{"$gt": cursor.ID}})
+		}
+		terms = append(terms, term)
 	}
 
-	return items, totalCount, nil
+	return bson.E{Key: "$or", Value: terms}
 }
 
-func GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
+func (s *MongoStore) GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
 	var item models.Inventory
 
-	// objectID, err := primitive.ObjectIDFromHex(id)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrItemNotFound
+	}
 
-	err := config.InventoryCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	err := s.Collection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("inventory item not found")
+			return nil, ErrItemNotFound
 		}
 		log.Printf("Error fetching inventory item by ID: %v", err)
 		return nil, err
@@ -65,39 +376,193 @@ func GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
 	return &item, nil
 }
 
-func UpdateItem(ctx context.Context, id primitive.ObjectID, item *models.Inventory) (*models.Inventory, error) {
-	update := bson.M{"$set": item}
+func (s *MongoStore) UpdateItem(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrItemNotFound
+	}
 
-	result := config.InventoryCollection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update)
+	update := bson.M{"$set": bson.M{
+		"product_name":     item.Name,
+		"price":            item.Price,
+		"currency":         item.Currency,
+		"discount":         item.Discount,
+		"vendor":           item.Vendor,
+		"reorder_point":    item.ReorderPoint,
+		"reorder_quantity": item.ReorderQuantity,
+		"lot_tracked":      item.LotTracked,
+		"serialized":       item.Serialized,
+		"sku":              item.SKU,
+		"barcodes":         item.Barcodes,
+		"category_id":      item.CategoryID,
+	}}
 
-	if result.Err() != nil {
-		if result.Err().Error() == "mongo: no documents in result" {
-			return nil, errors.New("inventory item not found")
+	var updatedItem models.Inventory
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.Collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&updatedItem)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrItemNotFound
 		}
-		log.Printf("Error updating inventory item: %v", result.Err())
-		return nil, result.Err()
+		if err := identifierError(err); err == ErrSKUExists || err == ErrBarcodeExists {
+			return nil, err
+		}
+		log.Printf("Error updating inventory item: %v", err)
+		return nil, err
 	}
 
-	item.ID = id.Hex()
-	return item, nil
+	return &updatedItem, nil
 }
 
-func DeleteItem(ctx context.Context, id string) error {
-	// objectID, err := primitive.ObjectIDFromHex(id)
-	// if err != nil {
-	// 	log.Printf("Invalid ID format: %v", err)
-	// 	return err
-	// }
+func (s *MongoStore) PatchItem(ctx context.Context, id string, patch models.InventoryPatch) (*models.Inventory, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrItemNotFound
+	}
+
+	set := bson.D{}
+	for _, field := range inventoryPatchColumns(patch) {
+		set = append(set, bson.E{Key: field.column, Value: field.value})
+	}
+	if patch.Barcodes != nil {
+		set = append(set, bson.E{Key: "barcodes", Value: *patch.Barcodes})
+	}
+	if len(set) == 0 {
+		return s.GetItemByID(ctx, id)
+	}
 
-	result, err := config.InventoryCollection.DeleteOne(ctx, bson.M{"_id": id})
+	var patchedItem models.Inventory
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.Collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$set": set}, opts).Decode(&patchedItem)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrItemNotFound
+		}
+		if err := identifierError(err); err == ErrSKUExists || err == ErrBarcodeExists {
+			return nil, err
+		}
+		log.Printf("Error patching inventory item: %v", err)
+		return nil, err
+	}
+
+	return &patchedItem, nil
+}
+
+func (s *MongoStore) AdjustStock(ctx context.Context, id string, onHandDelta, reservedDelta int) (*models.Inventory, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrItemNotFound
+	}
+
+	onHand := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$on_hand", 0}}, onHandDelta}}
+	reserved := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$reserved", 0}}, reservedDelta}}
+	filter := bson.M{
+		"_id": id,
+		"$expr": bson.M{"$and": bson.A{
+			bson.M{"$gte": bson.A{onHand, 0}},
+			bson.M{"$gte": bson.A{reserved, 0}},
+			bson.M{"$lte": bson.A{reserved, onHand}},
+		}},
+	}
+	update := bson.M{"$inc": bson.M{"on_hand": onHandDelta, "reserved": reservedDelta}}
+
+	var adjustedItem models.Inventory
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.Collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&adjustedItem)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := s.GetItemByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+	if err != nil {
+		log.Printf("Error adjusting stock: %v", err)
+		return nil, err
+	}
+
+	return &adjustedItem, nil
+}
+
+func (s *MongoStore) DeleteItem(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrItemNotFound
+	}
+
+	result, err := s.Collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
 		log.Printf("Error deleting inventory item: %v", err)
 		return err
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("inventory item not found")
+		return ErrItemNotFound
+	}
+
+	if _, err := s.stockLevels().DeleteMany(ctx, bson.M{"item_id": id}); err != nil {
+		log.Printf("Error deleting stock levels: %v", err)
+		return err
+	}
+	if _, err := s.bomComponents().DeleteMany(ctx, bson.M{"parent_id": id}); err != nil {
+		log.Printf("Error deleting bill of materials: %v", err)
+		return err
+	}
+	if _, err := s.lots().DeleteMany(ctx, bson.M{"item_id": id}); err != nil {
+		log.Printf("Error deleting lots: %v", err)
+		return err
+	}
+	if _, err := s.serials().DeleteMany(ctx, bson.M{"item_id": id}); err != nil {
+		log.Printf("Error deleting serials: %v", err)
+		return err
 	}
 
 	return nil
 }
+
+// SearchItems combines the text index, which matches whole words, with a
+// scan for words starting like each term, which the shared scoring then
+// checks for prefixes and typos. The text score breaks ties.
+func (s *MongoStore) SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var textMatches []struct {
+		models.Inventory `bson:",inline"`
+		Score            float64 `bson:"score"`
+	}
+	textOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetLimit(searchCandidateLimit)
+	result, err := s.Collection.Find(ctx, bson.M{"$text": bson.M{"$search": strings.Join(terms, " ")}}, textOptions)
+	if err != nil {
+		log.Printf("Error running text search: %v", err)
+		return nil, err
+	}
+	if err := result.All(ctx, &textMatches); err != nil {
+		return nil, err
+	}
+
+	var prefixFilters bson.A
+	for _, term := range terms {
+		pattern := primitive.Regex{Pattern: `\b` + regexp.QuoteMeta(candidatePrefix(term)), Options: "i"}
+		prefixFilters = append(prefixFilters, bson.M{"product_name": pattern}, bson.M{"vendor": pattern})
+	}
+	var prefixMatches []*models.Inventory
+	result, err = s.Collection.Find(ctx, bson.M{"$or": prefixFilters}, options.Find().SetLimit(searchCandidateLimit))
+	if err != nil {
+		log.Printf("Error running prefix search: %v", err)
+		return nil, err
+	}
+	if err := result.All(ctx, &prefixMatches); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*models.Inventory, 0, len(textMatches)+len(prefixMatches))
+	boost := make(map[string]float64, len(textMatches))
+	for i := range textMatches {
+		candidates = append(candidates, &textMatches[i].Inventory)
+		boost[textMatches[i].ID] = textMatches[i].Score / 10
+	}
+	candidates = append(candidates, prefixMatches...)
+
+	return rankItems(candidates, terms, boost, limit), nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error) {
+	defer s.lock(ctx)()
+
+	location.ID = uuid.New().String()
+	stored := *location
+	s.data.locations[location.ID] = &stored
+
+	return location, nil
+}
+
+func (s *MemoryStore) GetLocations(ctx context.Context) ([]*models.Location, error) {
+	defer s.rlock(ctx)()
+
+	locations := make([]*models.Location, 0, len(s.data.locations))
+	for _, stored := range s.data.locations {
+		location := *stored
+		locations = append(locations, &location)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Name != locations[j].Name {
+			return locations[i].Name < locations[j].Name
+		}
+		return locations[i].ID < locations[j].ID
+	})
+
+	return locations, nil
+}
+
+func (s *MemoryStore) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.locations[id]
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	location := *stored
+	return &location, nil
+}
+
+func (s *MemoryStore) DeleteLocation(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.locations[id]; !ok {
+		return ErrLocationNotFound
+	}
+
+	delete(s.data.locations, id)
+	for key := range s.data.stockLevels {
+		if key.locationID == id {
+			delete(s.data.stockLevels, key)
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) AdjustLocationStock(ctx context.Context, itemID, locationID string, delta int) (*models.StockLevel, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.items[itemID]; !ok {
+		return nil, ErrItemNotFound
+	}
+	if _, ok := s.data.locations[locationID]; !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	key := stockLevelKey{itemID: itemID, locationID: locationID}
+	onHand := s.data.stockLevels[key] + delta
+	if onHand < 0 {
+		return nil, ErrInsufficientStock
+	}
+	s.data.stockLevels[key] = onHand
+
+	return &models.StockLevel{ItemID: itemID, LocationID: locationID, OnHand: onHand}, nil
+}
+
+func (s *MemoryStore) GetStockLevels(ctx context.Context, query models.StockLevelQuery) ([]*models.StockLevel, error) {
+	defer s.rlock(ctx)()
+
+	itemIDs, locationIDs := stringSet(query.ItemIDs), stringSet(query.LocationIDs)
+
+	var levels []*models.StockLevel
+	for key, onHand := range s.data.stockLevels {
+		if onHand <= 0 {
+			continue
+		}
+		if itemIDs != nil && !itemIDs[key.itemID] || locationIDs != nil && !locationIDs[key.locationID] {
+			continue
+		}
+		levels = append(levels, &models.StockLevel{ItemID: key.itemID, LocationID: key.locationID, OnHand: onHand})
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if levels[i].ItemID != levels[j].ItemID {
+			return levels[i].ItemID < levels[j].ItemID
+		}
+		return levels[i].LocationID < levels[j].LocationID
+	})
+
+	return levels, nil
+}
+
+// stringSet returns the values as a set, or nil when there are none.
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) locations() *mongo.Collection {
+	return s.Collection.Database().Collection("locations")
+}
+
+func (s *MongoStore) stockLevels() *mongo.Collection {
+	return s.Collection.Database().Collection("stock_levels")
+}
+
+func (s *MongoStore) CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error) {
+	location.ID = primitive.NewObjectID().Hex()
+	if _, err := s.locations().InsertOne(ctx, location); err != nil {
+		log.Printf("Error inserting location: %v", err)
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (s *MongoStore) GetLocations(ctx context.Context) ([]*models.Location, error) {
+	var locations []*models.Location
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.locations().Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &locations); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (s *MongoStore) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	var location models.Location
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrLocationNotFound
+	}
+
+	err := s.locations().FindOne(ctx, bson.M{"_id": id}).Decode(&location)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrLocationNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching location: %v", err)
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func (s *MongoStore) DeleteLocation(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrLocationNotFound
+	}
+
+	result, err := s.locations().DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		log.Printf("Error deleting location: %v", err)
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrLocationNotFound
+	}
+
+	if _, err := s.stockLevels().DeleteMany(ctx, bson.M{"location_id": id}); err != nil {
+		log.Printf("Error deleting stock levels: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *MongoStore) AdjustLocationStock(ctx context.Context, itemID, locationID string, delta int) (*models.StockLevel, error) {
+	var level models.StockLevel
+
+	if !primitive.IsValidObjectID(itemID) {
+		return nil, ErrItemNotFound
+	}
+	if !primitive.IsValidObjectID(locationID) {
+		return nil, ErrLocationNotFound
+	}
+
+	filter := bson.M{"item_id": itemID, "location_id": locationID}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"_id": 0})
+	if delta > 0 {
+		opts.SetUpsert(true)
+	} else {
+		filter["on_hand"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{"$inc": bson.M{"on_hand": delta}}
+	err := s.stockLevels().FindOneAndUpdate(ctx, filter, update, opts).Decode(&level)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInsufficientStock
+	}
+	if err != nil {
+		log.Printf("Error adjusting location stock: %v", err)
+		return nil, err
+	}
+
+	return &level, nil
+}
+
+func (s *MongoStore) GetStockLevels(ctx context.Context, query models.StockLevelQuery) ([]*models.StockLevel, error) {
+	var levels []*models.StockLevel
+
+	filter := bson.M{"on_hand": bson.M{"$gt": 0}}
+	if len(query.ItemIDs) > 0 {
+		filter["item_id"] = bson.M{"$in": query.ItemIDs}
+	}
+	if len(query.LocationIDs) > 0 {
+		filter["location_id"] = bson.M{"$in": query.LocationIDs}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "item_id", Value: 1}, {Key: "location_id", Value: 1}}).
+		SetProjection(bson.M{"_id": 0})
+	cursor, err := s.stockLevels().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &levels); err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
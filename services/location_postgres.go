@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const locationColumns = "id, name, type, parent_id, quarantine"
+
+func (s *PostgresStore) CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error) {
+	location.ID = uuid.New().String()
+
+	query := `INSERT INTO locations (` + locationColumns + `) VALUES (?, ?, ?, ?, ?)`
+	err := s.conn(ctx).Exec(query, location.ID, location.Name, location.Type, location.ParentID, location.Quarantine).Error
+	if err != nil {
+		log.Printf("Error inserting location: %v", err)
+		return nil, fmt.Errorf("error inserting location: %w", err)
+	}
+
+	return location, nil
+}
+
+func (s *PostgresStore) GetLocations(ctx context.Context) ([]*models.Location, error) {
+	var locations []*models.Location
+
+	query := `SELECT ` + locationColumns + ` FROM locations ORDER BY name, id`
+	if err := s.conn(ctx).Raw(query).Scan(&locations).Error; err != nil {
+		log.Printf("Error fetching locations: %v", err)
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (s *PostgresStore) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
+	var location models.Location
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrLocationNotFound
+	}
+
+	query := `SELECT ` + locationColumns + ` FROM locations WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&location)
+	if result.Error != nil {
+		log.Printf("Error fetching location: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return &location, nil
+}
+
+func (s *PostgresStore) DeleteLocation(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrLocationNotFound
+	}
+
+	// Empty stock levels would otherwise block the delete.
+	if err := s.conn(ctx).Exec(`DELETE FROM stock_levels WHERE location_id = ?`, id).Error; err != nil {
+		log.Printf("Error deleting stock levels: %v", err)
+		return err
+	}
+
+	result := s.conn(ctx).Exec(`DELETE FROM locations WHERE id = ?`, id)
+	if result.Error != nil {
+		log.Printf("Error deleting location: %v", result.Error)
+		return fmt.Errorf("error deleting location: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrLocationNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) AdjustLocationStock(ctx context.Context, itemID, locationID string, delta int) (*models.StockLevel, error) {
+	var level models.StockLevel
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return nil, ErrItemNotFound
+	}
+	if _, err := uuid.Parse(locationID); err != nil {
+		return nil, ErrLocationNotFound
+	}
+
+	query := `UPDATE stock_levels SET on_hand = on_hand + ?
+				WHERE item_id = ? AND location_id = ? AND on_hand + ? >= 0
+				RETURNING item_id, location_id, on_hand`
+	args := []interface{}{delta, itemID, locationID, delta}
+	if delta > 0 {
+		query = `INSERT INTO stock_levels (item_id, location_id, on_hand) VALUES (?, ?, ?)
+				ON CONFLICT (item_id, location_id) DO UPDATE SET on_hand = stock_levels.on_hand + excluded.on_hand
+				RETURNING item_id, location_id, on_hand`
+		args = []interface{}{itemID, locationID, delta}
+	}
+
+	result := s.conn(ctx).Raw(query, args...).Scan(&level)
+	if result.Error != nil {
+		log.Printf("Error adjusting location stock: %v", result.Error)
+		return nil, fmt.Errorf("error adjusting location stock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientStock
+	}
+
+	return &level, nil
+}
+
+func (s *PostgresStore) GetStockLevels(ctx context.Context, query models.StockLevelQuery) ([]*models.StockLevel, error) {
+	var levels []*models.StockLevel
+
+	conditions := []string{"on_hand > 0"}
+	var args []interface{}
+	filters := []struct {
+		column string
+		ids    []string
+	}{{"item_id", query.ItemIDs}, {"location_id", query.LocationIDs}}
+	for _, filter := range filters {
+		if len(filter.ids) == 0 {
+			continue
+		}
+		valid := validUUIDs(filter.ids)
+		if len(valid) == 0 {
+			return nil, nil
+		}
+		conditions = append(conditions, filter.column+" IN ?")
+		args = append(args, valid)
+	}
+
+	selectQuery := `SELECT item_id, location_id, on_hand FROM stock_levels WHERE ` +
+		strings.Join(conditions, " AND ") + ` ORDER BY item_id, location_id`
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&levels).Error; err != nil {
+		log.Printf("Error fetching stock levels: %v", err)
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+// validUUIDs drops the IDs that are not UUIDs, which Postgres would reject.
+func validUUIDs(ids []string) []string {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateLot(ctx context.Context, lot *models.Lot) (*models.Lot, error) {
+	defer s.lock(ctx)()
+
+	for _, stored := range s.data.lots {
+		if stored.ItemID == lot.ItemID && stored.LotNumber == lot.LotNumber {
+			return nil, ErrLotExists
+		}
+	}
+
+	lot.ID = uuid.New().String()
+	stored := *lot
+	s.data.lots[lot.ID] = &stored
+
+	return lot, nil
+}
+
+func (s *MemoryStore) GetLots(ctx context.Context, query models.LotQuery) ([]*models.Lot, int64, error) {
+	defer s.rlock(ctx)()
+
+	itemIDs := stringSet(query.ItemIDs)
+
+	var matched []*models.Lot
+	for _, stored := range s.data.lots {
+		if itemIDs != nil && !itemIDs[stored.ItemID] {
+			continue
+		}
+		if query.LotNumber != "" && stored.LotNumber != query.LotNumber {
+			continue
+		}
+		if query.ExpiresBefore != nil && (stored.ExpiresAt == nil || !stored.ExpiresAt.Before(*query.ExpiresBefore)) {
+			continue
+		}
+		if query.InStock && stored.OnHand <= 0 {
+			continue
+		}
+		lot := *stored
+		matched = append(matched, &lot)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if (a.ExpiresAt == nil) != (b.ExpiresAt == nil) {
+			return b.ExpiresAt == nil
+		}
+		if a.ExpiresAt != nil && !a.ExpiresAt.Equal(*b.ExpiresAt) {
+			return a.ExpiresAt.Before(*b.ExpiresAt)
+		}
+		if a.LotNumber != b.LotNumber {
+			return a.LotNumber < b.LotNumber
+		}
+		return a.ID < b.ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Limit == 0 {
+		return matched, totalCount, nil
+	}
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetLotByID(ctx context.Context, id string) (*models.Lot, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.lots[id]
+	if !ok {
+		return nil, ErrLotNotFound
+	}
+
+	lot := *stored
+	return &lot, nil
+}
+
+func (s *MemoryStore) AdjustLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.lots[id]
+	if !ok {
+		return nil, ErrLotNotFound
+	}
+	if stored.OnHand+delta < stored.Quarantined {
+		return nil, ErrInsufficientStock
+	}
+	stored.OnHand += delta
+
+	lot := *stored
+	return &lot, nil
+}
+
+func (s *MemoryStore) HoldLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.lots[id]
+	if !ok {
+		return nil, ErrLotNotFound
+	}
+	if stored.Quarantined+delta < 0 || stored.Quarantined+delta > stored.OnHand {
+		return nil, ErrInsufficientStock
+	}
+	stored.Quarantined += delta
+
+	lot := *stored
+	return &lot, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) lots() *mongo.Collection {
+	return s.Collection.Database().Collection("lots")
+}
+
+func (s *MongoStore) CreateLot(ctx context.Context, lot *models.Lot) (*models.Lot, error) {
+	lot.ID = primitive.NewObjectID().Hex()
+	if _, err := s.lots().InsertOne(ctx, lot); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrLotExists
+		}
+		log.Printf("Error inserting lot: %v", err)
+		return nil, err
+	}
+
+	return lot, nil
+}
+
+func (s *MongoStore) GetLots(ctx context.Context, query models.LotQuery) ([]*models.Lot, int64, error) {
+	var lots []*models.Lot
+
+	filter := bson.M{}
+	if len(query.ItemIDs) > 0 {
+		filter["item_id"] = bson.M{"$in": query.ItemIDs}
+	}
+	if query.LotNumber != "" {
+		filter["lot_number"] = query.LotNumber
+	}
+	if query.ExpiresBefore != nil {
+		filter["expires_at"] = bson.M{"$ne": nil, "$lt": *query.ExpiresBefore}
+	}
+	if query.InStock {
+		filter["on_hand"] = bson.M{"$gt": 0}
+	}
+
+	totalCount, err := s.lots().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Mongo sorts missing expiry dates first, so sort on whether there is
+	// one before sorting on the date.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"no_expiry": bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$expires_at", nil}}, nil}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "no_expiry", Value: 1}, {Key: "expires_at", Value: 1}, {Key: "lot_number", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$skip", Value: int64(query.Offset)}},
+			bson.D{{Key: "$limit", Value: int64(query.Limit)}})
+	}
+	cursor, err := s.lots().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &lots); err != nil {
+		return nil, 0, err
+	}
+
+	return lots, totalCount, nil
+}
+
+func (s *MongoStore) GetLotByID(ctx context.Context, id string) (*models.Lot, error) {
+	var lot models.Lot
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrLotNotFound
+	}
+
+	err := s.lots().FindOne(ctx, bson.M{"_id": id}).Decode(&lot)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrLotNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching lot: %v", err)
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+func (s *MongoStore) AdjustLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	var lot models.Lot
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrLotNotFound
+	}
+
+	filter := bson.M{"_id": id, "$expr": bson.M{"$gte": bson.A{
+		bson.M{"$add": bson.A{"$on_hand", delta}},
+		bson.M{"$ifNull": bson.A{"$quarantined", 0}},
+	}}}
+	update := bson.M{"$inc": bson.M{"on_hand": delta}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.lots().FindOneAndUpdate(ctx, filter, update, opts).Decode(&lot)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := s.GetLotByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+	if err != nil {
+		log.Printf("Error adjusting lot stock: %v", err)
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+func (s *MongoStore) HoldLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	var lot models.Lot
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrLotNotFound
+	}
+
+	quarantined := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$quarantined", 0}}, delta}}
+	filter := bson.M{"_id": id, "$expr": bson.M{"$and": bson.A{
+		bson.M{"$gte": bson.A{quarantined, 0}},
+		bson.M{"$lte": bson.A{quarantined, "$on_hand"}},
+	}}}
+	update := bson.M{"$inc": bson.M{"quarantined": delta}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := s.lots().FindOneAndUpdate(ctx, filter, update, opts).Decode(&lot)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if _, err := s.GetLotByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+	if err != nil {
+		log.Printf("Error holding lot stock: %v", err)
+		return nil, err
+	}
+
+	return &lot, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const lotColumns = "id, item_id, lot_number, manufactured_at, expires_at, on_hand, quarantined, created_at"
+
+func (s *PostgresStore) CreateLot(ctx context.Context, lot *models.Lot) (*models.Lot, error) {
+	lot.ID = uuid.New().String()
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		var count int64
+		countQuery := `SELECT COUNT(*) FROM lots WHERE item_id = ? AND lot_number = ?`
+		if err := s.conn(ctx).Raw(countQuery, lot.ItemID, lot.LotNumber).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrLotExists
+		}
+
+		query := `INSERT INTO lots (` + lotColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		return s.conn(ctx).Exec(query, lot.ID, lot.ItemID, lot.LotNumber, lot.ManufacturedAt, lot.ExpiresAt,
+			lot.OnHand, lot.Quarantined, lot.CreatedAt).Error
+	})
+	if err == ErrLotExists {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("Error inserting lot: %v", err)
+		return nil, fmt.Errorf("error inserting lot: %w", err)
+	}
+
+	return lot, nil
+}
+
+func (s *PostgresStore) GetLots(ctx context.Context, query models.LotQuery) ([]*models.Lot, int64, error) {
+	var lots []*models.Lot
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if len(query.ItemIDs) > 0 {
+		valid := validUUIDs(query.ItemIDs)
+		if len(valid) == 0 {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "item_id IN ?")
+		args = append(args, valid)
+	}
+	if query.LotNumber != "" {
+		conditions = append(conditions, "lot_number = ?")
+		args = append(args, query.LotNumber)
+	}
+	if query.ExpiresBefore != nil {
+		conditions = append(conditions, "expires_at < ?")
+		args = append(args, *query.ExpiresBefore)
+	}
+	if query.InStock {
+		conditions = append(conditions, "on_hand > 0")
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM lots` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting lots: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + lotColumns + ` FROM lots` + where +
+		` ORDER BY expires_at IS NULL, expires_at, lot_number, id`
+	if query.Limit > 0 {
+		selectQuery += ` LIMIT ? OFFSET ?`
+		args = append(args, query.Limit, query.Offset)
+	}
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&lots).Error; err != nil {
+		log.Printf("Error fetching lots: %v", err)
+		return nil, 0, err
+	}
+
+	return lots, totalCount, nil
+}
+
+func (s *PostgresStore) GetLotByID(ctx context.Context, id string) (*models.Lot, error) {
+	var lot models.Lot
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrLotNotFound
+	}
+
+	query := `SELECT ` + lotColumns + ` FROM lots WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&lot)
+	if result.Error != nil {
+		log.Printf("Error fetching lot: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrLotNotFound
+	}
+
+	return &lot, nil
+}
+
+func (s *PostgresStore) AdjustLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	var lot models.Lot
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrLotNotFound
+	}
+
+	query := `UPDATE lots SET on_hand = on_hand + ? WHERE id = ? AND on_hand + ? >= quarantined RETURNING ` + lotColumns
+	result := s.conn(ctx).Raw(query, delta, id, delta).Scan(&lot)
+	if result.Error != nil {
+		log.Printf("Error adjusting lot stock: %v", result.Error)
+		return nil, fmt.Errorf("error adjusting lot stock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.GetLotByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+
+	return &lot, nil
+}
+
+func (s *PostgresStore) HoldLotStock(ctx context.Context, id string, delta int) (*models.Lot, error) {
+	var lot models.Lot
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrLotNotFound
+	}
+
+	query := `UPDATE lots SET quarantined = quarantined + ?
+				WHERE id = ? AND quarantined + ? >= 0 AND quarantined + ? <= on_hand RETURNING ` + lotColumns
+	result := s.conn(ctx).Raw(query, delta, id, delta, delta).Scan(&lot)
+	if result.Error != nil {
+		log.Printf("Error holding lot stock: %v", result.Error)
+		return nil, fmt.Errorf("error holding lot stock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.GetLotByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+
+	return &lot, nil
+}
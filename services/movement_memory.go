@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	defer s.lock(ctx)()
+
+	movement.ID = uuid.New().String()
+	stored := *movement
+	stored.Lots = append([]models.MovementLot(nil), movement.Lots...)
+	stored.SerialNumbers = append([]string(nil), movement.SerialNumbers...)
+	s.data.movements = append(s.data.movements, &stored)
+
+	return movement, nil
+}
+
+func (s *MemoryStore) ListMovements(ctx context.Context, itemID string, query models.MovementQuery) ([]*models.StockMovement, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.StockMovement
+	for _, stored := range s.data.movements {
+		if stored.ItemID == itemID {
+			movement := *stored
+			matched = append(matched, &movement)
+		}
+	}
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) SumMovements(ctx context.Context, itemID string) (int, error) {
+	defer s.rlock(ctx)()
+
+	total := 0
+	for _, movement := range s.data.movements {
+		if movement.ItemID == itemID {
+			total += movement.Delta
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) movements() *mongo.Collection {
+	return s.Collection.Database().Collection("stock_movements")
+}
+
+func (s *MongoStore) CreateMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	movement.ID = primitive.NewObjectID().Hex()
+	if _, err := s.movements().InsertOne(ctx, movement); err != nil {
+		log.Printf("Error inserting stock movement: %v", err)
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+func (s *MongoStore) ListMovements(ctx context.Context, itemID string, query models.MovementQuery) ([]*models.StockMovement, int64, error) {
+	var movements []*models.StockMovement
+
+	filter := bson.M{"item_id": itemID}
+	totalCount, err := s.movements().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.movements().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &movements); err != nil {
+		return nil, 0, err
+	}
+
+	return movements, totalCount, nil
+}
+
+func (s *MongoStore) SumMovements(ctx context.Context, itemID string) (int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"item_id": itemID}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": "$delta"}}}},
+	}
+	cursor, err := s.movements().Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total int `bson:"total"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Total, nil
+}
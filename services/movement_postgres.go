@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+func (s *PostgresStore) CreateMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	movement.ID = uuid.New().String()
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO stock_movements (id, item_id, delta, reason, reference, user_name, location_id, created_at)
+					VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, movement.ID, movement.ItemID, movement.Delta, movement.Reason,
+			movement.Reference, movement.User, movement.LocationID, movement.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+
+		for i, lot := range movement.Lots {
+			query := `INSERT INTO stock_movement_lots (movement_id, lot_id, position, delta) VALUES (?, ?, ?, ?)`
+			if err := s.conn(ctx).Exec(query, movement.ID, lot.LotID, i, lot.Delta).Error; err != nil {
+				return err
+			}
+		}
+		for i, serialNumber := range movement.SerialNumbers {
+			query := `INSERT INTO stock_movement_serials (movement_id, serial_number, position) VALUES (?, ?, ?)`
+			if err := s.conn(ctx).Exec(query, movement.ID, serialNumber, i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error inserting stock movement: %v", err)
+		return nil, fmt.Errorf("error inserting stock movement: %w", err)
+	}
+
+	return movement, nil
+}
+
+func (s *PostgresStore) ListMovements(ctx context.Context, itemID string, query models.MovementQuery) ([]*models.StockMovement, int64, error) {
+	var movements []*models.StockMovement
+	var totalCount int64
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return nil, 0, nil
+	}
+
+	countQuery := `SELECT COUNT(*) FROM stock_movements WHERE item_id = ?`
+	if err := s.conn(ctx).Raw(countQuery, itemID).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting stock movements: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT id, item_id, delta, reason, reference, user_name, location_id, created_at FROM stock_movements
+				WHERE item_id = ? ORDER BY created_at, id LIMIT ? OFFSET ?`
+	if err := s.conn(ctx).Raw(selectQuery, itemID, query.Limit, query.Offset).Scan(&movements).Error; err != nil {
+		log.Printf("Error fetching stock movements: %v", err)
+		return nil, 0, err
+	}
+
+	if err := s.loadMovementLots(ctx, movements); err != nil {
+		return nil, 0, err
+	}
+	if err := s.loadMovementSerials(ctx, movements); err != nil {
+		return nil, 0, err
+	}
+
+	return movements, totalCount, nil
+}
+
+func (s *PostgresStore) loadMovementLots(ctx context.Context, movements []*models.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.StockMovement, len(movements))
+	ids := make([]string, 0, len(movements))
+	for _, movement := range movements {
+		byID[movement.ID] = movement
+		ids = append(ids, movement.ID)
+	}
+
+	var lots []struct {
+		models.MovementLot
+		MovementID string `gorm:"column:movement_id"`
+	}
+	query := `SELECT movement_id, lot_id, delta FROM stock_movement_lots
+				WHERE movement_id IN ? ORDER BY movement_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&lots).Error; err != nil {
+		log.Printf("Error fetching stock movement lots: %v", err)
+		return err
+	}
+
+	for _, lot := range lots {
+		movement := byID[lot.MovementID]
+		movement.Lots = append(movement.Lots, lot.MovementLot)
+	}
+	return nil
+}
+
+func (s *PostgresStore) SumMovements(ctx context.Context, itemID string) (int, error) {
+	var total int
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return 0, nil
+	}
+
+	query := `SELECT COALESCE(SUM(delta), 0) FROM stock_movements WHERE item_id = ?`
+	if err := s.conn(ctx).Raw(query, itemID).Scan(&total).Error; err != nil {
+		log.Printf("Error summing stock movements: %v", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (s *PostgresStore) loadMovementSerials(ctx context.Context, movements []*models.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.StockMovement, len(movements))
+	ids := make([]string, 0, len(movements))
+	for _, movement := range movements {
+		byID[movement.ID] = movement
+		ids = append(ids, movement.ID)
+	}
+
+	var serials []struct {
+		MovementID   string `gorm:"column:movement_id"`
+		SerialNumber string `gorm:"column:serial_number"`
+	}
+	query := `SELECT movement_id, serial_number FROM stock_movement_serials
+				WHERE movement_id IN ? ORDER BY movement_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&serials).Error; err != nil {
+		log.Printf("Error fetching stock movement serials: %v", err)
+		return err
+	}
+
+	for _, serial := range serials {
+		movement := byID[serial.MovementID]
+		movement.SerialNumbers = append(movement.SerialNumbers, serial.SerialNumber)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"main/models"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor is the decoded form of the opaque cursor handed to clients. It
+// records the sort values and ID of the last item of a page so the next page
+// can seek past it. Sort pins the cursor to the sort order it was made for.
+type pageCursor struct {
+	Sort   string        `json:"s,omitempty"`
+	Values []interface{} `json:"v,omitempty"`
+	ID     string        `json:"id"`
+}
+
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes cursor for a query sorted by sort. Numeric sort values
+// are restored to int so they compare correctly in every backend.
+func decodeCursor(cursor string, sort []models.SortField) (*pageCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != models.SortString(sort) || len(c.Values) != len(sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, field := range sort {
+		switch value := c.Values[i].(type) {
+		case float64:
+			if !models.SortableItemFields[field.Field] {
+				return nil, ErrInvalidCursor
+			}
+			c.Values[i] = int(value)
+		case string:
+			if models.SortableItemFields[field.Field] {
+				return nil, ErrInvalidCursor
+			}
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &c, nil
+}
+
+// newPage trims the one extra item that stores fetch beyond the limit and
+// sets NextCursor when that extra item shows more results follow.
+func newPage(items []*models.Inventory, total int64, query models.ItemQuery) *models.ItemPage {
+	page := &models.ItemPage{Items: items, TotalCount: total}
+	if len(items) > query.Limit {
+		page.Items = items[:query.Limit]
+		last := page.Items[query.Limit-1]
+
+		values := make([]interface{}, len(query.Sort))
+		for i, field := range query.Sort {
+			values[i] = itemFieldValue(last, field.Field)
+		}
+		page.NextCursor = encodeCursor(pageCursor{Sort: models.SortString(query.Sort), Values: values, ID: last.ID})
+	}
+	return page
+}
+
+// itemFieldValue returns the value of a sortable item field.
+func itemFieldValue(item *models.Inventory, field string) interface{} {
+	switch field {
+	case "product_name":
+		return item.Name
+	case "price":
+		return item.Price
+	case "currency":
+		return item.Currency
+	case "discount":
+		return item.Discount
+	case "vendor":
+		return item.Vendor
+	default:
+		return nil
+	}
+}
+
+// compareItems orders a and b by sort, then by ID, matching the order used
+// by the database backends.
+func compareItems(a, b *models.Inventory, sort []models.SortField) int {
+	for _, field := range sort {
+		c := compareValues(itemFieldValue(a, field.Field), itemFieldValue(b, field.Field))
+		if field.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// afterCursor reports whether item sorts after the cursor position.
+func afterCursor(item *models.Inventory, cursor *pageCursor, sort []models.SortField) bool {
+	for i, field := range sort {
+		c := compareValues(itemFieldValue(item, field.Field), cursor.Values[i])
+		if field.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c > 0
+		}
+	}
+	return item.ID > cursor.ID
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int:
+		b := b.(int)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(a, b.(string))
+	default:
+		return 0
+	}
+}
+
+// matchesFilter applies filter in Go for backends without a query language.
+func matchesFilter(item *models.Inventory, filter models.ItemFilter) bool {
+	if filter.Vendor != "" && item.Vendor != filter.Vendor {
+		return false
+	}
+	if filter.Currency != "" && item.Currency != filter.Currency {
+		return false
+	}
+	if filter.PriceMin != nil && item.Price < *filter.PriceMin {
+		return false
+	}
+	if filter.PriceMax != nil && item.Price > *filter.PriceMax {
+		return false
+	}
+	if filter.DiscountGT != nil && item.Discount <= *filter.DiscountGT {
+		return false
+	}
+	if filter.NameContains != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(filter.NameContains)) {
+		return false
+	}
+	if filter.BelowReorderPoint && !item.BelowReorderPoint() {
+		return false
+	}
+	if len(filter.CategoryIDs) > 0 && (item.CategoryID == nil || !slices.Contains(filter.CategoryIDs, *item.CategoryID)) {
+		return false
+	}
+	return true
+}
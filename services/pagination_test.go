@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"main/models"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := encodeCursor(pageCursor{ID: "b"})
+	if c, err := decodeCursor(cursor, nil); err != nil || c.ID != "b" {
+		t.Errorf("decodeCursor(encodeCursor(b)) = %+v, %v", c, err)
+	}
+	if c, err := decodeCursor("", nil); c != nil || err != nil {
+		t.Errorf("decodeCursor of no cursor = %+v, %v, want nothing", c, err)
+	}
+
+	tests := []struct {
+		name, cursor string
+	}{
+		{"not base64", "!!!"},
+		{"not JSON", base64.RawURLEncoding.EncodeToString([]byte("id=b"))},
+		{"no ID", base64.RawURLEncoding.EncodeToString([]byte(`{"id":""}`))},
+		{"padded", base64.URLEncoding.EncodeToString([]byte(`{"id":"b"}`))},
+	}
+	for _, tt := range tests {
+		if _, err := decodeCursor(tt.cursor, nil); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: %v, want ErrInvalidCursor", tt.name, err)
+		}
+	}
+}
+
+func TestDecodeSortedCursor(t *testing.T) {
+	sort, err := models.ParseSort("price,-product_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor := encodeCursor(pageCursor{Sort: "price,-product_name", Values: []interface{}{150, "Widget"}, ID: "b"})
+	c, err := decodeCursor(cursor, sort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Numbers come back from JSON as float64 and must compare as int again.
+	if c.ID != "b" || c.Values[0] != 150 || c.Values[1] != "Widget" {
+		t.Errorf("decodeCursor = %+v, want price 150, name Widget and ID b", *c)
+	}
+
+	tests := []struct {
+		name, sort string
+		cursor     pageCursor
+	}{
+		{"other sort", "-price,-product_name", pageCursor{Sort: "price,-product_name", Values: []interface{}{150, "Widget"}, ID: "b"}},
+		{"unsorted cursor", "price", pageCursor{ID: "b"}},
+		{"sorted cursor", "", pageCursor{Sort: "price", Values: []interface{}{150}, ID: "b"}},
+		{"missing value", "price,-product_name", pageCursor{Sort: "price,-product_name", Values: []interface{}{150}, ID: "b"}},
+		{"text for a number", "price", pageCursor{Sort: "price", Values: []interface{}{"150"}, ID: "b"}},
+		{"number for text", "vendor", pageCursor{Sort: "vendor", Values: []interface{}{150}, ID: "b"}},
+		{"null value", "vendor", pageCursor{Sort: "vendor", Values: []interface{}{nil}, ID: "b"}},
+	}
+	for _, tt := range tests {
+		sort, err := models.ParseSort(tt.sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decodeCursor(encodeCursor(tt.cursor), sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: %v, want ErrInvalidCursor", tt.name, err)
+		}
+	}
+}
+
+func createPageItems(t *testing.T, store *MemoryStore, prices ...int) {
+	t.Helper()
+	for _, price := range prices {
+		item := &models.Inventory{Name: "Widget", Price: price, Currency: "USD", Vendor: "Acme"}
+		if _, err := store.CreateItem(context.Background(), item); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pageThrough follows the cursors of query from its first page to its last
+// and returns every item in the order the pages listed them.
+func pageThrough(t *testing.T, store *MemoryStore, query models.ItemQuery) []*models.Inventory {
+	t.Helper()
+	var items []*models.Inventory
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatal("cursors never reached the last page")
+		}
+		page, err := store.GetItems(context.Background(), query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Items) > query.Limit {
+			t.Fatalf("page of %d items, limit %d", len(page.Items), query.Limit)
+		}
+		items = append(items, page.Items...)
+		if page.NextCursor == "" {
+			return items
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func TestCursorPages(t *testing.T) {
+	store := NewMemoryStore()
+	createPageItems(t, store, 100, 200, 300, 400, 500)
+
+	items := pageThrough(t, store, models.ItemQuery{Limit: 2})
+	if len(items) != 5 {
+		t.Fatalf("pages list %d items, want 5", len(items))
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i-1].ID >= items[i].ID {
+			t.Errorf("item %d (%s) does not follow item %d (%s)", i, items[i].ID, i-1, items[i-1].ID)
+		}
+	}
+
+	// Offset pages list the same items.
+	page, err := store.GetItems(context.Background(), models.ItemQuery{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalCount != 5 || len(page.Items) != 2 || page.Items[0].ID != items[2].ID || page.Items[1].ID != items[3].ID {
+		t.Errorf("offset 2 lists %d of %d items, want items 2 and 3 of 5", len(page.Items), page.TotalCount)
+	}
+	if _, err := store.GetItems(context.Background(), models.ItemQuery{Limit: 2, Cursor: "!!!"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("listing with a bad cursor: %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestSortedCursorPages(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	// Most prices are shared, so pages must break ties by ID to neither skip
+	// nor repeat items.
+	createPageItems(t, store, 200, 100, 200, 100, 300, 200, 100, 200)
+
+	for _, expr := range []string{"price", "-price", "product_name,-price"} {
+		sort, err := models.ParseSort(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, limit := range []int{1, 2, 3, 8} {
+			items := pageThrough(t, store, models.ItemQuery{Limit: limit, Sort: sort})
+			if len(items) != 8 {
+				t.Errorf("%s by %d: pages list %d items, want 8", expr, limit, len(items))
+				continue
+			}
+			for i := 1; i < len(items); i++ {
+				if compareItems(items[i-1], items[i], sort) >= 0 {
+					t.Errorf("%s by %d: item %d (%d, %s) does not follow item %d (%d, %s)", expr, limit,
+						i, items[i].Price, items[i].ID, i-1, items[i-1].Price, items[i-1].ID)
+				}
+			}
+		}
+	}
+
+	// A cursor keeps its place when items are added before it.
+	sort, _ := models.ParseSort("price")
+	page, err := store.GetItems(ctx, models.ItemQuery{Limit: 4, Sort: sort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	createPageItems(t, store, 50, 400)
+	rest := pageThrough(t, store, models.ItemQuery{Limit: 4, Sort: sort, Cursor: page.NextCursor})
+	if len(rest) != 5 || rest[len(rest)-1].Price != 400 {
+		t.Errorf("pages after the cursor list %d items, want the 4 not yet listed and the new 400", len(rest))
+	}
+	for _, item := range rest {
+		for _, listed := range page.Items {
+			if item.ID == listed.ID {
+				t.Errorf("item %s is listed again after the cursor", item.ID)
+			}
+		}
+	}
+
+	if _, err := store.GetItems(ctx, models.ItemQuery{Limit: 4, Cursor: page.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("listing without the cursor's sort: %v, want ErrInvalidCursor", err)
+	}
+}
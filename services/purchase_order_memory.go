@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// copyPurchaseOrder copies order together with its lines, so callers never
+// share lines with the store.
+func copyPurchaseOrder(order *models.PurchaseOrder) *models.PurchaseOrder {
+	copied := *order
+	copied.Lines = append([]models.PurchaseOrderLine{}, order.Lines...)
+	return &copied
+}
+
+func (s *MemoryStore) CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	defer s.lock(ctx)()
+
+	order.ID = uuid.New().String()
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.New().String()
+	}
+	s.data.purchaseOrders[order.ID] = copyPurchaseOrder(order)
+
+	return order, nil
+}
+
+func (s *MemoryStore) GetPurchaseOrders(ctx context.Context, query models.PurchaseOrderQuery) ([]*models.PurchaseOrder, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.PurchaseOrder
+	for _, stored := range s.data.purchaseOrders {
+		if query.Vendor != "" && stored.Vendor != query.Vendor || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		matched = append(matched, copyPurchaseOrder(stored))
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetPurchaseOrderByID(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.purchaseOrders[id]
+	if !ok {
+		return nil, ErrPurchaseOrderNotFound
+	}
+
+	return copyPurchaseOrder(stored), nil
+}
+
+func (s *MemoryStore) UpdatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.purchaseOrders[order.ID]
+	if !ok {
+		return ErrPurchaseOrderNotFound
+	}
+	if stored.Version != order.Version {
+		return ErrPurchaseOrderConflict
+	}
+
+	order.Version++
+	s.data.purchaseOrders[order.ID] = copyPurchaseOrder(order)
+	return nil
+}
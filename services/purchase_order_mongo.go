@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) purchaseOrders() *mongo.Collection {
+	return s.Collection.Database().Collection("purchase_orders")
+}
+
+func (s *MongoStore) CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	order.ID = primitive.NewObjectID().Hex()
+	for i := range order.Lines {
+		order.Lines[i].ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := s.purchaseOrders().InsertOne(ctx, order); err != nil {
+		log.Printf("Error inserting purchase order: %v", err)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *MongoStore) GetPurchaseOrders(ctx context.Context, query models.PurchaseOrderQuery) ([]*models.PurchaseOrder, int64, error) {
+	var orders []*models.PurchaseOrder
+
+	filter := bson.M{}
+	if query.Vendor != "" {
+		filter["vendor"] = query.Vendor
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.purchaseOrders().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.purchaseOrders().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalCount, nil
+}
+
+func (s *MongoStore) GetPurchaseOrderByID(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrPurchaseOrderNotFound
+	}
+
+	err := s.purchaseOrders().FindOne(ctx, bson.M{"_id": id}).Decode(&order)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching purchase order: %v", err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (s *MongoStore) UpdatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) error {
+	if !primitive.IsValidObjectID(order.ID) {
+		return ErrPurchaseOrderNotFound
+	}
+
+	updated := *order
+	updated.Version++
+	result, err := s.purchaseOrders().ReplaceOne(ctx, bson.M{"_id": order.ID, "version": order.Version}, &updated)
+	if err != nil {
+		log.Printf("Error updating purchase order: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrPurchaseOrderConflict
+	}
+
+	order.Version = updated.Version
+	return nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const purchaseOrderColumns = "id, vendor, currency, reference, status, created_at, closed_at, version"
+
+func (s *PostgresStore) CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) (*models.PurchaseOrder, error) {
+	order.ID = uuid.New().String()
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.New().String()
+	}
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO purchase_orders (` + purchaseOrderColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, order.ID, order.Vendor, order.Currency, order.Reference, order.Status,
+			order.CreatedAt, order.ClosedAt, order.Version).Error
+		if err != nil {
+			return err
+		}
+
+		for i, line := range order.Lines {
+			query := `INSERT INTO purchase_order_lines
+						(id, purchase_order_id, position, item_id, quantity, unit_cost, expected_date, received)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+			err := s.conn(ctx).Exec(query, line.ID, order.ID, i, line.ItemID, line.Quantity, line.UnitCost,
+				line.ExpectedDate, line.Received).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error inserting purchase order: %v", err)
+		return nil, fmt.Errorf("error inserting purchase order: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *PostgresStore) GetPurchaseOrders(ctx context.Context, query models.PurchaseOrderQuery) ([]*models.PurchaseOrder, int64, error) {
+	var orders []*models.PurchaseOrder
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.Vendor != "" {
+		conditions = append(conditions, "vendor = ?")
+		args = append(args, query.Vendor)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM purchase_orders` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting purchase orders: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders` + where +
+		` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&orders).Error; err != nil {
+		log.Printf("Error fetching purchase orders: %v", err)
+		return nil, 0, err
+	}
+
+	if err := s.loadPurchaseOrderLines(ctx, orders); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalCount, nil
+}
+
+func (s *PostgresStore) GetPurchaseOrderByID(ctx context.Context, id string) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrPurchaseOrderNotFound
+	}
+
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&order)
+	if result.Error != nil {
+		log.Printf("Error fetching purchase order: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrPurchaseOrderNotFound
+	}
+
+	if err := s.loadPurchaseOrderLines(ctx, []*models.PurchaseOrder{&order}); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// loadPurchaseOrderLines fills in the lines of orders with one query.
+func (s *PostgresStore) loadPurchaseOrderLines(ctx context.Context, orders []*models.PurchaseOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.PurchaseOrder, len(orders))
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		order.Lines = []models.PurchaseOrderLine{}
+		byID[order.ID] = order
+		ids = append(ids, order.ID)
+	}
+
+	var lines []struct {
+		models.PurchaseOrderLine
+		PurchaseOrderID string `gorm:"column:purchase_order_id"`
+	}
+	query := `SELECT id, purchase_order_id, item_id, quantity, unit_cost, expected_date, received
+				FROM purchase_order_lines WHERE purchase_order_id IN ? ORDER BY purchase_order_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&lines).Error; err != nil {
+		log.Printf("Error fetching purchase order lines: %v", err)
+		return err
+	}
+
+	for _, line := range lines {
+		order := byID[line.PurchaseOrderID]
+		order.Lines = append(order.Lines, line.PurchaseOrderLine)
+	}
+	return nil
+}
+
+func (s *PostgresStore) UpdatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) error {
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `UPDATE purchase_orders SET status = ?, closed_at = ?, version = version + 1
+					WHERE id = ? AND version = ?`
+		result := s.conn(ctx).Exec(query, order.Status, order.ClosedAt, order.ID, order.Version)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPurchaseOrderConflict
+		}
+
+		for _, line := range order.Lines {
+			query := `UPDATE purchase_order_lines SET received = ? WHERE id = ? AND purchase_order_id = ?`
+			if err := s.conn(ctx).Exec(query, line.Received, line.ID, order.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err == ErrPurchaseOrderConflict {
+			return err
+		}
+		log.Printf("Error updating purchase order: %v", err)
+		return fmt.Errorf("error updating purchase order: %w", err)
+	}
+
+	order.Version++
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateReservation(ctx context.Context, reservation *models.Reservation) (*models.Reservation, error) {
+	defer s.lock(ctx)()
+
+	reservation.ID = uuid.New().String()
+	stored := *reservation
+	s.data.reservations[reservation.ID] = &stored
+
+	return reservation, nil
+}
+
+func (s *MemoryStore) GetReservations(ctx context.Context, query models.ReservationQuery) ([]*models.Reservation, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.Reservation
+	for _, stored := range s.data.reservations {
+		if query.ItemID != "" && stored.ItemID != query.ItemID || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		if query.ExpiresBefore != nil && stored.ExpiresAt.After(*query.ExpiresBefore) {
+			continue
+		}
+		reservation := *stored
+		matched = append(matched, &reservation)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.reservations[id]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+
+	reservation := *stored
+	return &reservation, nil
+}
+
+func (s *MemoryStore) UpdateReservationStatus(ctx context.Context, id, from, to string) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.reservations[id]
+	if !ok {
+		return ErrReservationNotFound
+	}
+	if stored.Status != from {
+		return ErrReservationConflict
+	}
+
+	stored.Status = to
+	return nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) reservations() *mongo.Collection {
+	return s.Collection.Database().Collection("reservations")
+}
+
+func (s *MongoStore) CreateReservation(ctx context.Context, reservation *models.Reservation) (*models.Reservation, error) {
+	reservation.ID = primitive.NewObjectID().Hex()
+	if _, err := s.reservations().InsertOne(ctx, reservation); err != nil {
+		log.Printf("Error inserting reservation: %v", err)
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+func (s *MongoStore) GetReservations(ctx context.Context, query models.ReservationQuery) ([]*models.Reservation, int64, error) {
+	var reservations []*models.Reservation
+
+	filter := bson.M{}
+	if query.ItemID != "" {
+		filter["item_id"] = query.ItemID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.ExpiresBefore != nil {
+		filter["expires_at"] = bson.M{"$lte": *query.ExpiresBefore}
+	}
+
+	totalCount, err := s.reservations().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.reservations().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, 0, err
+	}
+
+	return reservations, totalCount, nil
+}
+
+func (s *MongoStore) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	var reservation models.Reservation
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrReservationNotFound
+	}
+
+	err := s.reservations().FindOne(ctx, bson.M{"_id": id}).Decode(&reservation)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching reservation: %v", err)
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (s *MongoStore) UpdateReservationStatus(ctx context.Context, id, from, to string) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrReservationNotFound
+	}
+
+	result, err := s.reservations().UpdateOne(ctx, bson.M{"_id": id, "status": from}, bson.M{"$set": bson.M{"status": to}})
+	if err != nil {
+		log.Printf("Error updating reservation: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrReservationConflict
+	}
+
+	return nil
+}
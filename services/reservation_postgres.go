@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const reservationColumns = "id, item_id, quantity, status, reference, created_at, expires_at"
+
+func (s *PostgresStore) CreateReservation(ctx context.Context, reservation *models.Reservation) (*models.Reservation, error) {
+	reservation.ID = uuid.New().String()
+
+	query := `INSERT INTO reservations (` + reservationColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	err := s.conn(ctx).Exec(query, reservation.ID, reservation.ItemID, reservation.Quantity, reservation.Status,
+		reservation.Reference, reservation.CreatedAt, reservation.ExpiresAt).Error
+	if err != nil {
+		log.Printf("Error inserting reservation: %v", err)
+		return nil, fmt.Errorf("error inserting reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (s *PostgresStore) GetReservations(ctx context.Context, query models.ReservationQuery) ([]*models.Reservation, int64, error) {
+	var reservations []*models.Reservation
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.ItemID != "" {
+		if _, err := uuid.Parse(query.ItemID); err != nil {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "item_id = ?")
+		args = append(args, query.ItemID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	if query.ExpiresBefore != nil {
+		conditions = append(conditions, "expires_at <= ?")
+		args = append(args, *query.ExpiresBefore)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM reservations` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting reservations: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + reservationColumns + ` FROM reservations` + where + ` ORDER BY created_at, id LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&reservations).Error; err != nil {
+		log.Printf("Error fetching reservations: %v", err)
+		return nil, 0, err
+	}
+
+	return reservations, totalCount, nil
+}
+
+func (s *PostgresStore) GetReservationByID(ctx context.Context, id string) (*models.Reservation, error) {
+	var reservation models.Reservation
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrReservationNotFound
+	}
+
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&reservation)
+	if result.Error != nil {
+		log.Printf("Error fetching reservation: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrReservationNotFound
+	}
+
+	return &reservation, nil
+}
+
+func (s *PostgresStore) UpdateReservationStatus(ctx context.Context, id, from, to string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrReservationNotFound
+	}
+
+	result := s.conn(ctx).Exec(`UPDATE reservations SET status = ? WHERE id = ? AND status = ?`, to, id, from)
+	if result.Error != nil {
+		log.Printf("Error updating reservation: %v", result.Error)
+		return fmt.Errorf("error updating reservation: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrReservationConflict
+	}
+
+	return nil
+}
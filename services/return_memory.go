@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// copyReturn copies ret together with its lines and entries, so callers
+// never share them with the store.
+func copyReturn(ret *models.Return) *models.Return {
+	copied := *ret
+	copied.Lines = append([]models.ReturnLine{}, ret.Lines...)
+	copied.Entries = append([]models.ReturnEntry{}, ret.Entries...)
+	return &copied
+}
+
+func (s *MemoryStore) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	defer s.lock(ctx)()
+
+	ret.ID = uuid.New().String()
+	for i := range ret.Lines {
+		ret.Lines[i].ID = uuid.New().String()
+	}
+	s.data.returns[ret.ID] = copyReturn(ret)
+
+	return ret, nil
+}
+
+func (s *MemoryStore) GetReturns(ctx context.Context, query models.ReturnQuery) ([]*models.Return, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.Return
+	for _, stored := range s.data.returns {
+		if query.SalesOrderID != "" && stored.SalesOrderID != query.SalesOrderID || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		matched = append(matched, copyReturn(stored))
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetReturnByID(ctx context.Context, id string) (*models.Return, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.returns[id]
+	if !ok {
+		return nil, ErrReturnNotFound
+	}
+
+	return copyReturn(stored), nil
+}
+
+func (s *MemoryStore) UpdateReturn(ctx context.Context, ret *models.Return) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.returns[ret.ID]
+	if !ok {
+		return ErrReturnNotFound
+	}
+	if stored.Version != ret.Version {
+		return ErrReturnConflict
+	}
+
+	ret.Version++
+	s.data.returns[ret.ID] = copyReturn(ret)
+	return nil
+}
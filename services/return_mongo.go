@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) returns() *mongo.Collection {
+	return s.Collection.Database().Collection("returns")
+}
+
+func (s *MongoStore) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	ret.ID = primitive.NewObjectID().Hex()
+	for i := range ret.Lines {
+		ret.Lines[i].ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := s.returns().InsertOne(ctx, ret); err != nil {
+		log.Printf("Error inserting return: %v", err)
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *MongoStore) GetReturns(ctx context.Context, query models.ReturnQuery) ([]*models.Return, int64, error) {
+	var rets []*models.Return
+
+	filter := bson.M{}
+	if query.SalesOrderID != "" {
+		filter["sales_order_id"] = query.SalesOrderID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.returns().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.returns().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &rets); err != nil {
+		return nil, 0, err
+	}
+
+	return rets, totalCount, nil
+}
+
+func (s *MongoStore) GetReturnByID(ctx context.Context, id string) (*models.Return, error) {
+	var ret models.Return
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrReturnNotFound
+	}
+
+	err := s.returns().FindOne(ctx, bson.M{"_id": id}).Decode(&ret)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrReturnNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching return: %v", err)
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (s *MongoStore) UpdateReturn(ctx context.Context, ret *models.Return) error {
+	if !primitive.IsValidObjectID(ret.ID) {
+		return ErrReturnNotFound
+	}
+
+	updated := *ret
+	updated.Version++
+	result, err := s.returns().ReplaceOne(ctx, bson.M{"_id": ret.ID, "version": ret.Version}, &updated)
+	if err != nil {
+		log.Printf("Error updating return: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrReturnConflict
+	}
+
+	ret.Version = updated.Version
+	return nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const returnColumns = "id, sales_order_id, reason, status, created_at, received_at, closed_at, version"
+
+func (s *PostgresStore) CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error) {
+	ret.ID = uuid.New().String()
+	for i := range ret.Lines {
+		ret.Lines[i].ID = uuid.New().String()
+	}
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO returns (` + returnColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, ret.ID, ret.SalesOrderID, ret.Reason, ret.Status, ret.CreatedAt,
+			ret.ReceivedAt, ret.ClosedAt, ret.Version).Error
+		if err != nil {
+			return err
+		}
+
+		for i, line := range ret.Lines {
+			query := `INSERT INTO return_lines (id, return_id, position, item_id, quantity, received, restocked, scrapped)
+						VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+			err := s.conn(ctx).Exec(query, line.ID, ret.ID, i, line.ItemID, line.Quantity, line.Received,
+				line.Restocked, line.Scrapped).Error
+			if err != nil {
+				return err
+			}
+		}
+		return s.insertReturnEntries(ctx, ret, 0)
+	})
+	if err != nil {
+		log.Printf("Error inserting return: %v", err)
+		return nil, fmt.Errorf("error inserting return: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (s *PostgresStore) GetReturns(ctx context.Context, query models.ReturnQuery) ([]*models.Return, int64, error) {
+	var returns []*models.Return
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.SalesOrderID != "" {
+		if _, err := uuid.Parse(query.SalesOrderID); err != nil {
+			return []*models.Return{}, 0, nil
+		}
+		conditions = append(conditions, "sales_order_id = ?")
+		args = append(args, query.SalesOrderID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM returns` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting returns: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + returnColumns + ` FROM returns` + where +
+		` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&returns).Error; err != nil {
+		log.Printf("Error fetching returns: %v", err)
+		return nil, 0, err
+	}
+
+	if err := s.loadReturnDetails(ctx, returns); err != nil {
+		return nil, 0, err
+	}
+
+	return returns, totalCount, nil
+}
+
+func (s *PostgresStore) GetReturnByID(ctx context.Context, id string) (*models.Return, error) {
+	var ret models.Return
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrReturnNotFound
+	}
+
+	query := `SELECT ` + returnColumns + ` FROM returns WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&ret)
+	if result.Error != nil {
+		log.Printf("Error fetching return: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrReturnNotFound
+	}
+
+	if err := s.loadReturnDetails(ctx, []*models.Return{&ret}); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// loadReturnDetails fills in the lines and entries of returns with one query
+// each.
+func (s *PostgresStore) loadReturnDetails(ctx context.Context, returns []*models.Return) error {
+	if len(returns) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.Return, len(returns))
+	ids := make([]string, 0, len(returns))
+	for _, ret := range returns {
+		ret.Lines = []models.ReturnLine{}
+		ret.Entries = []models.ReturnEntry{}
+		byID[ret.ID] = ret
+		ids = append(ids, ret.ID)
+	}
+
+	var lines []struct {
+		models.ReturnLine
+		ReturnID string `gorm:"column:return_id"`
+	}
+	query := `SELECT id, return_id, item_id, quantity, received, restocked, scrapped
+				FROM return_lines WHERE return_id IN ? ORDER BY return_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&lines).Error; err != nil {
+		log.Printf("Error fetching return lines: %v", err)
+		return err
+	}
+	for _, line := range lines {
+		ret := byID[line.ReturnID]
+		ret.Lines = append(ret.Lines, line.ReturnLine)
+	}
+
+	var entries []struct {
+		models.ReturnEntry
+		ReturnID string `gorm:"column:return_id"`
+	}
+	query = `SELECT return_id, line_id, item_id, condition, disposition, from_location_id, location_id, lot_id, quantity,
+				created_at FROM return_entries WHERE return_id IN ? ORDER BY return_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&entries).Error; err != nil {
+		log.Printf("Error fetching return entries: %v", err)
+		return err
+	}
+	for _, entry := range entries {
+		ret := byID[entry.ReturnID]
+		ret.Entries = append(ret.Entries, entry.ReturnEntry)
+	}
+	return nil
+}
+
+// insertReturnEntries inserts the entries of ret from index from on; the
+// ones before it are already stored.
+func (s *PostgresStore) insertReturnEntries(ctx context.Context, ret *models.Return, from int) error {
+	for i := from; i < len(ret.Entries); i++ {
+		entry := ret.Entries[i]
+		query := `INSERT INTO return_entries
+					(return_id, position, line_id, item_id, condition, disposition, from_location_id, location_id, lot_id,
+					quantity, created_at)
+					VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, ret.ID, i, entry.LineID, entry.ItemID, entry.Condition, entry.Disposition,
+			entry.FromLocationID, entry.LocationID, entry.LotID, entry.Quantity, entry.CreatedAt).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) UpdateReturn(ctx context.Context, ret *models.Return) error {
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `UPDATE returns SET status = ?, received_at = ?, closed_at = ?, version = version + 1
+					WHERE id = ? AND version = ?`
+		result := s.conn(ctx).Exec(query, ret.Status, ret.ReceivedAt, ret.ClosedAt, ret.ID, ret.Version)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReturnConflict
+		}
+
+		for _, line := range ret.Lines {
+			query := `UPDATE return_lines SET received = ?, restocked = ?, scrapped = ? WHERE id = ? AND return_id = ?`
+			err := s.conn(ctx).Exec(query, line.Received, line.Restocked, line.Scrapped, line.ID, ret.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Entries are only ever appended.
+		var stored int
+		if err := s.conn(ctx).Raw(`SELECT COUNT(*) FROM return_entries WHERE return_id = ?`, ret.ID).Scan(&stored).Error; err != nil {
+			return err
+		}
+		return s.insertReturnEntries(ctx, ret, stored)
+	})
+	if err != nil {
+		if err == ErrReturnConflict {
+			return err
+		}
+		log.Printf("Error updating return: %v", err)
+		return fmt.Errorf("error updating return: %w", err)
+	}
+
+	ret.Version++
+	return nil
+}
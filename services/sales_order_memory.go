@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// copySalesOrder copies order together with its lines and picks, so callers
+// never share them with the store.
+func copySalesOrder(order *models.SalesOrder) *models.SalesOrder {
+	copied := *order
+	copied.Lines = append([]models.SalesOrderLine{}, order.Lines...)
+	copied.Picks = append([]models.SalesOrderPick{}, order.Picks...)
+	return &copied
+}
+
+func (s *MemoryStore) CreateSalesOrder(ctx context.Context, order *models.SalesOrder) (*models.SalesOrder, error) {
+	defer s.lock(ctx)()
+
+	order.ID = uuid.New().String()
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.New().String()
+	}
+	s.data.salesOrders[order.ID] = copySalesOrder(order)
+
+	return order, nil
+}
+
+func (s *MemoryStore) GetSalesOrders(ctx context.Context, query models.SalesOrderQuery) ([]*models.SalesOrder, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.SalesOrder
+	for _, stored := range s.data.salesOrders {
+		if query.Customer != "" && stored.Customer != query.Customer || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		matched = append(matched, copySalesOrder(stored))
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetSalesOrderByID(ctx context.Context, id string) (*models.SalesOrder, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.salesOrders[id]
+	if !ok {
+		return nil, ErrSalesOrderNotFound
+	}
+
+	return copySalesOrder(stored), nil
+}
+
+func (s *MemoryStore) UpdateSalesOrder(ctx context.Context, order *models.SalesOrder) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.salesOrders[order.ID]
+	if !ok {
+		return ErrSalesOrderNotFound
+	}
+	if stored.Version != order.Version {
+		return ErrSalesOrderConflict
+	}
+
+	order.Version++
+	s.data.salesOrders[order.ID] = copySalesOrder(order)
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) salesOrders() *mongo.Collection {
+	return s.Collection.Database().Collection("sales_orders")
+}
+
+func (s *MongoStore) CreateSalesOrder(ctx context.Context, order *models.SalesOrder) (*models.SalesOrder, error) {
+	order.ID = primitive.NewObjectID().Hex()
+	for i := range order.Lines {
+		order.Lines[i].ID = primitive.NewObjectID().Hex()
+	}
+
+	if _, err := s.salesOrders().InsertOne(ctx, order); err != nil {
+		log.Printf("Error inserting sales order: %v", err)
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *MongoStore) GetSalesOrders(ctx context.Context, query models.SalesOrderQuery) ([]*models.SalesOrder, int64, error) {
+	var orders []*models.SalesOrder
+
+	filter := bson.M{}
+	if query.Customer != "" {
+		filter["customer"] = query.Customer
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.salesOrders().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.salesOrders().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &orders); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalCount, nil
+}
+
+func (s *MongoStore) GetSalesOrderByID(ctx context.Context, id string) (*models.SalesOrder, error) {
+	var order models.SalesOrder
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrSalesOrderNotFound
+	}
+
+	err := s.salesOrders().FindOne(ctx, bson.M{"_id": id}).Decode(&order)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSalesOrderNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching sales order: %v", err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (s *MongoStore) UpdateSalesOrder(ctx context.Context, order *models.SalesOrder) error {
+	if !primitive.IsValidObjectID(order.ID) {
+		return ErrSalesOrderNotFound
+	}
+
+	updated := *order
+	updated.Version++
+	result, err := s.salesOrders().ReplaceOne(ctx, bson.M{"_id": order.ID, "version": order.Version}, &updated)
+	if err != nil {
+		log.Printf("Error updating sales order: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrSalesOrderConflict
+	}
+
+	order.Version = updated.Version
+	return nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const salesOrderColumns = "id, customer, reference, status, created_at, allocated_at, picked_at, shipped_at, delivered_at, version"
+
+func (s *PostgresStore) CreateSalesOrder(ctx context.Context, order *models.SalesOrder) (*models.SalesOrder, error) {
+	order.ID = uuid.New().String()
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.New().String()
+	}
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `INSERT INTO sales_orders (` + salesOrderColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, order.ID, order.Customer, order.Reference, order.Status, order.CreatedAt,
+			order.AllocatedAt, order.PickedAt, order.ShippedAt, order.DeliveredAt, order.Version).Error
+		if err != nil {
+			return err
+		}
+
+		for i, line := range order.Lines {
+			query := `INSERT INTO sales_order_lines (id, sales_order_id, position, item_id, quantity) VALUES (?, ?, ?, ?, ?)`
+			if err := s.conn(ctx).Exec(query, line.ID, order.ID, i, line.ItemID, line.Quantity).Error; err != nil {
+				return err
+			}
+		}
+		return s.insertSalesOrderPicks(ctx, order)
+	})
+	if err != nil {
+		log.Printf("Error inserting sales order: %v", err)
+		return nil, fmt.Errorf("error inserting sales order: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *PostgresStore) GetSalesOrders(ctx context.Context, query models.SalesOrderQuery) ([]*models.SalesOrder, int64, error) {
+	var orders []*models.SalesOrder
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.Customer != "" {
+		conditions = append(conditions, "customer = ?")
+		args = append(args, query.Customer)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM sales_orders` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting sales orders: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + salesOrderColumns + ` FROM sales_orders` + where +
+		` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&orders).Error; err != nil {
+		log.Printf("Error fetching sales orders: %v", err)
+		return nil, 0, err
+	}
+
+	if err := s.loadSalesOrderDetails(ctx, orders); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, totalCount, nil
+}
+
+func (s *PostgresStore) GetSalesOrderByID(ctx context.Context, id string) (*models.SalesOrder, error) {
+	var order models.SalesOrder
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrSalesOrderNotFound
+	}
+
+	query := `SELECT ` + salesOrderColumns + ` FROM sales_orders WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&order)
+	if result.Error != nil {
+		log.Printf("Error fetching sales order: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrSalesOrderNotFound
+	}
+
+	if err := s.loadSalesOrderDetails(ctx, []*models.SalesOrder{&order}); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// loadSalesOrderDetails fills in the lines and picks of orders with one
+// query each.
+func (s *PostgresStore) loadSalesOrderDetails(ctx context.Context, orders []*models.SalesOrder) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*models.SalesOrder, len(orders))
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		order.Lines = []models.SalesOrderLine{}
+		order.Picks = []models.SalesOrderPick{}
+		byID[order.ID] = order
+		ids = append(ids, order.ID)
+	}
+
+	var lines []struct {
+		models.SalesOrderLine
+		SalesOrderID string `gorm:"column:sales_order_id"`
+	}
+	query := `SELECT id, sales_order_id, item_id, quantity
+				FROM sales_order_lines WHERE sales_order_id IN ? ORDER BY sales_order_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&lines).Error; err != nil {
+		log.Printf("Error fetching sales order lines: %v", err)
+		return err
+	}
+	for _, line := range lines {
+		order := byID[line.SalesOrderID]
+		order.Lines = append(order.Lines, line.SalesOrderLine)
+	}
+
+	var picks []struct {
+		models.SalesOrderPick
+		SalesOrderID string `gorm:"column:sales_order_id"`
+	}
+	query = `SELECT sales_order_id, line_id, item_id, location_id, quantity
+				FROM sales_order_picks WHERE sales_order_id IN ? ORDER BY sales_order_id, position`
+	if err := s.conn(ctx).Raw(query, ids).Scan(&picks).Error; err != nil {
+		log.Printf("Error fetching sales order picks: %v", err)
+		return err
+	}
+	for _, pick := range picks {
+		order := byID[pick.SalesOrderID]
+		order.Picks = append(order.Picks, pick.SalesOrderPick)
+	}
+	return nil
+}
+
+func (s *PostgresStore) insertSalesOrderPicks(ctx context.Context, order *models.SalesOrder) error {
+	for i, pick := range order.Picks {
+		query := `INSERT INTO sales_order_picks (sales_order_id, position, line_id, item_id, location_id, quantity)
+					VALUES (?, ?, ?, ?, ?, ?)`
+		err := s.conn(ctx).Exec(query, order.ID, i, pick.LineID, pick.ItemID, pick.LocationID, pick.Quantity).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) UpdateSalesOrder(ctx context.Context, order *models.SalesOrder) error {
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		query := `UPDATE sales_orders SET status = ?, allocated_at = ?, picked_at = ?, shipped_at = ?, delivered_at = ?,
+					version = version + 1 WHERE id = ? AND version = ?`
+		result := s.conn(ctx).Exec(query, order.Status, order.AllocatedAt, order.PickedAt, order.ShippedAt,
+			order.DeliveredAt, order.ID, order.Version)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSalesOrderConflict
+		}
+
+		if err := s.conn(ctx).Exec(`DELETE FROM sales_order_picks WHERE sales_order_id = ?`, order.ID).Error; err != nil {
+			return err
+		}
+		return s.insertSalesOrderPicks(ctx, order)
+	})
+	if err != nil {
+		if err == ErrSalesOrderConflict {
+			return err
+		}
+		log.Printf("Error updating sales order: %v", err)
+		return fmt.Errorf("error updating sales order: %w", err)
+	}
+
+	order.Version++
+	return nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"main/models"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// searchTerms splits a search query into lower-case words of letters and
+// digits, dropping everything else.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// maxTypos is the edit distance tolerated for a search term of the given
+// length; short terms must match exactly or by prefix.
+func maxTypos(term string) int {
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// termScore scores how well term matches one of words: 1 for an exact word,
+// 0.8 for a prefix, less for a word within maxTypos edits, and 0 otherwise.
+// A word is only a typo of term if it starts with the term's
+// candidatePrefix, so that every backend finds it among its candidates.
+func termScore(term string, words []string) float64 {
+	best := 0.0
+	for _, word := range words {
+		var score float64
+		switch {
+		case word == term:
+			score = 1
+		case strings.HasPrefix(word, term):
+			score = 0.8
+		case strings.HasPrefix(word, candidatePrefix(term)):
+			if limit := maxTypos(term); limit > 0 {
+				// Compare against the word cut to the term's length as well,
+				// so a misspelt prefix still matches a longer word.
+				distance := editDistance(term, word)
+				if prefix := []rune(word); len(prefix) > len([]rune(term)) {
+					if d := editDistance(term, string(prefix[:len([]rune(term))])); d < distance {
+						distance = d
+					}
+				}
+				if distance <= limit {
+					score = 0.6 - 0.1*float64(distance)
+				}
+			}
+		}
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// scoreItem ranks item against terms. Every term must match the product
+// name or vendor; name matches weigh twice as much. It returns 0 when the
+// item does not match.
+func scoreItem(item *models.Inventory, terms []string) float64 {
+	nameWords := searchTerms(item.Name)
+	vendorWords := searchTerms(item.Vendor)
+
+	total := 0.0
+	for _, term := range terms {
+		score := termScore(term, nameWords)
+		if vendorScore := termScore(term, vendorWords) / 2; vendorScore > score {
+			score = vendorScore
+		}
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total / float64(len(terms))
+}
+
+// rankItems scores candidates against terms, adds any backend-specific
+// boost keyed by item ID, and returns the best limit matches. The boost only
+// reorders matches: a candidate that scores 0 is dropped whatever its boost,
+// so every backend returns the same items.
+func rankItems(candidates []*models.Inventory, terms []string, boost map[string]float64, limit int) []models.SearchResult {
+	results := make([]models.SearchResult, 0, len(candidates))
+	seen := make(map[string]bool, len(candidates))
+	for _, item := range candidates {
+		if seen[item.ID] {
+			continue
+		}
+		seen[item.ID] = true
+
+		score := scoreItem(item, terms)
+		if score == 0 {
+			continue
+		}
+		results = append(results, models.SearchResult{Item: item, Score: score + boost[item.ID]})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Item.ID < results[j].Item.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// candidatePrefix is the leading part of term that a word must start with
+// to match it; typos are assumed not to hit the first letters.
+func candidatePrefix(term string) string {
+	runes := []rune(term)
+	if len(runes) > 2 {
+		runes = runes[:2]
+	}
+	return string(runes)
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and transpositions of
+// adjacent letters that turn one into the other, editing no letter twice.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	beforePrevious := make([]int, len(rb)+1)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = min(current[j], beforePrevious[j-2]+1)
+			}
+		}
+		beforePrevious, previous, current = previous, current, beforePrevious
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"slices"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"widget", "widget", 0},
+		{"widgte", "widget", 1},
+		{"wdiget", "widget", 1},
+		{"widgt", "widget", 1},
+		{"wigdte", "widget", 2},
+		{"kitten", "sitting", 3},
+		// No letter is edited twice, so this is not 2 as in Damerau-Levenshtein.
+		{"ca", "abc", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func searchTestItems() []*models.Inventory {
+	return []*models.Inventory{
+		{ID: "1", Name: "Widget", Vendor: "Acme"},
+		{ID: "2", Name: "Gadget", Vendor: "Widgetco"},
+		{ID: "3", Name: "Wodget", Vendor: "Acme"},
+		{ID: "4", Name: "Sprocket", Vendor: "Acme"},
+	}
+}
+
+func resultNames(results []models.SearchResult) []string {
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.Item.Name
+	}
+	return names
+}
+
+func TestSearchRanking(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	for _, item := range searchTestItems() {
+		item.ID = ""
+		if _, err := store.CreateItem(ctx, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"widget", []string{"Widget", "Gadget"}},
+		{"widg", []string{"Widget", "Gadget"}},
+		{"WIDGET!", []string{"Widget", "Gadget"}},
+		{"widgte", []string{"Widget", "Gadget"}},
+		{"acme widget", []string{"Widget"}},
+		{"sprockte", []string{"Sprocket"}},
+		// Typos in the first letters are not tolerated.
+		{"wdiget", []string{}},
+		// Short terms must match exactly or by prefix.
+		{"wid", []string{"Widget", "Gadget"}},
+		{"wdg", []string{}},
+	}
+	for _, tt := range tests {
+		results, err := store.SearchItems(ctx, tt.query, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resultNames(results); !slices.Equal(got, tt.want) {
+			t.Errorf("SearchItems(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchBoostOnlyReorders(t *testing.T) {
+	boost := map[string]float64{"2": 1, "3": 5, "4": 5}
+
+	results := rankItems(searchTestItems(), searchTerms("widget"), boost, 10)
+	if got, want := resultNames(results), []string{"Gadget", "Widget"}; !slices.Equal(got, want) {
+		t.Errorf("boosted ranking = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateSerial(ctx context.Context, serial *models.Serial) (*models.Serial, error) {
+	defer s.lock(ctx)()
+
+	for _, stored := range s.data.serials {
+		if stored.SerialNumber == serial.SerialNumber {
+			return nil, ErrSerialExists
+		}
+	}
+
+	serial.ID = uuid.New().String()
+	stored := *serial
+	s.data.serials[serial.ID] = &stored
+
+	return serial, nil
+}
+
+func (s *MemoryStore) GetSerials(ctx context.Context, query models.SerialQuery) ([]*models.Serial, int64, error) {
+	defer s.rlock(ctx)()
+
+	serialNumbers := stringSet(query.SerialNumbers)
+
+	var matched []*models.Serial
+	for _, stored := range s.data.serials {
+		if query.ItemID != "" && stored.ItemID != query.ItemID {
+			continue
+		}
+		if serialNumbers != nil && !serialNumbers[stored.SerialNumber] {
+			continue
+		}
+		if query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		serial := *stored
+		matched = append(matched, &serial)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].SerialNumber < matched[j].SerialNumber
+	})
+	totalCount := int64(len(matched))
+
+	if query.Limit == 0 {
+		return matched, totalCount, nil
+	}
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	defer s.rlock(ctx)()
+
+	for _, stored := range s.data.serials {
+		if stored.SerialNumber == serialNumber {
+			serial := *stored
+			return &serial, nil
+		}
+	}
+	return nil, ErrSerialNotFound
+}
+
+func (s *MemoryStore) UpdateSerialStatus(ctx context.Context, id, from, to string, updatedAt time.Time) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.serials[id]
+	if !ok {
+		return ErrSerialNotFound
+	}
+	if stored.Status != from {
+		return ErrSerialConflict
+	}
+
+	stored.Status = to
+	stored.UpdatedAt = updatedAt
+	return nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) serials() *mongo.Collection {
+	return s.Collection.Database().Collection("serials")
+}
+
+func (s *MongoStore) CreateSerial(ctx context.Context, serial *models.Serial) (*models.Serial, error) {
+	serial.ID = primitive.NewObjectID().Hex()
+	if _, err := s.serials().InsertOne(ctx, serial); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrSerialExists
+		}
+		log.Printf("Error inserting serial: %v", err)
+		return nil, err
+	}
+
+	return serial, nil
+}
+
+func (s *MongoStore) GetSerials(ctx context.Context, query models.SerialQuery) ([]*models.Serial, int64, error) {
+	var serials []*models.Serial
+
+	filter := bson.M{}
+	if query.ItemID != "" {
+		filter["item_id"] = query.ItemID
+	}
+	if len(query.SerialNumbers) > 0 {
+		filter["serial_number"] = bson.M{"$in": query.SerialNumbers}
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.serials().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "serial_number", Value: 1}})
+	if query.Limit > 0 {
+		findOptions.SetSkip(int64(query.Offset)).SetLimit(int64(query.Limit))
+	}
+	cursor, err := s.serials().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &serials); err != nil {
+		return nil, 0, err
+	}
+
+	return serials, totalCount, nil
+}
+
+func (s *MongoStore) GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	var serial models.Serial
+
+	err := s.serials().FindOne(ctx, bson.M{"serial_number": serialNumber}).Decode(&serial)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSerialNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching serial: %v", err)
+		return nil, err
+	}
+
+	return &serial, nil
+}
+
+func (s *MongoStore) UpdateSerialStatus(ctx context.Context, id, from, to string, updatedAt time.Time) error {
+	if !primitive.IsValidObjectID(id) {
+		return ErrSerialNotFound
+	}
+
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": updatedAt}}
+	result, err := s.serials().UpdateOne(ctx, bson.M{"_id": id, "status": from}, update)
+	if err != nil {
+		log.Printf("Error updating serial: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrSerialConflict
+	}
+
+	return nil
+}
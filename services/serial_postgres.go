@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const serialColumns = "id, item_id, serial_number, status, created_at, updated_at"
+
+func (s *PostgresStore) CreateSerial(ctx context.Context, serial *models.Serial) (*models.Serial, error) {
+	serial.ID = uuid.New().String()
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		var count int64
+		countQuery := `SELECT COUNT(*) FROM serials WHERE serial_number = ?`
+		if err := s.conn(ctx).Raw(countQuery, serial.SerialNumber).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrSerialExists
+		}
+
+		query := `INSERT INTO serials (` + serialColumns + `) VALUES (?, ?, ?, ?, ?, ?)`
+		return s.conn(ctx).Exec(query, serial.ID, serial.ItemID, serial.SerialNumber, serial.Status,
+			serial.CreatedAt, serial.UpdatedAt).Error
+	})
+	if err == ErrSerialExists {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("Error inserting serial: %v", err)
+		return nil, fmt.Errorf("error inserting serial: %w", err)
+	}
+
+	return serial, nil
+}
+
+func (s *PostgresStore) GetSerials(ctx context.Context, query models.SerialQuery) ([]*models.Serial, int64, error) {
+	var serials []*models.Serial
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.ItemID != "" {
+		if _, err := uuid.Parse(query.ItemID); err != nil {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "item_id = ?")
+		args = append(args, query.ItemID)
+	}
+	if len(query.SerialNumbers) > 0 {
+		conditions = append(conditions, "serial_number IN ?")
+		args = append(args, query.SerialNumbers)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM serials` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting serials: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + serialColumns + ` FROM serials` + where + ` ORDER BY serial_number`
+	if query.Limit > 0 {
+		selectQuery += ` LIMIT ? OFFSET ?`
+		args = append(args, query.Limit, query.Offset)
+	}
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&serials).Error; err != nil {
+		log.Printf("Error fetching serials: %v", err)
+		return nil, 0, err
+	}
+
+	return serials, totalCount, nil
+}
+
+func (s *PostgresStore) GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error) {
+	var serial models.Serial
+
+	query := `SELECT ` + serialColumns + ` FROM serials WHERE serial_number = ?`
+	result := s.conn(ctx).Raw(query, serialNumber).Scan(&serial)
+	if result.Error != nil {
+		log.Printf("Error fetching serial: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrSerialNotFound
+	}
+
+	return &serial, nil
+}
+
+func (s *PostgresStore) UpdateSerialStatus(ctx context.Context, id, from, to string, updatedAt time.Time) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrSerialNotFound
+	}
+
+	query := `UPDATE serials SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+	result := s.conn(ctx).Exec(query, to, updatedAt, id, from)
+	if result.Error != nil {
+		log.Printf("Error updating serial: %v", result.Error)
+		return fmt.Errorf("error updating serial: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrSerialConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+	"sync"
+)
+
+// MemoryStore keeps inventory items in process memory. It needs no database
+// and is meant for local development and tests; all data is lost on restart.
+// Item IDs are UUIDs, as with the Postgres backend.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data *memoryData
+}
+
+// memoryData holds everything the memory store keeps, so a transaction can
+// snapshot it and roll back by swapping the snapshot in.
+type memoryData struct {
+	items          map[string]*models.Inventory
+	movements      []*models.StockMovement
+	locations      map[string]*models.Location
+	stockLevels    map[stockLevelKey]int
+	transfers      map[string]*models.Transfer
+	reservations   map[string]*models.Reservation
+	alerts         map[string]*models.LowStockAlert
+	purchaseOrders map[string]*models.PurchaseOrder
+	salesOrders    map[string]*models.SalesOrder
+	returns        map[string]*models.Return
+	lots           map[string]*models.Lot
+	serials        map[string]*models.Serial
+	categories     map[string]*models.Category
+	// boms holds the components of each kit, in order.
+	boms map[string][]models.BOMComponent
+}
+
+type stockLevelKey struct {
+	itemID     string
+	locationID string
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		items:          make(map[string]*models.Inventory),
+		locations:      make(map[string]*models.Location),
+		stockLevels:    make(map[stockLevelKey]int),
+		transfers:      make(map[string]*models.Transfer),
+		reservations:   make(map[string]*models.Reservation),
+		alerts:         make(map[string]*models.LowStockAlert),
+		purchaseOrders: make(map[string]*models.PurchaseOrder),
+		salesOrders:    make(map[string]*models.SalesOrder),
+		returns:        make(map[string]*models.Return),
+		boms:           make(map[string][]models.BOMComponent),
+		lots:           make(map[string]*models.Lot),
+		serials:        make(map[string]*models.Serial),
+		categories:     make(map[string]*models.Category),
+	}
+}
+
+func (d *memoryData) clone() *memoryData {
+	c := newMemoryData()
+	for id, item := range d.items {
+		copied := *item
+		c.items[id] = &copied
+	}
+	// Movements are immutable, so the snapshot can share them.
+	c.movements = append([]*models.StockMovement(nil), d.movements...)
+	for id, location := range d.locations {
+		copied := *location
+		c.locations[id] = &copied
+	}
+	for key, onHand := range d.stockLevels {
+		c.stockLevels[key] = onHand
+	}
+	for id, transfer := range d.transfers {
+		copied := *transfer
+		c.transfers[id] = &copied
+	}
+	for id, reservation := range d.reservations {
+		copied := *reservation
+		c.reservations[id] = &copied
+	}
+	for id, alert := range d.alerts {
+		copied := *alert
+		c.alerts[id] = &copied
+	}
+	for id, order := range d.purchaseOrders {
+		c.purchaseOrders[id] = copyPurchaseOrder(order)
+	}
+	for id, order := range d.salesOrders {
+		c.salesOrders[id] = copySalesOrder(order)
+	}
+	for id, ret := range d.returns {
+		c.returns[id] = copyReturn(ret)
+	}
+	for parentID, components := range d.boms {
+		c.boms[parentID] = append([]models.BOMComponent(nil), components...)
+	}
+	for id, lot := range d.lots {
+		copied := *lot
+		c.lots[id] = &copied
+	}
+	for id, serial := range d.serials {
+		copied := *serial
+		c.serials[id] = &copied
+	}
+	for id, category := range d.categories {
+		copied := *category
+		c.categories[id] = &copied
+	}
+	return c
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: newMemoryData()}
+}
+
+type memoryTxKey struct{}
+
+// WithTransaction runs fn while holding the store's write lock. Calls made
+// with the context passed to fn skip locking, and a failed fn rolls the
+// store back to its state before the transaction.
+func (s *MemoryStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) == s {
+		return fn(ctx)
+	}
+
+	defer s.lock(ctx)()
+
+	snapshot := s.data.clone()
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, s)); err != nil {
+		s.data = snapshot
+		return err
+	}
+	return nil
+}
+
+// lock takes the write lock unless ctx belongs to a transaction, which
+// already holds it, and returns the matching unlock.
+func (s *MemoryStore) lock(ctx context.Context) func() {
+	if ctx.Value(memoryTxKey{}) == s {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *MemoryStore) rlock(ctx context.Context) func() {
+	if ctx.Value(memoryTxKey{}) == s {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+func (s *MemoryStore) CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
+	defer s.lock(ctx)()
+
+	item.ID = ""
+	item.GenerateUUID()
+	item.OnHand, item.Reserved = 0, 0
+	if err := s.data.checkItemIdentifiers(item.ID, item); err != nil {
+		return nil, err
+	}
+	stored := *item
+	stored.Barcodes = append([]string(nil), item.Barcodes...)
+	s.data.items[item.ID] = &stored
+
+	return item, nil
+}
+
+func (s *MemoryStore) GetItems(ctx context.Context, query models.ItemQuery) (*models.ItemPage, error) {
+	cursor, err := decodeCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.rlock(ctx)()
+
+	var matched []*models.Inventory
+	for _, stored := range s.data.items {
+		if matchesFilter(stored, query.Filter) {
+			item := *stored
+			matched = append(matched, &item)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return compareItems(matched[i], matched[j], query.Sort) < 0
+	})
+	totalCount := int64(len(matched))
+
+	items := matched[:0]
+	for _, item := range matched {
+		if cursor == nil || afterCursor(item, cursor, query.Sort) {
+			items = append(items, item)
+		}
+	}
+
+	if query.Offset < len(items) {
+		items = items[query.Offset:]
+	} else {
+		items = nil
+	}
+	if len(items) > query.Limit+1 {
+		items = items[:query.Limit+1]
+	}
+
+	return newPage(items, totalCount, query), nil
+}
+
+func (s *MemoryStore) GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.items[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+
+	item := *stored
+	return &item, nil
+}
+
+func (s *MemoryStore) UpdateItem(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.items[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+	if err := s.data.checkItemIdentifiers(id, item); err != nil {
+		return nil, err
+	}
+
+	stored.Name = item.Name
+	stored.Price = item.Price
+	stored.Currency = item.Currency
+	stored.Discount = item.Discount
+	stored.Vendor = item.Vendor
+	stored.ReorderPoint = item.ReorderPoint
+	stored.ReorderQuantity = item.ReorderQuantity
+	stored.LotTracked = item.LotTracked
+	stored.Serialized = item.Serialized
+	stored.SKU = item.SKU
+	stored.Barcodes = append([]string(nil), item.Barcodes...)
+	stored.CategoryID = item.CategoryID
+
+	updatedItem := *stored
+	return &updatedItem, nil
+}
+
+func (s *MemoryStore) PatchItem(ctx context.Context, id string, patch models.InventoryPatch) (*models.Inventory, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.items[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+
+	if err := s.data.checkItemIdentifiers(id, patchIdentifiers(patch)); err != nil {
+		return nil, err
+	}
+
+	patch.Apply(stored)
+	if patch.Barcodes != nil {
+		stored.Barcodes = append([]string(nil), *patch.Barcodes...)
+	}
+
+	patchedItem := *stored
+	return &patchedItem, nil
+}
+
+func (s *MemoryStore) AdjustStock(ctx context.Context, id string, onHandDelta, reservedDelta int) (*models.Inventory, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.items[id]
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+
+	onHand, reserved := stored.OnHand+onHandDelta, stored.Reserved+reservedDelta
+	if onHand < 0 || reserved < 0 || reserved > onHand {
+		return nil, ErrInsufficientStock
+	}
+	stored.OnHand, stored.Reserved = onHand, reserved
+
+	adjustedItem := *stored
+	return &adjustedItem, nil
+}
+
+func (s *MemoryStore) DeleteItem(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.data.items[id]; !ok {
+		return ErrItemNotFound
+	}
+
+	delete(s.data.items, id)
+	delete(s.data.boms, id)
+	for key := range s.data.stockLevels {
+		if key.itemID == id {
+			delete(s.data.stockLevels, key)
+		}
+	}
+	for lotID, lot := range s.data.lots {
+		if lot.ItemID == id {
+			delete(s.data.lots, lotID)
+		}
+	}
+	for serialID, serial := range s.data.serials {
+		if serial.ItemID == id {
+			delete(s.data.serials, serialID)
+		}
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	defer s.rlock(ctx)()
+
+	candidates := make([]*models.Inventory, 0, len(s.data.items))
+	for _, stored := range s.data.items {
+		item := *stored
+		candidates = append(candidates, &item)
+	}
+
+	return rankItems(candidates, searchTerms(query), nil, limit), nil
+}
@@ -5,47 +5,83 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"main/config"
 	"main/models"
+	"strings"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-func CreateTableIfNotExists(db *gorm.DB) error {
-	query := `
-	DROP TABLE IF EXISTS "inventories";
-	CREATE TABLE "inventories" (
-		"id" uuid DEFAULT gen_random_uuid() PRIMARY KEY,
-		"product_name" varchar(255),
-		"price" bigint,
-		"currency" varchar(10),
-		"discount" bigint,
-		"vendor" varchar(255)
-	);
-	`
-
-	err := db.Exec(query).Error
-	if err != nil {
-		log.Printf("Error executing table creation query: %v", err)
-		return fmt.Errorf("failed to create table: %v", err)
+var errPostgresNotInitialized = errors.New("PostgreSQL database connection is not initialized")
+
+// inventoryColumns is the column list selected into models.Inventory.
+const inventoryColumns = `id, product_name, price, currency, discount, vendor, on_hand, reserved,
+	reorder_point, reorder_quantity, lot_tracked, serialized, COALESCE(sku, '') AS sku, category_id`
+
+// PostgresStore keeps inventory items in the "inventories" table. Item IDs
+// are UUIDs generated by the database.
+type PostgresStore struct {
+	DB *gorm.DB
+}
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{DB: db}
+}
+
+type postgresTxKey struct{}
+
+// WithTransaction runs fn in a database transaction. Calls made with the
+// context passed to fn use the transaction; nested calls join it.
+func (s *PostgresStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.DB == nil {
+		log.Println("Error: PostgreSQL database connection is not initialized.")
+		return errPostgresNotInitialized
 	}
 
-	log.Println("Table 'inventories' checked/created successfully.")
-	return nil
+	if _, ok := ctx.Value(postgresTxKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, postgresTxKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or the connection pool.
+func (s *PostgresStore) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(postgresTxKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return s.DB.WithContext(ctx)
 }
 
-func CreateItemPostgres(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
-	
-	if config.PG == nil {
+func (s *PostgresStore) CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
+	if s.DB == nil {
 		log.Println("Error: PostgreSQL database connection is not initialized.")
-		return nil, errors.New("PostgreSQL database connection is not initialized")
+		return nil, errPostgresNotInitialized
 	}
 
-	query := `INSERT INTO inventories (product_name, price, currency, discount, vendor)
-				VALUES (?, ?, ?, ?, ?)
-				RETURNING id, product_name, price, currency, discount, vendor`
-	err := config.PG.Raw(query, item.Name, item.Price, item.Currency, item.Discount, item.Vendor).
-		Scan(item).Error
+	barcodes := item.Barcodes
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkItemIdentifiers(ctx, "", item); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO inventories (product_name, price, currency, discount, vendor, reorder_point, reorder_quantity,
+				lot_tracked, serialized, sku, category_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?)
+				RETURNING ` + inventoryColumns
+		err := s.conn(ctx).Raw(query, item.Name, item.Price, item.Currency, item.Discount, item.Vendor,
+			item.ReorderPoint, item.ReorderQuantity, item.LotTracked, item.Serialized, item.SKU, item.CategoryID).Scan(item).Error
+		if err != nil {
+			return err
+		}
+		item.Barcodes = barcodes
+		return s.saveBarcodes(ctx, item.ID, barcodes)
+	})
+	if err == ErrSKUExists || err == ErrBarcodeExists {
+		return nil, err
+	}
 	if err != nil {
 		log.Println("Error inserting item:", err)
 		return nil, fmt.Errorf("error inserting item: %w", err)
@@ -55,47 +91,163 @@ func CreateItemPostgres(ctx context.Context, item *models.Inventory) (*models.In
 	return item, nil
 }
 
-func GetItemsPostgres(ctx context.Context) ([]*models.Inventory, int64, error) {
+func (s *PostgresStore) GetItems(ctx context.Context, query models.ItemQuery) (*models.ItemPage, error) {
 	var items []*models.Inventory
 	var totalCount int64
 
-	if config.PG == nil {
+	if s.DB == nil {
 		log.Println("Error: PostgreSQL database connection is not initialized.")
-		return nil, 0, errors.New("PostgreSQL database connection is not initialized")
+		return nil, errPostgresNotInitialized
 	}
 
-	query := `SELECT id, product_name, price, currency, discount, vendor FROM inventories`
-	err := config.PG.Raw(query).Scan(&items).Error
+	cursor, err := decodeCursor(query.Cursor, query.Sort)
 	if err != nil {
-		log.Printf("Error fetching inventory items from PostgreSQL: %v", err)
-		return nil, 0, err
+		return nil, err
 	}
 
-	countQuery := `SELECT COUNT(*) FROM inventories`
-	err = config.PG.Raw(countQuery).Scan(&totalCount).Error
+	where, args := itemFilterSQL(query.Filter)
+
+	countQuery := `SELECT COUNT(*) FROM inventories` + whereClause(where)
+	err = s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error
 	if err != nil {
 		log.Printf("Error counting inventory items in PostgreSQL: %v", err)
-		return nil, 0, err
+		return nil, err
+	}
+
+	if cursor != nil {
+		condition, cursorArgs := keysetSQL(cursor, query.Sort)
+		where = append(where, condition)
+		args = append(args, cursorArgs...)
 	}
 
-	return items, totalCount, nil
+	orderBy := make([]string, 0, len(query.Sort)+1)
+	for _, field := range query.Sort {
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		orderBy = append(orderBy, field.Field+" "+direction)
+	}
+	orderBy = append(orderBy, "id ASC")
+
+	selectQuery := `SELECT ` + inventoryColumns + ` FROM inventories` +
+		whereClause(where) + ` ORDER BY ` + strings.Join(orderBy, ", ") + ` LIMIT ? OFFSET ?`
+	args = append(args, query.Limit+1, query.Offset)
+
+	err = s.conn(ctx).Raw(selectQuery, args...).Scan(&items).Error
+	if err != nil {
+		log.Printf("Error fetching inventory items from PostgreSQL: %v", err)
+		return nil, err
+	}
+	if err := s.loadBarcodes(ctx, items); err != nil {
+		return nil, err
+	}
+
+	return newPage(items, totalCount, query), nil
 }
 
-func GetItemByIDPostgres(ctx context.Context, id string) (*models.Inventory, error) {
+// itemFilterSQL translates filter into parameterized conditions. Column
+// names come from fixed strings, never from the request.
+func itemFilterSQL(filter models.ItemFilter) ([]string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	if filter.Vendor != "" {
+		where = append(where, "vendor = ?")
+		args = append(args, filter.Vendor)
+	}
+	if filter.Currency != "" {
+		where = append(where, "currency = ?")
+		args = append(args, filter.Currency)
+	}
+	if filter.PriceMin != nil {
+		where = append(where, "price >= ?")
+		args = append(args, *filter.PriceMin)
+	}
+	if filter.PriceMax != nil {
+		where = append(where, "price <= ?")
+		args = append(args, *filter.PriceMax)
+	}
+	if filter.DiscountGT != nil {
+		where = append(where, "discount > ?")
+		args = append(args, *filter.DiscountGT)
+	}
+	if filter.NameContains != "" {
+		where = append(where, `LOWER(product_name) LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(filter.NameContains))+"%")
+	}
+	if filter.BelowReorderPoint {
+		where = append(where, "reorder_point > 0 AND on_hand - reserved < reorder_point")
+	}
+	if len(filter.CategoryIDs) > 0 {
+		where = append(where, "category_id IN ?")
+		args = append(args, validUUIDs(filter.CategoryIDs))
+	}
+
+	return where, args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// keysetSQL builds the condition selecting rows that sort after cursor:
+// (a > x) OR (a = x AND b < y) OR (a = x AND b = y AND id > z) for a sort of
+// "a,-b". The sort fields are validated against models.SortableItemFields.
+func keysetSQL(cursor *pageCursor, sort []models.SortField) (string, []interface{}) {
+	var terms []string
+	var args []interface{}
+
+	for i := 0; i <= len(sort); i++ {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, sort[j].Field+" = ?")
+			args = append(args, cursor.Values[j])
+		}
+		if i < len(sort) {
+			op := ">"
+			if sort[i].Desc {
+				op = "<"
+			}
+			parts = append(parts, sort[i].Field+" "+op+" ?")
+			args = append(args, cursor.Values[i])
+		} else {
+			parts = append(parts, "id > ?")
+			args = append(args, cursor.ID)
+		}
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func (s *PostgresStore) GetItemByID(ctx context.Context, id string) (*models.Inventory, error) {
 	var item models.Inventory
 
-	if config.PG == nil {
+	if s.DB == nil {
 		log.Println("Error: PostgreSQL database connection is not initialized.")
-		return nil, errors.New("PostgreSQL database connection is not initialized")
+		return nil, errPostgresNotInitialized
 	}
 
-	query := `SELECT * FROM inventories WHERE id = ?`
-	err := config.PG.Raw(query, id).Scan(&item).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("inventory item not found")
-		}
-		log.Printf("Error fetching inventory item by ID from PostgreSQL: %v", err)
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrItemNotFound
+	}
+
+	query := `SELECT ` + inventoryColumns + ` FROM inventories WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&item)
+	if result.Error != nil {
+		log.Printf("Error fetching inventory item by ID from PostgreSQL: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrItemNotFound
+	}
+	if err := s.loadBarcodes(ctx, []*models.Inventory{&item}); err != nil {
 		return nil, err
 	}
 
@@ -103,20 +255,44 @@ func GetItemByIDPostgres(ctx context.Context, id string) (*models.Inventory, err
 	return &item, nil
 }
 
-func UpdateItemPostgres(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error) {
-	if config.PG == nil {
+func (s *PostgresStore) UpdateItem(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error) {
+	if s.DB == nil {
 		log.Println("Error: PostgreSQL database connection is not initialized.")
-		return nil, errors.New("PostgreSQL database connection is not initialized")
+		return nil, errPostgresNotInitialized
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrItemNotFound
 	}
 
-	query := `UPDATE inventories SET product_name = ?, price = ?, currency = ?, discount = ?, vendor = ? WHERE id = ?`
-	err := config.PG.Exec(query, item.Name, item.Price, item.Currency, item.Discount, item.Vendor, id).Error
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkItemIdentifiers(ctx, id, item); err != nil {
+			return err
+		}
+
+		query := `UPDATE inventories SET product_name = ?, price = ?, currency = ?, discount = ?, vendor = ?,
+				reorder_point = ?, reorder_quantity = ?, lot_tracked = ?, serialized = ?, sku = NULLIF(?, ''),
+				category_id = ?
+				WHERE id = ?`
+		result := s.conn(ctx).Exec(query, item.Name, item.Price, item.Currency, item.Discount, item.Vendor,
+			item.ReorderPoint, item.ReorderQuantity, item.LotTracked, item.Serialized, item.SKU, item.CategoryID, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrItemNotFound
+		}
+		return s.saveBarcodes(ctx, id, item.Barcodes)
+	})
+	if err == ErrItemNotFound || err == ErrSKUExists || err == ErrBarcodeExists {
+		return nil, err
+	}
 	if err != nil {
 		log.Printf("Error updating inventory item in PostgreSQL: %v", err)
 		return nil, fmt.Errorf("error updating item: %w", err)
 	}
 
-	updatedItem, err := GetItemByIDPostgres(ctx, id)
+	updatedItem, err := s.GetItemByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching updated item: %w", err)
 	}
@@ -125,19 +301,236 @@ func UpdateItemPostgres(ctx context.Context, id string, item *models.Inventory)
 	return updatedItem, nil
 }
 
-func DeleteItemPostgres(ctx context.Context, id string) error {
-	if config.PG == nil {
+func (s *PostgresStore) PatchItem(ctx context.Context, id string, patch models.InventoryPatch) (*models.Inventory, error) {
+	if s.DB == nil {
 		log.Println("Error: PostgreSQL database connection is not initialized.")
-		return errors.New("PostgreSQL database connection is not initialized")
+		return nil, errPostgresNotInitialized
 	}
 
-	query := `DELETE FROM inventories WHERE id = ?`
-	err := config.PG.Exec(query, id).Error
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrItemNotFound
+	}
+
+	var columns []string
+	var args []interface{}
+	for _, field := range inventoryPatchColumns(patch) {
+		columns = append(columns, field.column+" = ?")
+		args = append(args, field.value)
+	}
+	if len(columns) == 0 && patch.Barcodes == nil {
+		return s.GetItemByID(ctx, id)
+	}
+
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkItemIdentifiers(ctx, id, patchIdentifiers(patch)); err != nil {
+			return err
+		}
+
+		if len(columns) > 0 {
+			query := `UPDATE inventories SET ` + strings.Join(columns, ", ") + ` WHERE id = ?`
+			result := s.conn(ctx).Exec(query, append(args, id)...)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrItemNotFound
+			}
+		} else if _, err := s.GetItemByID(ctx, id); err != nil {
+			return err
+		}
+		if patch.Barcodes != nil {
+			return s.saveBarcodes(ctx, id, *patch.Barcodes)
+		}
+		return nil
+	})
+	if err == ErrItemNotFound || err == ErrSKUExists || err == ErrBarcodeExists {
+		return nil, err
+	}
 	if err != nil {
-		log.Printf("Error deleting inventory item from PostgreSQL: %v", err)
-		return fmt.Errorf("error deleting item: %w", err)
+		log.Printf("Error patching inventory item in PostgreSQL: %v", err)
+		return nil, fmt.Errorf("error patching item: %w", err)
+	}
+
+	return s.GetItemByID(ctx, id)
+}
+
+type patchColumn struct {
+	column string
+	value  interface{}
+}
+
+// inventoryPatchColumns lists the columns and values set by patch. The
+// column names double as Mongo field names.
+func inventoryPatchColumns(patch models.InventoryPatch) []patchColumn {
+	var columns []patchColumn
+	if patch.Name != nil {
+		columns = append(columns, patchColumn{"product_name", *patch.Name})
+	}
+	if patch.Price != nil {
+		columns = append(columns, patchColumn{"price", *patch.Price})
+	}
+	if patch.Currency != nil {
+		columns = append(columns, patchColumn{"currency", *patch.Currency})
+	}
+	if patch.Discount != nil {
+		columns = append(columns, patchColumn{"discount", *patch.Discount})
+	}
+	if patch.Vendor != nil {
+		columns = append(columns, patchColumn{"vendor", *patch.Vendor})
+	}
+	if patch.ReorderPoint != nil {
+		columns = append(columns, patchColumn{"reorder_point", *patch.ReorderPoint})
+	}
+	if patch.ReorderQuantity != nil {
+		columns = append(columns, patchColumn{"reorder_quantity", *patch.ReorderQuantity})
+	}
+	if patch.LotTracked != nil {
+		columns = append(columns, patchColumn{"lot_tracked", *patch.LotTracked})
+	}
+	if patch.Serialized != nil {
+		columns = append(columns, patchColumn{"serialized", *patch.Serialized})
+	}
+	if patch.SKU != nil {
+		columns = append(columns, patchColumn{"sku", *patch.SKU})
+	}
+	if patch.CategoryID != nil {
+		var categoryID interface{}
+		if *patch.CategoryID != "" {
+			categoryID = *patch.CategoryID
+		}
+		columns = append(columns, patchColumn{"category_id", categoryID})
+	}
+	return columns
+}
+
+// patchIdentifiers returns the SKU and barcodes set by patch as an item to
+// check them against the other items.
+func patchIdentifiers(patch models.InventoryPatch) *models.Inventory {
+	identifiers := &models.Inventory{}
+	if patch.SKU != nil {
+		identifiers.SKU = *patch.SKU
+	}
+	if patch.Barcodes != nil {
+		identifiers.Barcodes = *patch.Barcodes
+	}
+	return identifiers
+}
+
+func (s *PostgresStore) AdjustStock(ctx context.Context, id string, onHandDelta, reservedDelta int) (*models.Inventory, error) {
+	var item models.Inventory
+
+	if s.DB == nil {
+		log.Println("Error: PostgreSQL database connection is not initialized.")
+		return nil, errPostgresNotInitialized
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrItemNotFound
+	}
+
+	query := `UPDATE inventories SET on_hand = on_hand + ?, reserved = reserved + ?
+				WHERE id = ? AND on_hand + ? >= 0 AND reserved + ? >= 0 AND reserved + ? <= on_hand + ?
+				RETURNING ` + inventoryColumns
+	result := s.conn(ctx).Raw(query, onHandDelta, reservedDelta,
+		id, onHandDelta, reservedDelta, reservedDelta, onHandDelta).Scan(&item)
+	if result.Error != nil {
+		log.Printf("Error adjusting stock in PostgreSQL: %v", result.Error)
+		return nil, fmt.Errorf("error adjusting stock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := s.GetItemByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInsufficientStock
+	}
+	if err := s.loadBarcodes(ctx, []*models.Inventory{&item}); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (s *PostgresStore) DeleteItem(ctx context.Context, id string) error {
+	if s.DB == nil {
+		log.Println("Error: PostgreSQL database connection is not initialized.")
+		return errPostgresNotInitialized
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrItemNotFound
+	}
+
+	query := `DELETE FROM inventories WHERE id = ?`
+	result := s.conn(ctx).Exec(query, id)
+	if result.Error != nil {
+		log.Printf("Error deleting inventory item from PostgreSQL: %v", result.Error)
+		return fmt.Errorf("error deleting item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrItemNotFound
 	}
 
 	log.Println("Item deleted successfully with ID:", id)
 	return nil
 }
+
+// SearchItems finds the same candidates as the other backends, items with a
+// word containing the candidatePrefix of a term, and ranks them in the
+// database by full-text and trigram similarity. That rank only adjusts the
+// order of the shared scoring.
+func (s *PostgresStore) SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	if s.DB == nil {
+		log.Println("Error: PostgreSQL database connection is not initialized.")
+		return nil, errPostgresNotInitialized
+	}
+
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	tsQuery := strings.Join(prefixes, " & ")
+	text := strings.Join(terms, " ")
+
+	where := make([]string, 0, len(terms))
+	args := []interface{}{tsQuery, text}
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(candidatePrefix(term)) + "%"
+		where = append(where, `LOWER(product_name) LIKE ? ESCAPE '\' OR LOWER(vendor) LIKE ? ESCAPE '\'`)
+		args = append(args, pattern, pattern)
+	}
+	args = append(args, searchCandidateLimit)
+
+	var rows []struct {
+		models.Inventory
+		Rank float64
+	}
+	searchQuery := `SELECT ` + inventoryColumns + `,
+			ts_rank(search_vector, to_tsquery('simple', ?)) + word_similarity(?, product_name) AS rank
+		FROM inventories
+		WHERE ` + strings.Join(where, " OR ") + `
+		ORDER BY rank DESC, id
+		LIMIT ?`
+	err := s.conn(ctx).Raw(searchQuery, args...).Scan(&rows).Error
+	if err != nil {
+		log.Printf("Error searching inventory items in PostgreSQL: %v", err)
+		return nil, err
+	}
+
+	candidates := make([]*models.Inventory, len(rows))
+	boost := make(map[string]float64, len(rows))
+	for i := range rows {
+		candidates[i] = &rows[i].Inventory
+		boost[rows[i].ID] = rows[i].Rank / 4
+	}
+
+	results := rankItems(candidates, terms, boost, limit)
+	if err := s.loadBarcodes(ctx, resultItems(results)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
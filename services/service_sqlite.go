@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SQLiteStore keeps inventory items in a single SQLite file. It reuses the
+// Postgres queries, which are portable, and differs only in generating item
+// UUIDs itself since SQLite has no gen_random_uuid(). The schema comes from
+// the sqlite migrations.
+type SQLiteStore struct {
+	*PostgresStore
+}
+
+func NewSQLiteStore(db *gorm.DB) *SQLiteStore {
+	return &SQLiteStore{PostgresStore: NewPostgresStore(db)}
+}
+
+func (s *SQLiteStore) CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error) {
+	item.ID = ""
+	item.GenerateUUID()
+
+	barcodes := item.Barcodes
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkItemIdentifiers(ctx, "", item); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO inventories (id, product_name, price, currency, discount, vendor, reorder_point, reorder_quantity,
+				lot_tracked, serialized, sku, category_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, ''), ?)
+				RETURNING ` + inventoryColumns
+		err := s.conn(ctx).Raw(query, item.ID, item.Name, item.Price, item.Currency, item.Discount, item.Vendor,
+			item.ReorderPoint, item.ReorderQuantity, item.LotTracked, item.Serialized, item.SKU, item.CategoryID).Scan(item).Error
+		if err != nil {
+			return err
+		}
+		item.Barcodes = barcodes
+		return s.saveBarcodes(ctx, item.ID, barcodes)
+	})
+	if err == ErrSKUExists || err == ErrBarcodeExists {
+		return nil, err
+	}
+	if err != nil {
+		log.Println("Error inserting item into SQLite:", err)
+		return nil, fmt.Errorf("error inserting item: %w", err)
+	}
+
+	return item, nil
+}
+
+// SearchItems scans the items whose name or vendor contains the leading
+// letters of a search term and ranks them in Go, as SQLite has neither
+// tsvector nor trigram indexes.
+func (s *SQLiteStore) SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var where []string
+	var args []interface{}
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(candidatePrefix(term)) + "%"
+		where = append(where, `LOWER(product_name) LIKE ? ESCAPE '\' OR LOWER(vendor) LIKE ? ESCAPE '\'`)
+		args = append(args, pattern, pattern)
+	}
+
+	var candidates []*models.Inventory
+	searchQuery := `SELECT ` + inventoryColumns + ` FROM inventories WHERE ` +
+		strings.Join(where, " OR ") + ` LIMIT ?`
+	args = append(args, searchCandidateLimit)
+	if err := s.DB.WithContext(ctx).Raw(searchQuery, args...).Scan(&candidates).Error; err != nil {
+		log.Printf("Error searching inventory items in SQLite: %v", err)
+		return nil, err
+	}
+
+	results := rankItems(candidates, terms, nil, limit)
+	if err := s.loadBarcodes(ctx, resultItems(results)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// LockCategories does nothing: SQLite has no advisory locks, and the store's
+// single connection runs one transaction at a time.
+func (s *SQLiteStore) LockCategories(ctx context.Context) error {
+	return nil
+}
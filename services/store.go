@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"main/models"
+	"time"
+)
+
+var (
+	ErrItemNotFound          = errors.New("inventory item not found")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrLocationNotFound      = errors.New("location not found")
+	ErrTransferNotFound      = errors.New("transfer not found")
+	ErrReservationNotFound   = errors.New("reservation not found")
+	ErrAlertNotFound         = errors.New("alert not found")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrSalesOrderNotFound    = errors.New("sales order not found")
+	ErrReturnNotFound        = errors.New("return not found")
+	ErrLotNotFound           = errors.New("lot not found")
+	ErrSerialNotFound        = errors.New("serial number not found")
+	ErrCategoryNotFound      = errors.New("category not found")
+	// ErrLotExists reports that the item already has a lot with the number.
+	ErrLotExists = errors.New("lot number already exists")
+	// ErrSerialExists reports that a serial number is already registered.
+	ErrSerialExists = errors.New("serial number already exists")
+	// ErrSKUExists reports that another item has the SKU.
+	ErrSKUExists = errors.New("sku already exists")
+	// ErrBarcodeExists reports that another item has the barcode.
+	ErrBarcodeExists = errors.New("barcode already exists")
+	// ErrTransferConflict reports that a transfer changed since it was read.
+	ErrTransferConflict = errors.New("transfer was changed concurrently")
+	// ErrReservationConflict reports that a reservation changed since it
+	// was read.
+	ErrReservationConflict = errors.New("reservation was changed concurrently")
+	// ErrPurchaseOrderConflict reports that a purchase order changed since
+	// it was read.
+	ErrPurchaseOrderConflict = errors.New("purchase order was changed concurrently")
+	// ErrSalesOrderConflict reports that a sales order changed since it was
+	// read.
+	ErrSalesOrderConflict = errors.New("sales order was changed concurrently")
+	// ErrReturnConflict reports that a return changed since it was read.
+	ErrReturnConflict = errors.New("return was changed concurrently")
+	// ErrSerialConflict reports that a serial changed status since it was
+	// read.
+	ErrSerialConflict = errors.New("serial number was changed concurrently")
+)
+
+// InventoryStore is the storage backend used by the inventory manager.
+// Every backend must report a missing item as ErrItemNotFound.
+type InventoryStore interface {
+	Transactor
+	MovementStore
+	LocationStore
+	TransferStore
+	ReservationStore
+	AlertStore
+	PurchaseOrderStore
+	SalesOrderStore
+	ReturnStore
+	BOMStore
+	LotStore
+	SerialStore
+	CategoryStore
+
+	// CreateItem, UpdateItem and PatchItem fail with ErrSKUExists or
+	// ErrBarcodeExists, changing nothing, if another item has the SKU or
+	// one of the barcodes.
+	CreateItem(ctx context.Context, item *models.Inventory) (*models.Inventory, error)
+	GetItems(ctx context.Context, query models.ItemQuery) (*models.ItemPage, error)
+	GetItemByID(ctx context.Context, id string) (*models.Inventory, error)
+	GetItemBySKU(ctx context.Context, sku string) (*models.Inventory, error)
+	// GetItemByBarcode finds the item with the 14-digit GTIN.
+	GetItemByBarcode(ctx context.Context, gtin string) (*models.Inventory, error)
+	UpdateItem(ctx context.Context, id string, item *models.Inventory) (*models.Inventory, error)
+	// PatchItem changes only the fields set in patch.
+	PatchItem(ctx context.Context, id string, patch models.InventoryPatch) (*models.Inventory, error)
+	DeleteItem(ctx context.Context, id string) error
+	// AdjustStock atomically adds the deltas to the item's on-hand and
+	// reserved quantities. It fails with ErrInsufficientStock, changing
+	// nothing, if either would become negative or reserved would exceed
+	// on-hand. New items always start with zero stock.
+	AdjustStock(ctx context.Context, id string, onHandDelta, reservedDelta int) (*models.Inventory, error)
+	// SearchItems ranks items by relevance of their product name and vendor
+	// to query, tolerating prefixes and small typos.
+	SearchItems(ctx context.Context, query string, limit int) ([]models.SearchResult, error)
+}
+
+// searchCandidateLimit caps how many rows a backend scans when it has to
+// score search candidates in Go.
+const searchCandidateLimit = 500
+
+// Transactor runs fn atomically: either every store call fn makes with the
+// context it is given takes effect, or none does when fn returns an error.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// MovementStore keeps the append-only stock ledger. Movements are never
+// updated or deleted, and outlive the items they refer to.
+type MovementStore interface {
+	CreateMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error)
+	// ListMovements returns a page of an item's movements, oldest first, and
+	// the item's total number of movements.
+	ListMovements(ctx context.Context, itemID string, query models.MovementQuery) ([]*models.StockMovement, int64, error)
+	// SumMovements replays an item's ledger and returns the on-hand quantity
+	// it adds up to.
+	SumMovements(ctx context.Context, itemID string) (int, error)
+}
+
+// LocationStore keeps the location hierarchy and the stock levels of items
+// at each location. Deleting an item deletes its stock levels.
+type LocationStore interface {
+	CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	GetLocations(ctx context.Context) ([]*models.Location, error)
+	GetLocationByID(ctx context.Context, id string) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id string) error
+	// AdjustLocationStock atomically adds delta to the item's stock at the
+	// location. It fails with ErrInsufficientStock, changing nothing, if the
+	// level would become negative.
+	AdjustLocationStock(ctx context.Context, itemID, locationID string, delta int) (*models.StockLevel, error)
+	GetStockLevels(ctx context.Context, query models.StockLevelQuery) ([]*models.StockLevel, error)
+}
+
+// CategoryStore keeps the category tree. Items refer to categories by ID.
+type CategoryStore interface {
+	CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	// GetCategories returns every category, by position and then name.
+	GetCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryByID(ctx context.Context, id string) (*models.Category, error)
+	// UpdateCategory saves the name, parent and position of category.
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id string) error
+	// LockCategories keeps other transactions from changing the tree until
+	// the transaction in ctx ends, so that what it read of the tree stays
+	// true. It must be called inside WithTransaction, before reading.
+	LockCategories(ctx context.Context) error
+}
+
+// TransferStore keeps transfer documents. Transfers outlive the items and
+// locations they refer to.
+type TransferStore interface {
+	CreateTransfer(ctx context.Context, transfer *models.Transfer) (*models.Transfer, error)
+	GetTransfers(ctx context.Context, query models.TransferQuery) ([]*models.Transfer, int64, error)
+	GetTransferByID(ctx context.Context, id string) (*models.Transfer, error)
+	// UpdateTransfer saves the status, received quantity and timestamps of
+	// transfer. It fails with ErrTransferConflict, changing nothing, unless
+	// the stored transfer still has the given status and received quantity.
+	UpdateTransfer(ctx context.Context, transfer *models.Transfer, status string, received int) error
+}
+
+// ReservationStore keeps reservation records. The stock they hold is
+// tracked in the item's reserved quantity.
+type ReservationStore interface {
+	CreateReservation(ctx context.Context, reservation *models.Reservation) (*models.Reservation, error)
+	GetReservations(ctx context.Context, query models.ReservationQuery) ([]*models.Reservation, int64, error)
+	GetReservationByID(ctx context.Context, id string) (*models.Reservation, error)
+	// UpdateReservationStatus moves a reservation from status from to status
+	// to. It fails with ErrReservationConflict, changing nothing, if the
+	// reservation is not in status from.
+	UpdateReservationStatus(ctx context.Context, id, from, to string) error
+}
+
+// AlertStore keeps low-stock alerts.
+type AlertStore interface {
+	CreateAlert(ctx context.Context, alert *models.LowStockAlert) (*models.LowStockAlert, error)
+	GetAlerts(ctx context.Context, query models.AlertQuery) ([]*models.LowStockAlert, int64, error)
+	// ResolveAlert closes an open alert. It fails with ErrAlertNotFound if
+	// there is no such open alert.
+	ResolveAlert(ctx context.Context, id string, resolvedAt time.Time) error
+}
+
+// PurchaseOrderStore keeps purchase orders together with their lines.
+type PurchaseOrderStore interface {
+	CreatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) (*models.PurchaseOrder, error)
+	GetPurchaseOrders(ctx context.Context, query models.PurchaseOrderQuery) ([]*models.PurchaseOrder, int64, error)
+	GetPurchaseOrderByID(ctx context.Context, id string) (*models.PurchaseOrder, error)
+	// UpdatePurchaseOrder saves the status, closing time and received
+	// quantities of order and increments its version. It fails with
+	// ErrPurchaseOrderConflict, changing nothing, unless the stored order
+	// still has order's version.
+	UpdatePurchaseOrder(ctx context.Context, order *models.PurchaseOrder) error
+}
+
+// SalesOrderStore keeps sales orders together with their lines and picks.
+type SalesOrderStore interface {
+	CreateSalesOrder(ctx context.Context, order *models.SalesOrder) (*models.SalesOrder, error)
+	GetSalesOrders(ctx context.Context, query models.SalesOrderQuery) ([]*models.SalesOrder, int64, error)
+	GetSalesOrderByID(ctx context.Context, id string) (*models.SalesOrder, error)
+	// UpdateSalesOrder saves the status, timestamps and picks of order and
+	// increments its version. It fails with ErrSalesOrderConflict, changing
+	// nothing, unless the stored order still has order's version.
+	UpdateSalesOrder(ctx context.Context, order *models.SalesOrder) error
+}
+
+// ReturnStore keeps customer returns together with their lines and entries.
+type ReturnStore interface {
+	CreateReturn(ctx context.Context, ret *models.Return) (*models.Return, error)
+	GetReturns(ctx context.Context, query models.ReturnQuery) ([]*models.Return, int64, error)
+	GetReturnByID(ctx context.Context, id string) (*models.Return, error)
+	// UpdateReturn saves the status, timestamps, line quantities and entries
+	// of ret and increments its version. It fails with ErrReturnConflict,
+	// changing nothing, unless the stored return still has ret's version.
+	UpdateReturn(ctx context.Context, ret *models.Return) error
+}
+
+// BOMStore keeps the bills of materials of kits.
+type BOMStore interface {
+	// SetBOM replaces the bill of materials of the kit parentID with
+	// components, in their order. No components makes the item a plain
+	// item again.
+	SetBOM(ctx context.Context, parentID string, components []*models.BOMComponent) error
+	// GetBOMComponents returns the matching lines, by kit and then in the
+	// order they were set.
+	GetBOMComponents(ctx context.Context, query models.BOMQuery) ([]*models.BOMComponent, error)
+}
+
+// LotStore keeps the lots of lot-tracked items. Deleting an item deletes its
+// lots.
+type LotStore interface {
+	// CreateLot fails with ErrLotExists if the item already has a lot with
+	// lot's number.
+	CreateLot(ctx context.Context, lot *models.Lot) (*models.Lot, error)
+	// GetLots returns a page of the matching lots and their total number.
+	// A zero limit returns every matching lot.
+	GetLots(ctx context.Context, query models.LotQuery) ([]*models.Lot, int64, error)
+	GetLotByID(ctx context.Context, id string) (*models.Lot, error)
+	// AdjustLotStock atomically adds delta to the lot's on-hand quantity. It
+	// fails with ErrInsufficientStock, changing nothing, if the quantity
+	// would drop below the quarantined quantity.
+	AdjustLotStock(ctx context.Context, id string, delta int) (*models.Lot, error)
+	// HoldLotStock atomically adds delta to the lot's quarantined quantity.
+	// It fails with ErrInsufficientStock, changing nothing, if the quantity
+	// would become negative or exceed the on-hand quantity.
+	HoldLotStock(ctx context.Context, id string, delta int) (*models.Lot, error)
+}
+
+// SerialStore keeps the serial number registry of serialized items.
+// Deleting an item deletes its serials.
+type SerialStore interface {
+	// CreateSerial fails with ErrSerialExists if the serial number is
+	// already registered, for any item.
+	CreateSerial(ctx context.Context, serial *models.Serial) (*models.Serial, error)
+	// GetSerials returns a page of the matching serials, by serial number,
+	// and their total number.
+	GetSerials(ctx context.Context, query models.SerialQuery) ([]*models.Serial, int64, error)
+	GetSerialByNumber(ctx context.Context, serialNumber string) (*models.Serial, error)
+	// UpdateSerialStatus moves a serial from status from to status to. It
+	// fails with ErrSerialConflict, changing nothing, if the serial is not
+	// in status from.
+	UpdateSerialStatus(ctx context.Context, id, from, to string, updatedAt time.Time) error
+}
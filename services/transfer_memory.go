@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"main/models"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+func (s *MemoryStore) CreateTransfer(ctx context.Context, transfer *models.Transfer) (*models.Transfer, error) {
+	defer s.lock(ctx)()
+
+	transfer.ID = uuid.New().String()
+	stored := *transfer
+	s.data.transfers[transfer.ID] = &stored
+
+	return transfer, nil
+}
+
+func (s *MemoryStore) GetTransfers(ctx context.Context, query models.TransferQuery) ([]*models.Transfer, int64, error) {
+	defer s.rlock(ctx)()
+
+	var matched []*models.Transfer
+	for _, stored := range s.data.transfers {
+		if query.ItemID != "" && stored.ItemID != query.ItemID || query.Status != "" && stored.Status != query.Status {
+			continue
+		}
+		if query.LocationID != "" && stored.FromLocationID != query.LocationID && stored.ToLocationID != query.LocationID {
+			continue
+		}
+		transfer := *stored
+		matched = append(matched, &transfer)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	totalCount := int64(len(matched))
+
+	if query.Offset >= len(matched) {
+		return nil, totalCount, nil
+	}
+	matched = matched[query.Offset:]
+	if len(matched) > query.Limit {
+		matched = matched[:query.Limit]
+	}
+
+	return matched, totalCount, nil
+}
+
+func (s *MemoryStore) GetTransferByID(ctx context.Context, id string) (*models.Transfer, error) {
+	defer s.rlock(ctx)()
+
+	stored, ok := s.data.transfers[id]
+	if !ok {
+		return nil, ErrTransferNotFound
+	}
+
+	transfer := *stored
+	return &transfer, nil
+}
+
+func (s *MemoryStore) UpdateTransfer(ctx context.Context, transfer *models.Transfer, status string, received int) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.data.transfers[transfer.ID]
+	if !ok || stored.Status != status || stored.Received != received {
+		return ErrTransferConflict
+	}
+
+	stored.Status, stored.Received = transfer.Status, transfer.Received
+	stored.ShippedAt, stored.ReceivedAt = transfer.ShippedAt, transfer.ReceivedAt
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"main/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *MongoStore) transfers() *mongo.Collection {
+	return s.Collection.Database().Collection("transfers")
+}
+
+func (s *MongoStore) CreateTransfer(ctx context.Context, transfer *models.Transfer) (*models.Transfer, error) {
+	transfer.ID = primitive.NewObjectID().Hex()
+	if _, err := s.transfers().InsertOne(ctx, transfer); err != nil {
+		log.Printf("Error inserting transfer: %v", err)
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s *MongoStore) GetTransfers(ctx context.Context, query models.TransferQuery) ([]*models.Transfer, int64, error) {
+	var transfers []*models.Transfer
+
+	filter := bson.M{}
+	if query.ItemID != "" {
+		filter["item_id"] = query.ItemID
+	}
+	if query.LocationID != "" {
+		filter["$or"] = bson.A{bson.M{"from_location_id": query.LocationID}, bson.M{"to_location_id": query.LocationID}}
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	totalCount, err := s.transfers().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(query.Offset)).
+		SetLimit(int64(query.Limit))
+	cursor, err := s.transfers().Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, 0, err
+	}
+
+	return transfers, totalCount, nil
+}
+
+func (s *MongoStore) GetTransferByID(ctx context.Context, id string) (*models.Transfer, error) {
+	var transfer models.Transfer
+
+	if !primitive.IsValidObjectID(id) {
+		return nil, ErrTransferNotFound
+	}
+
+	err := s.transfers().FindOne(ctx, bson.M{"_id": id}).Decode(&transfer)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching transfer: %v", err)
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+func (s *MongoStore) UpdateTransfer(ctx context.Context, transfer *models.Transfer, status string, received int) error {
+	filter := bson.M{"_id": transfer.ID, "status": status, "received": received}
+	update := bson.M{"$set": bson.M{
+		"status":      transfer.Status,
+		"received":    transfer.Received,
+		"shipped_at":  transfer.ShippedAt,
+		"received_at": transfer.ReceivedAt,
+	}}
+
+	result, err := s.transfers().UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.Printf("Error updating transfer: %v", err)
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrTransferConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"main/models"
+
+	"github.com/google/uuid"
+)
+
+const transferColumns = `id, item_id, from_location_id, to_location_id, quantity, received, status,
+	reference, user_name, created_at, shipped_at, received_at`
+
+func (s *PostgresStore) CreateTransfer(ctx context.Context, transfer *models.Transfer) (*models.Transfer, error) {
+	transfer.ID = uuid.New().String()
+
+	query := `INSERT INTO transfers (` + transferColumns + `) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	err := s.conn(ctx).Exec(query, transfer.ID, transfer.ItemID, transfer.FromLocationID, transfer.ToLocationID,
+		transfer.Quantity, transfer.Received, transfer.Status, transfer.Reference, transfer.User,
+		transfer.CreatedAt, transfer.ShippedAt, transfer.ReceivedAt).Error
+	if err != nil {
+		log.Printf("Error inserting transfer: %v", err)
+		return nil, fmt.Errorf("error inserting transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+func (s *PostgresStore) GetTransfers(ctx context.Context, query models.TransferQuery) ([]*models.Transfer, int64, error) {
+	var transfers []*models.Transfer
+	var totalCount int64
+
+	var conditions []string
+	var args []interface{}
+	if query.ItemID != "" {
+		if _, err := uuid.Parse(query.ItemID); err != nil {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "item_id = ?")
+		args = append(args, query.ItemID)
+	}
+	if query.LocationID != "" {
+		if _, err := uuid.Parse(query.LocationID); err != nil {
+			return nil, 0, nil
+		}
+		conditions = append(conditions, "(from_location_id = ? OR to_location_id = ?)")
+		args = append(args, query.LocationID, query.LocationID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	where := whereClause(conditions)
+
+	countQuery := `SELECT COUNT(*) FROM transfers` + where
+	if err := s.conn(ctx).Raw(countQuery, args...).Scan(&totalCount).Error; err != nil {
+		log.Printf("Error counting transfers: %v", err)
+		return nil, 0, err
+	}
+
+	selectQuery := `SELECT ` + transferColumns + ` FROM transfers` + where + ` ORDER BY created_at, id LIMIT ? OFFSET ?`
+	args = append(args, query.Limit, query.Offset)
+	if err := s.conn(ctx).Raw(selectQuery, args...).Scan(&transfers).Error; err != nil {
+		log.Printf("Error fetching transfers: %v", err)
+		return nil, 0, err
+	}
+
+	return transfers, totalCount, nil
+}
+
+func (s *PostgresStore) GetTransferByID(ctx context.Context, id string) (*models.Transfer, error) {
+	var transfer models.Transfer
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrTransferNotFound
+	}
+
+	query := `SELECT ` + transferColumns + ` FROM transfers WHERE id = ?`
+	result := s.conn(ctx).Raw(query, id).Scan(&transfer)
+	if result.Error != nil {
+		log.Printf("Error fetching transfer: %v", result.Error)
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTransferNotFound
+	}
+
+	return &transfer, nil
+}
+
+func (s *PostgresStore) UpdateTransfer(ctx context.Context, transfer *models.Transfer, status string, received int) error {
+	query := `UPDATE transfers SET status = ?, received = ?, shipped_at = ?, received_at = ?
+				WHERE id = ? AND status = ? AND received = ?`
+	result := s.conn(ctx).Exec(query, transfer.Status, transfer.Received, transfer.ShippedAt, transfer.ReceivedAt,
+		transfer.ID, status, received)
+	if result.Error != nil {
+		log.Printf("Error updating transfer: %v", result.Error)
+		return fmt.Errorf("error updating transfer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTransferConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func jsonObject(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &object); err != nil {
+		t.Fatalf("bad test JSON %s: %v", s, err)
+	}
+	return object
+}
+
+// The cases are the examples of RFC 7396, appendix A, whose targets are
+// objects.
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		doc := jsonObject(t, tt.doc)
+		got, err := ApplyMergePatch(doc, []byte(tt.patch))
+		if err != nil {
+			t.Errorf("ApplyMergePatch(%s, %s): %v", tt.doc, tt.patch, err)
+			continue
+		}
+		if want := jsonObject(t, tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("ApplyMergePatch(%s, %s) = %v, want %v", tt.doc, tt.patch, got, want)
+		}
+		if !reflect.DeepEqual(doc, jsonObject(t, tt.doc)) {
+			t.Errorf("ApplyMergePatch(%s, %s) changed the document to %v", tt.doc, tt.patch, doc)
+		}
+	}
+
+	for _, patch := range []string{`["a"]`, `"a"`, `null`, `{"a":`} {
+		if _, err := ApplyMergePatch(map[string]interface{}{}, []byte(patch)); !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("ApplyMergePatch(%s): %v, want ErrInvalidPatch", patch, err)
+		}
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, want string
+		err                    error
+	}{
+		{"add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`, nil},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`, nil},
+		{"append with -", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc"]}]`, `{"foo":["bar",["abc"]]}`, nil},
+		{"add past the end", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":"qux"}]`, "", ErrInvalidPatch},
+		{"remove", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`, nil},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`, nil},
+		{"remove with -", `{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/-"}]`, "", ErrInvalidPatch},
+		{"remove the document", `{"foo":"bar"}`, `[{"op":"remove","path":""}]`, "", ErrInvalidPatch},
+		{"replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`, nil},
+		{"replace missing", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, "", ErrInvalidPatch},
+		{"move", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`, nil},
+		{"move array element", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			`{"foo":["all","cows","eat","grass"]}`, nil},
+		{"move into itself", `{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar"}]`, "", ErrInvalidPatch},
+		{"copy", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`,
+			`{"foo":{"bar":1},"baz":{"bar":2}}`, nil},
+		{"test", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`, nil},
+		{"test failure", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, "", ErrPatchTestFailed},
+		{"test number against string", `{"baz":"10"}`, `[{"op":"test","path":"/baz","value":10}]`, "", ErrPatchTestFailed},
+		{"test missing", `{"baz":"qux"}`, `[{"op":"test","path":"/foo","value":null}]`, "", ErrInvalidPatch},
+		{"escaped slash", `{"a/b":1}`, `[{"op":"replace","path":"/a~1b","value":2}]`, `{"a/b":2}`, nil},
+		{"escaped tilde", `{"m~n":1}`, `[{"op":"remove","path":"/m~0n"}]`, `{}`, nil},
+		{"escapes decoded once", `{"~1":1}`, `[{"op":"test","path":"/~01","value":1}]`, `{"~1":1}`, nil},
+		{"leading zero index", `{"foo":["a","b"]}`, `[{"op":"remove","path":"/foo/01"}]`, "", ErrInvalidPatch},
+		{"relative path", `{"foo":1}`, `[{"op":"remove","path":"foo"}]`, "", ErrInvalidPatch},
+		{"missing value", `{"foo":1}`, `[{"op":"add","path":"/bar"}]`, "", ErrInvalidPatch},
+		{"unknown op", `{"foo":1}`, `[{"op":"increment","path":"/foo"}]`, "", ErrInvalidPatch},
+		{"not an array", `{"foo":1}`, `{"op":"remove","path":"/foo"}`, "", ErrInvalidPatch},
+		{"replace the document", `{"foo":1}`, `[{"op":"replace","path":"","value":["bar"]}]`, "", ErrInvalidPatch},
+	}
+	for _, tt := range tests {
+		doc := jsonObject(t, tt.doc)
+		got, err := ApplyJSONPatch(doc, []byte(tt.patch))
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s: %v, want %v", tt.name, err, tt.err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if want := jsonObject(t, tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+		if !reflect.DeepEqual(doc, jsonObject(t, tt.doc)) {
+			t.Errorf("%s: the document changed to %v", tt.name, doc)
+		}
+	}
+}